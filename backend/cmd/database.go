@@ -0,0 +1,101 @@
+// backend/cmd/database.go
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/config"
+	"github.com/Kurs-24-06/aegis/backend/internal/migrations"
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/Kurs-24-06/aegis/backend/internal/simulation"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openDatabase connects to the database selected by database.type.
+// It returns a nil database for the in-memory store.
+func openDatabase(cfg *config.Config) (*sql.DB, migrations.Dialect, error) {
+	switch strings.ToLower(cfg.Database.Type) {
+	case "", "memory":
+		return nil, "", nil
+
+	case "postgres", "postgresql":
+		dsn := url.URL{
+			Scheme: "postgres",
+			User:   url.UserPassword(cfg.Database.User, cfg.Database.Password),
+			Host:   net.JoinHostPort(cfg.Database.Host, strconv.Itoa(cfg.Database.Port)),
+			Path:   cfg.Database.Name,
+		}
+		if cfg.Database.SSLMode != "" {
+			dsn.RawQuery = url.Values{"sslmode": {cfg.Database.SSLMode}}.Encode()
+		}
+
+		db, err := sql.Open("postgres", dsn.String())
+		if err != nil {
+			return nil, "", err
+		}
+		if err := db.Ping(); err != nil {
+			db.Close()
+			return nil, "", fmt.Errorf("could not connect to %s: %w", dsn.Host, err)
+		}
+		logging.Logger.Infof("Connected to Postgres at %s/%s", dsn.Host, cfg.Database.Name)
+		return db, migrations.Postgres, nil
+
+	case "sqlite", "sqlite3":
+		path := cfg.Database.Path
+		if path == "" {
+			path = "aegis.db"
+		}
+
+		db, err := sql.Open("sqlite3", simulation.SQLiteDSN(path))
+		if err != nil {
+			return nil, "", err
+		}
+		// SQLite allows a single writer; see simulation.NewSQLiteRepository
+		db.SetMaxOpenConns(1)
+		logging.Logger.Infof("Using SQLite database at %s", path)
+		return db, migrations.SQLite, nil
+
+	default:
+		return nil, "", fmt.Errorf("unsupported database type %q", cfg.Database.Type)
+	}
+}
+
+// openSimulationStore selects the simulation store based on database.type and
+// brings the database schema up to date. The returned closer is nil for stores
+// without a connection.
+func openSimulationStore(cfg *config.Config) (simulation.Store, io.Closer, error) {
+	db, dialect, err := openDatabase(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if db == nil {
+		logging.Logger.Warn("Using in-memory simulation store, simulations are lost on restart")
+		return simulation.NewMemoryStore(), nil, nil
+	}
+
+	migrator, err := migrations.NewMigrator(db, dialect)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	applied, err := migrator.Up()
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	for _, migration := range applied {
+		logging.Logger.Infof("Applied migration %d_%s", migration.Version, migration.Name)
+	}
+
+	if dialect == migrations.SQLite {
+		return simulation.NewSQLiteRepository(db), db, nil
+	}
+	return simulation.NewRepository(db), db, nil
+}
@@ -7,12 +7,14 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Kurs-24-06/aegis/backend/internal/api"
 	"github.com/Kurs-24-06/aegis/backend/internal/config"
 	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
 	"github.com/Kurs-24-06/aegis/backend/internal/observability/metrics"
 	"github.com/Kurs-24-06/aegis/backend/internal/observability/tracing"
+	"github.com/Kurs-24-06/aegis/backend/internal/simulation"
 	"github.com/rs/cors"
 )
 
@@ -32,16 +34,16 @@ func main() {
 	logging.InitLogger(cfg.Logging.Level, cfg.Logging.Format)
 	logging.Logger.Info("Logging initialized")
 
-	// Initialize tracing
-	_, closer, err := tracing.InitTracer("aegis-backend")
+	// "aegis migrate <status|up|down>" manages the database schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(cfg, os.Args[2:]))
+	}
+
+	// Initialize tracing; the tracer is closed during shutdown
+	_, tracerCloser, err := tracing.InitTracer("aegis-backend")
 	if err != nil {
 		logging.Logger.Warnf("Could not initialize tracer: %v", err)
-	} else {
-		defer func() {
-			if err := closer.Close(); err != nil {
-				logging.Logger.Errorf("Error closing tracer: %v", err)
-			}
-		}()
+		tracerCloser = nil
 	}
 
 	// Server configuration
@@ -56,8 +58,48 @@ func main() {
 	metricsHandler := metrics.MetricsHandler()
 	metrics.SetVersion(version)
 
+	// Open the store that keeps simulations across restarts
+	store, storeCloser, err := openSimulationStore(cfg)
+	if err != nil {
+		logging.Logger.Fatalf("Error opening simulation store: %v", err)
+	}
+
+	// Initialize simulation service with the configured store, worker pool, timeout
+	// and retention policy
+	retention := cfg.Simulation.Retention
+	simService := simulation.InitService(
+		simulation.WithStore(store),
+		simulation.WithWorkerPool(cfg.Simulation.WorkerCount, cfg.Simulation.BufferSize),
+		simulation.WithDefaultTimeout(time.Duration(cfg.Simulation.DefaultTimeoutSeconds)*time.Second),
+		simulation.WithJanitor(
+			time.Duration(retention.JanitorIntervalMinutes)*time.Minute,
+			simulation.RetentionPolicy{
+				EventsAfter:      time.Duration(retention.EventsAfterDays) * 24 * time.Hour,
+				SimulationsAfter: time.Duration(retention.SimulationsAfterDays) * 24 * time.Hour,
+			},
+		),
+	)
+	logging.Logger.Infof("Simulation worker pool: %d workers, queue size %d, timeout %ds",
+		cfg.Simulation.WorkerCount, cfg.Simulation.BufferSize, cfg.Simulation.DefaultTimeoutSeconds)
+	logging.Logger.Infof("Simulation retention: events %d days, simulations %d days (0 keeps forever)",
+		retention.EventsAfterDays, retention.SimulationsAfterDays)
+
+	// Load the scenario files kept alongside the server, e.g. from the red team's repository
+	if dir := cfg.Simulation.ScenarioDirectory; dir != "" {
+		if _, err := simService.LoadScenarioDirectory(dir); err != nil {
+			logging.Logger.Warnf("Scenario files not loaded: %v", err)
+		}
+	}
+
+	// Resume simulations that were still running when the server last stopped
+	simService.ResumeInterrupted()
+
+	// Run scheduled simulations; schedules missed while the server was down run once now
+	simService.StartSchedules(time.Duration(cfg.Simulation.ScheduleIntervalSeconds) * time.Second)
+	logging.Logger.Infof("Simulation schedules checked every %ds (0 disables schedules)", cfg.Simulation.ScheduleIntervalSeconds)
+
 	// Initialize API router
-	apiRouter := api.NewAPIRouter()
+	apiRouter := api.NewAPIRouter(api.WithAllowedOrigins(cfg.Server.CORS.AllowedOrigins))
 
 	// Set up main router - HIER WAR DAS PROBLEM!
 	mainRouter := http.NewServeMux()
@@ -86,9 +128,8 @@ func main() {
 	logging.Logger.Infof("Server starting on %s in %s mode", addr, getEnvironmentName())
 	logging.Logger.Infof("Version: %s", version)
 
-	if err := http.ListenAndServe(addr, corsHandler(mainRouter)); err != nil {
-		logging.Logger.Fatalf("Error starting server: %v", err)
-	}
+	server := newHTTPServer(cfg, addr, corsHandler(mainRouter))
+	os.Exit(runServer(cfg, server, simService, storeCloser, tracerCloser))
 }
 
 // setupCORS configures CORS
@@ -0,0 +1,113 @@
+// backend/cmd/server.go
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/config"
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/Kurs-24-06/aegis/backend/internal/simulation"
+)
+
+// Defaults for server timeouts that are not configured
+const (
+	defaultReadTimeout     = 15 * time.Second
+	defaultWriteTimeout    = 30 * time.Second
+	defaultIdleTimeout     = 60 * time.Second
+	defaultShutdownTimeout = 30 * time.Second
+)
+
+// secondsOr converts a configured number of seconds, falling back to a default for values <= 0
+func secondsOr(seconds int, fallback time.Duration) time.Duration {
+	if seconds <= 0 {
+		return fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// newHTTPServer creates the HTTP server with the configured timeouts. Event streams
+// lift the write deadline for their own connection.
+func newHTTPServer(cfg *config.Config, addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		ReadTimeout:  secondsOr(cfg.Server.ReadTimeoutSeconds, defaultReadTimeout),
+		WriteTimeout: secondsOr(cfg.Server.WriteTimeoutSeconds, defaultWriteTimeout),
+		IdleTimeout:  secondsOr(cfg.Server.IdleTimeoutSeconds, defaultIdleTimeout),
+	}
+}
+
+// runServer serves HTTP until SIGINT or SIGTERM arrives and then shuts everything
+// down in order: drain in-flight requests, checkpoint running simulations, close the
+// store and the tracer. All steps share one deadline. Returns the process exit code.
+func runServer(cfg *config.Config, server *http.Server, simService *simulation.Service, storeCloser, tracerCloser io.Closer) int {
+	// Cancelling the base context ends long-lived requests such as event streams,
+	// which Shutdown would otherwise wait for until the deadline
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	server.BaseContext = func(net.Listener) context.Context { return baseCtx }
+	server.RegisterOnShutdown(cancelRequests)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	exitCode := 0
+	select {
+	case err := <-serverErr:
+		logging.Logger.Errorf("Error starting server: %v", err)
+		exitCode = 1
+	case sig := <-signals:
+		logging.Logger.Infof("Received %s, shutting down", sig)
+	}
+
+	timeout := secondsOr(cfg.Server.ShutdownTimeoutSeconds, defaultShutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	logging.Logger.Infof("Graceful shutdown started (deadline %s)", timeout)
+
+	logging.Logger.Info("Draining in-flight HTTP requests")
+	if err := server.Shutdown(ctx); err != nil {
+		logging.Logger.Errorf("HTTP server did not shut down cleanly: %v", err)
+		exitCode = 1
+	}
+
+	logging.Logger.Info("Checkpointing running simulations")
+	if err := simService.Shutdown(ctx); err != nil {
+		exitCode = 1
+	}
+
+	if storeCloser != nil {
+		logging.Logger.Info("Closing simulation store")
+		if err := storeCloser.Close(); err != nil {
+			logging.Logger.Errorf("Error closing simulation store: %v", err)
+			exitCode = 1
+		}
+	}
+
+	if tracerCloser != nil {
+		logging.Logger.Info("Closing tracer")
+		if err := tracerCloser.Close(); err != nil {
+			logging.Logger.Errorf("Error closing tracer: %v", err)
+			exitCode = 1
+		}
+	}
+
+	logging.Logger.Info("Shutdown complete")
+	return exitCode
+}
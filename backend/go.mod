@@ -4,7 +4,11 @@ go 1.23
 
 require (
 	github.com/google/uuid v1.6.0
+	github.com/gorilla/websocket v1.5.3
+	github.com/lib/pq v1.10.9
+	github.com/mattn/go-sqlite3 v1.14.33
 	github.com/opentracing/opentracing-go v1.2.0
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/uber/jaeger-client-go v2.30.0+incompatible
 )
 
@@ -30,9 +30,10 @@ func (api *APIRouter) getInfrastructureDetailsHandler(w http.ResponseWriter, r *
 	id := vars["id"]
 
 	// In einer realen Implementierung würden hier die Daten aus der Datenbank abgerufen werden
-	// Für den Prototypen verwenden wir generierte Daten
+	// Für den Prototypen verwenden wir generierte Daten, die für dieselbe ID stabil sind
+	// und damit dem Netzwerk entsprechen, auf dem die Simulations-Engine angreift
 
-	infraData := simulation.GenerateMockInfrastructure()
+	infraData := simulation.MockInfrastructureFor(id)
 
 	// Füge die ID hinzu
 	infraData["id"] = id
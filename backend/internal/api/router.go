@@ -3,9 +3,14 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Kurs-24-06/aegis/backend/internal/config"
@@ -19,12 +24,25 @@ type Response struct {
 	Status  string      `json:"status"`
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"` // e.g. cursors of a paginated list
 	Error   string      `json:"error,omitempty"`
 }
 
 // APIRouter defines the HTTP endpoints for the AEGIS API
 type APIRouter struct {
-	router *mux.Router
+	router         *mux.Router
+	allowedOrigins []string
+}
+
+// RouterOption configures an APIRouter
+type RouterOption func(*APIRouter)
+
+// WithAllowedOrigins sets the origins that may open WebSocket connections.
+// "*" allows any origin; requests without an Origin header are always allowed.
+func WithAllowedOrigins(origins []string) RouterOption {
+	return func(api *APIRouter) {
+		api.allowedOrigins = origins
+	}
 }
 
 func errorMiddleware(next http.Handler) http.Handler {
@@ -44,9 +62,12 @@ func errorMiddleware(next http.Handler) http.Handler {
 }
 
 // NewAPIRouter creates a new API router
-func NewAPIRouter() *APIRouter {
+func NewAPIRouter(opts ...RouterOption) *APIRouter {
     router := mux.NewRouter().PathPrefix("/api").Subrouter()
     api := &APIRouter{router: router}
+    for _, opt := range opts {
+        opt(api)
+    }
     
     // Füge die Error-Middleware zum Router hinzu
     router.Use(errorMiddleware)
@@ -82,21 +103,44 @@ func NewAPIRouter() *APIRouter {
     
     // Simulation endpoints
     router.HandleFunc("/simulations", api.getSimulationsHandler).Methods("GET")
+    router.HandleFunc("/simulations/compare", api.compareSimulationsHandler).Methods("GET")
     router.HandleFunc("/simulations/{id}", api.getSimulationHandler).Methods("GET")
     router.HandleFunc("/simulations", api.createSimulationHandler).Methods("POST")
+    router.HandleFunc("/simulations/{id}", api.deleteSimulationHandler).Methods("DELETE")
     router.HandleFunc("/simulations/{id}/start", api.startSimulationHandler).Methods("POST")
     router.HandleFunc("/simulations/{id}/stop", api.stopSimulationHandler).Methods("POST")
     router.HandleFunc("/simulations/{id}/pause", api.pauseSimulationHandler).Methods("POST")
+    router.HandleFunc("/simulations/{id}/resume", api.resumeSimulationHandler).Methods("POST")
+    router.HandleFunc("/simulations/{id}/archive", api.archiveSimulationHandler).Methods("POST")
+    router.HandleFunc("/simulations/{id}/unarchive", api.unarchiveSimulationHandler).Methods("POST")
+    router.HandleFunc("/simulations/{id}/clone", api.cloneSimulationHandler).Methods("POST")
+    router.HandleFunc("/simulations/{id}/runs", api.getSimulationRunsHandler).Methods("GET")
+    router.HandleFunc("/simulations/{id}/runs/{runId}", api.getSimulationRunHandler).Methods("GET")
+    router.HandleFunc("/simulations/{id}/runs/{runId}/events", api.getSimulationRunEventsHandler).Methods("GET")
+    router.HandleFunc("/simulations/{id}/runs/{runId}/resources", api.getSimulationRunResourcesHandler).Methods("GET")
+    router.HandleFunc("/simulations/{id}/ws", api.simulationWebSocketHandler).Methods("GET")
+    
+    // Schedule endpoints
+    router.HandleFunc("/schedules", api.getSchedulesHandler).Methods("GET")
+    router.HandleFunc("/schedules", api.createScheduleHandler).Methods("POST")
+    router.HandleFunc("/schedules/{id}", api.getScheduleHandler).Methods("GET")
+    router.HandleFunc("/schedules/{id}", api.updateScheduleHandler).Methods("PUT")
+    router.HandleFunc("/schedules/{id}", api.deleteScheduleHandler).Methods("DELETE")
     
     // Monitoring endpoints
     router.HandleFunc("/monitoring/simulations/{id}/status", api.getSimulationStatusHandler).Methods("GET")
     router.HandleFunc("/monitoring/simulations/{id}/events", api.getSimulationEventsHandler).Methods("GET")
     router.HandleFunc("/monitoring/simulations/{id}/resources", api.getAffectedResourcesHandler).Methods("GET")
+    router.HandleFunc("/monitoring/simulations/{id}/stream", api.streamSimulationHandler).Methods("GET")
     
     // Scenario endpoints - verwende existierende Handler
     router.HandleFunc("/scenarios", api.getScenariosHandler).Methods("GET")
     router.HandleFunc("/scenarios/{id}", api.getScenarioHandler).Methods("GET")
     router.HandleFunc("/scenarios", api.createScenarioHandler).Methods("POST")
+    router.HandleFunc("/scenarios/import", api.importScenarioHandler).Methods("POST")
+    router.HandleFunc("/scenarios/{id}/export", api.exportScenarioHandler).Methods("GET")
+    router.HandleFunc("/scenarios/{id}", api.updateScenarioHandler).Methods("PUT")
+    router.HandleFunc("/scenarios/{id}", api.deleteScenarioHandler).Methods("DELETE")
     
     // Initialisiere den Simulations-Service mit Beispieldaten für die Entwicklung
     if os.Getenv("ENVIRONMENT") == "development" {
@@ -148,17 +192,135 @@ func (api *APIRouter) versionHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // Simulation handlers - NUR DIE, DIE NICHT IN ANDEREN DATEIEN SIND
+// Limits for the number of simulations per page
+const (
+	defaultSimulationLimit = 50
+	maxSimulationLimit     = 500
+)
+
+// getSimulationsHandler lists simulations. Query parameters: sort (createdAt,
+// updatedAt, name, status) and order (asc, desc); filters status (repeatable or
+// comma-separated), scenarioId, infrastructureId, createdAfter/createdBefore
+// (RFC 3339), search (part of the name) and archived (false by default, true or
+// all); paging via page and limit or via the cursor from meta.nextCursor.
+// meta.total counts all matching simulations.
 func (api *APIRouter) getSimulationsHandler(w http.ResponseWriter, r *http.Request) {
+	query, page, err := parseSimulationQuery(r)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	simService := simulation.GetService()
-	simulations := simService.GetSimulations()
+	result, err := simService.QuerySimulations(query)
+	if errors.Is(err, simulation.ErrInvalidCursor) {
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid cursor")
+		return
+	} else if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 	
+	meta := map[string]interface{}{
+		"total": result.Total,
+		"limit": query.Limit,
+	}
+	if page > 0 {
+		meta["page"] = page
+	}
+	if result.NextCursor != "" {
+		meta["nextCursor"] = result.NextCursor
+	}
 	response := Response{
 		Status: "success",
-		Data:   simulations,
+		Data:   result.Simulations,
+		Meta:   meta,
 	}
 	writeJSONResponse(w, http.StatusOK, response)
 }
 
+// parseSimulationQuery reads sorting, filters and paging of the simulation list.
+// The returned page is 0 when paging by cursor.
+func parseSimulationQuery(r *http.Request) (simulation.SimulationQuery, int, error) {
+	values := r.URL.Query()
+	query := simulation.SimulationQuery{Limit: defaultSimulationLimit}
+
+	if sort := values.Get("sort"); sort != "" {
+		query.Sort = simulation.SimulationSort(sort)
+		if !simulation.ValidSimulationSort(query.Sort) {
+			return query, 0, fmt.Errorf("Invalid sort %q", sort)
+		}
+	}
+	switch order := values.Get("order"); order {
+	case "":
+		// Dates newest first, names and statuses alphabetically
+		query.Descending = query.Sort == "" || query.Sort == simulation.SortCreatedAt || query.Sort == simulation.SortUpdatedAt
+	case "asc", "desc":
+		query.Descending = order == "desc"
+	default:
+		return query, 0, fmt.Errorf("Invalid order %q, expected asc or desc", order)
+	}
+
+	for _, status := range queryValues(values, "status") {
+		query.Statuses = append(query.Statuses, simulation.Status(status))
+	}
+	query.ScenarioID = values.Get("scenarioId")
+	query.InfrastructureID = values.Get("infrastructureId")
+	query.Search = strings.TrimSpace(values.Get("search"))
+	switch archived := values.Get("archived"); archived {
+	case "all":
+	case "", "false", "true":
+		onlyArchived := archived == "true"
+		query.Archived = &onlyArchived
+	default:
+		return query, 0, fmt.Errorf("Invalid archived %q, expected true, false or all", archived)
+	}
+	for _, bound := range []struct {
+		name   string
+		target **time.Time
+	}{{"createdAfter", &query.CreatedAfter}, {"createdBefore", &query.CreatedBefore}} {
+		value := values.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return query, 0, fmt.Errorf("Invalid %s, expected RFC 3339 timestamp", bound.name)
+		}
+		*bound.target = &parsed
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			return query, 0, fmt.Errorf("Invalid limit %q", limit)
+		}
+		query.Limit = parsed
+	}
+	if query.Limit > maxSimulationLimit {
+		query.Limit = maxSimulationLimit
+	}
+
+	query.Cursor = values.Get("cursor")
+	page := 0
+	if pageValue := values.Get("page"); pageValue != "" {
+		if query.Cursor != "" {
+			return query, 0, fmt.Errorf("Use either page or cursor, not both")
+		}
+		parsed, err := strconv.Atoi(pageValue)
+		if err != nil || parsed <= 0 {
+			return query, 0, fmt.Errorf("Invalid page %q", pageValue)
+		}
+		page = parsed
+	} else if query.Cursor == "" {
+		page = 1
+	}
+	if page > 0 {
+		query.Offset = (page - 1) * query.Limit
+	}
+	return query, page, nil
+}
+
 func (api *APIRouter) getSimulationHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
@@ -187,7 +349,10 @@ func (api *APIRouter) createSimulationHandler(w http.ResponseWriter, r *http.Req
 	
 	simService := simulation.GetService()
 	sim, err := simService.CreateSimulation(config)
-	if err != nil {
+	if errors.Is(err, simulation.ErrInvalidParameter) {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
 		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -206,11 +371,25 @@ func (api *APIRouter) startSimulationHandler(w http.ResponseWriter, r *http.Requ
 	
 	simService := simulation.GetService()
 	sim, err := simService.StartSimulation(id)
-	if err != nil {
+	if errors.Is(err, simulation.ErrQueueFull) {
+		writeErrorResponse(w, http.StatusTooManyRequests, err.Error())
+		return
+	} else if err != nil {
 		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 	
+	// Wartende Simulationen werden gestartet, sobald ein Worker frei wird
+	if sim.Status == simulation.StatusQueued {
+		response := Response{
+			Status:  "success",
+			Message: fmt.Sprintf("Simulation queued at position %d", sim.QueuePosition),
+			Data:    sim,
+		}
+		writeJSONResponse(w, http.StatusAccepted, response)
+		return
+	}
+	
 	response := Response{
 		Status:  "success",
 		Message: "Simulation started successfully",
@@ -257,38 +436,368 @@ func (api *APIRouter) pauseSimulationHandler(w http.ResponseWriter, r *http.Requ
 	writeJSONResponse(w, http.StatusOK, response)
 }
 
+func (api *APIRouter) resumeSimulationHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	
+	simService := simulation.GetService()
+	sim, err := simService.ResumeSimulation(id)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	
+	response := Response{
+		Status:  "success",
+		Message: "Simulation resumed successfully",
+		Data:    sim,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// deleteSimulationHandler deletes a simulation with its events and resources.
+// Active simulations are only deleted with force=true and are stopped first.
+func (api *APIRouter) deleteSimulationHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	force := r.URL.Query().Get("force") == "true"
+
+	simService := simulation.GetService()
+	if err := simService.DeleteSimulation(id, force); err != nil {
+		writeSimulationStateError(w, err)
+		return
+	}
+
+	response := Response{
+		Status:  "success",
+		Message: "Simulation deleted successfully",
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// archiveSimulationHandler hides a finished simulation from the default listing
+func (api *APIRouter) archiveSimulationHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	simService := simulation.GetService()
+	sim, err := simService.ArchiveSimulation(id)
+	if err != nil {
+		writeSimulationStateError(w, err)
+		return
+	}
+
+	response := Response{
+		Status:  "success",
+		Message: "Simulation archived successfully",
+		Data:    sim,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// unarchiveSimulationHandler returns an archived simulation to the default listing
+func (api *APIRouter) unarchiveSimulationHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	simService := simulation.GetService()
+	sim, err := simService.UnarchiveSimulation(id)
+	if err != nil {
+		writeSimulationStateError(w, err)
+		return
+	}
+
+	response := Response{
+		Status:  "success",
+		Message: "Simulation unarchived successfully",
+		Data:    sim,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// cloneSimulationHandler creates a new simulation from the configuration of an existing
+// one. The optional body overrides name, description, infrastructureId, scenarioId and
+// single parameters; a parameter set to null is removed. Without a seed override the
+// clone reuses the seed of the source's current run.
+func (api *APIRouter) cloneSimulationHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	// An empty body clones without overrides
+	var overrides simulation.SimulationConfig
+	if err := json.NewDecoder(r.Body).Decode(&overrides); err != nil && err != io.EOF {
+		logging.Logger.Errorf("Error parsing clone overrides: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	simService := simulation.GetService()
+	sim, err := simService.CloneSimulation(id, overrides)
+	if errors.Is(err, simulation.ErrSimulationNotFound) {
+		writeErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	} else if errors.Is(err, simulation.ErrInvalidParameter) {
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := Response{
+		Status:  "success",
+		Message: "Simulation cloned successfully",
+		Data:    sim,
+	}
+	writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// getSimulationRunsHandler lists all runs of a simulation, the first run first
+func (api *APIRouter) getSimulationRunsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	simService := simulation.GetService()
+	runs, err := simService.GetRuns(id)
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+
+	response := Response{
+		Status: "success",
+		Data:   runs,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// getSimulationRunHandler returns one run of a simulation with its results
+func (api *APIRouter) getSimulationRunHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	simService := simulation.GetService()
+	run, err := simService.GetRun(vars["id"], vars["runId"])
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+
+	response := Response{
+		Status: "success",
+		Data:   run,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// getSimulationRunEventsHandler returns all events of one run, oldest first
+func (api *APIRouter) getSimulationRunEventsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	simService := simulation.GetService()
+	events, err := simService.GetRunEvents(vars["id"], vars["runId"])
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+
+	response := Response{
+		Status: "success",
+		Data:   events,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// getSimulationRunResourcesHandler returns the affected resources of one run
+func (api *APIRouter) getSimulationRunResourcesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	simService := simulation.GetService()
+	resources, err := simService.GetRunResources(vars["id"], vars["runId"])
+	if err != nil {
+		writeRunError(w, err)
+		return
+	}
+
+	response := Response{
+		Status: "success",
+		Data:   resources,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// compareSimulationsHandler compares two finished runs on the same infrastructure.
+// Query parameters: a and b (simulation IDs, a is the baseline) and optionally aRun
+// and bRun to pick a run other than the current one, e.g. two runs of one simulation.
+// The result lists resources compromised in only one run, phase reach, severity
+// distribution, detection rate and time to first compromise, each rated from b's
+// point of view, and a verdict (improvement, regression, mixed or unchanged).
+func (api *APIRouter) compareSimulationsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	a := simulation.RunSelector{SimulationID: query.Get("a"), RunID: query.Get("aRun")}
+	b := simulation.RunSelector{SimulationID: query.Get("b"), RunID: query.Get("bRun")}
+	if a.SimulationID == "" || b.SimulationID == "" {
+		writeErrorResponse(w, http.StatusBadRequest, "Query parameters a and b are required")
+		return
+	}
+
+	simService := simulation.GetService()
+	comparison, err := simService.CompareRuns(a, b)
+	switch {
+	case errors.Is(err, simulation.ErrSimulationNotFound), errors.Is(err, simulation.ErrRunNotFound):
+		writeErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, simulation.ErrInvalidParameter):
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, simulation.ErrRunNotFinished):
+		writeErrorResponse(w, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := Response{
+		Status: "success",
+		Data:   comparison,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// writeRunError maps errors of the run endpoints
+func writeRunError(w http.ResponseWriter, err error) {
+	if errors.Is(err, simulation.ErrSimulationNotFound) || errors.Is(err, simulation.ErrRunNotFound) {
+		writeErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+}
+
+// writeSimulationStateError maps errors of actions that require a finished simulation
+func writeSimulationStateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, simulation.ErrSimulationNotFound):
+		writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, simulation.ErrSimulationActive):
+		writeErrorResponse(w, http.StatusConflict, err.Error())
+	default:
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
 // Monitoring handlers - NUR DIE, DIE NICHT IN ANDEREN DATEIEN SIND
+// Limits for the number of events per page
+const (
+	defaultEventLimit = 20
+	maxEventLimit     = 1000
+)
+
+// getSimulationEventsHandler returns one page of a simulation's events, oldest first.
+// Query parameters: type and severity (repeatable or comma-separated), minSeverity,
+// resourceId, since/until (RFC 3339), limit and cursor. Without a cursor the latest
+// events are returned; meta.nextCursor and meta.prevCursor page forward and backward.
 func (api *APIRouter) getSimulationEventsHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 	
 	// Parse limit query parameter
-	limit := 20 // Default limit
+	limit := defaultEventLimit
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
 			limit = parsedLimit
 		}
 	}
-	
-	simService := simulation.GetService()
-	events, err := simService.GetEvents(id)
+	if limit > maxEventLimit {
+		limit = maxEventLimit
+	}
+
+	filter, err := parseEventFilter(r)
 	if err != nil {
-		writeErrorResponse(w, http.StatusNotFound, err.Error())
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	
-	// Limit the number of events if needed
-	if len(events) > limit {
-		events = events[len(events)-limit:]
+	simService := simulation.GetService()
+	page, err := simService.QueryEvents(id, filter, r.URL.Query().Get("cursor"), limit)
+	switch {
+	case errors.Is(err, simulation.ErrInvalidCursor):
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid cursor")
+		return
+	case errors.Is(err, simulation.ErrSimulationNotFound):
+		writeErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
 	}
 	
 	response := Response{
 		Status: "success",
-		Data:   events,
+		Data:   page.Events,
+		Meta: map[string]string{
+			"nextCursor": page.NextCursor,
+			"prevCursor": page.PrevCursor,
+		},
 	}
 	writeJSONResponse(w, http.StatusOK, response)
 }
 
+// parseEventFilter reads the event filter from the query parameters
+func parseEventFilter(r *http.Request) (simulation.EventFilter, error) {
+	query := r.URL.Query()
+	var filter simulation.EventFilter
+
+	for _, eventType := range queryValues(query, "type") {
+		if !simulation.ValidEventType(simulation.EventType(eventType)) {
+			return filter, fmt.Errorf("Invalid type %q", eventType)
+		}
+		filter.Types = append(filter.Types, simulation.EventType(eventType))
+	}
+	for _, severity := range queryValues(query, "severity") {
+		if !simulation.ValidSeverity(simulation.Severity(severity)) {
+			return filter, fmt.Errorf("Invalid severity %q", severity)
+		}
+		filter.Severities = append(filter.Severities, simulation.Severity(severity))
+	}
+	if minSeverity := query.Get("minSeverity"); minSeverity != "" {
+		if !simulation.ValidSeverity(simulation.Severity(minSeverity)) {
+			return filter, fmt.Errorf("Invalid minSeverity %q", minSeverity)
+		}
+		filter.MinSeverity = simulation.Severity(minSeverity)
+	}
+	filter.ResourceID = query.Get("resourceId")
+
+	for _, bound := range []struct {
+		name   string
+		target **time.Time
+	}{{"since", &filter.Since}, {"until", &filter.Until}} {
+		value := query.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("Invalid %s, expected RFC 3339 timestamp", bound.name)
+		}
+		*bound.target = &parsed
+	}
+	return filter, nil
+}
+
+// queryValues returns all values of a repeatable query parameter, also splitting
+// comma-separated lists
+func queryValues(query url.Values, name string) []string {
+	var values []string
+	for _, value := range query[name] {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
+
 func (api *APIRouter) getAffectedResourcesHandler(w http.ResponseWriter, r *http.Request) {
     vars := mux.Vars(r)
     id := vars["id"]
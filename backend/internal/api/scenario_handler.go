@@ -3,20 +3,25 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
-	"time"
 
 	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
 	"github.com/Kurs-24-06/aegis/backend/internal/simulation"
-	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
-// getScenariosHandler gibt alle verfügbaren Szenarien zurück
+// getScenariosHandler gibt alle verfügbaren Szenarien zurück, die mitgelieferten zuerst
 func (api *APIRouter) getScenariosHandler(w http.ResponseWriter, r *http.Request) {
-	// Mock-Daten aus dem Simulationsmodul abrufen
-	scenarios := simulation.GenerateMockSimulationScenarios()
-	
+	simService := simulation.GetService()
+	scenarios, err := simService.GetScenarios()
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	response := Response{
 		Status: "success",
 		Data:   scenarios,
@@ -27,25 +32,14 @@ func (api *APIRouter) getScenariosHandler(w http.ResponseWriter, r *http.Request
 // getScenarioHandler gibt ein bestimmtes Szenario zurück
 func (api *APIRouter) getScenarioHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	id := vars["id"]
-	
-	// Mock-Daten abrufen
-	scenarios := simulation.GenerateMockSimulationScenarios()
-	
-	// Suche nach dem angeforderten Szenario
-	var scenario map[string]interface{}
-	for _, s := range scenarios {
-		if s["id"] == id {
-			scenario = s
-			break
-		}
-	}
-	
-	if scenario == nil {
-		writeErrorResponse(w, http.StatusNotFound, "Scenario not found")
+
+	simService := simulation.GetService()
+	scenario, err := simService.GetScenario(vars["id"])
+	if err != nil {
+		writeScenarioError(w, err)
 		return
 	}
-	
+
 	response := Response{
 		Status: "success",
 		Data:   scenario,
@@ -53,33 +47,142 @@ func (api *APIRouter) getScenarioHandler(w http.ResponseWriter, r *http.Request)
 	writeJSONResponse(w, http.StatusOK, response)
 }
 
-// createScenarioHandler erstellt ein neues Szenario
+// createScenarioHandler erstellt ein neues Szenario. ID, Dauer und Nummerierung der
+// Schritte werden vergeben.
 func (api *APIRouter) createScenarioHandler(w http.ResponseWriter, r *http.Request) {
-	var requestData map[string]interface{}
-	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
-		logging.Logger.Errorf("Error parsing request: %v", err)
+	var scenario simulation.Scenario
+	if err := json.NewDecoder(r.Body).Decode(&scenario); err != nil {
+		logging.Logger.Errorf("Error parsing scenario: %v", err)
 		writeErrorResponse(w, http.StatusBadRequest, "Invalid request format")
 		return
 	}
-	
-	// Generiere eine ID für das neue Szenario
-	id := "scenario-" + uuid.New().String()[0:8]
-	
-	// Erstelle das neue Szenario mit den angegebenen Daten
-	scenario := map[string]interface{}{
-		"id":          id,
-		"name":        requestData["name"],
-		"description": requestData["description"],
-		"difficulty":  requestData["difficulty"],
-		"duration":    requestData["duration"],
-		"steps":       requestData["steps"],
-		"createdAt":   time.Now().Format(time.RFC3339),
-	}
-	
+
+	simService := simulation.GetService()
+	created, err := simService.CreateScenario(scenario)
+	if err != nil {
+		writeScenarioError(w, err)
+		return
+	}
+
 	response := Response{
 		Status:  "success",
 		Message: "Scenario created successfully",
-		Data:    scenario,
+		Data:    created,
 	}
 	writeJSONResponse(w, http.StatusCreated, response)
-}
\ No newline at end of file
+}
+
+// updateScenarioHandler ersetzt ein eigenes Szenario; mitgelieferte sind schreibgeschützt
+func (api *APIRouter) updateScenarioHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var scenario simulation.Scenario
+	if err := json.NewDecoder(r.Body).Decode(&scenario); err != nil {
+		logging.Logger.Errorf("Error parsing scenario: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	simService := simulation.GetService()
+	updated, err := simService.UpdateScenario(vars["id"], scenario)
+	if err != nil {
+		writeScenarioError(w, err)
+		return
+	}
+
+	response := Response{
+		Status:  "success",
+		Message: "Scenario updated successfully",
+		Data:    updated,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// deleteScenarioHandler löscht ein eigenes Szenario; mitgelieferte sind schreibgeschützt
+func (api *APIRouter) deleteScenarioHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	simService := simulation.GetService()
+	if err := simService.DeleteScenario(vars["id"]); err != nil {
+		writeScenarioError(w, err)
+		return
+	}
+
+	response := Response{
+		Status:  "success",
+		Message: "Scenario deleted successfully",
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// maxScenarioFileSize begrenzt die Größe importierter Szenariodateien
+const maxScenarioFileSize = 1 << 20
+
+// importScenarioHandler legt ein Szenario aus einer YAML-Datei im Body an. Trägt die
+// Datei eine ID, wird ein vorhandenes eigenes Szenario mit dieser ID ersetzt. Fehler
+// in der Datei werden mit Zeilennummern in data zurückgegeben.
+func (api *APIRouter) importScenarioHandler(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxScenarioFileSize))
+	if err != nil {
+		logging.Logger.Errorf("Error reading scenario file: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	simService := simulation.GetService()
+	scenario, err := simService.ImportScenario(data)
+	if err != nil {
+		var fileErrs simulation.ScenarioFileErrors
+		if errors.As(err, &fileErrs) {
+			response := Response{
+				Status: "error",
+				Error:  "Invalid scenario file",
+				Data:   fileErrs,
+			}
+			writeJSONResponse(w, http.StatusBadRequest, response)
+			return
+		}
+		writeScenarioError(w, err)
+		return
+	}
+
+	response := Response{
+		Status:  "success",
+		Message: "Scenario imported successfully",
+		Data:    scenario,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// exportScenarioHandler gibt ein Szenario als YAML-Datei zum Herunterladen zurück
+func (api *APIRouter) exportScenarioHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	simService := simulation.GetService()
+	data, err := simService.ExportScenario(vars["id"])
+	if err != nil {
+		writeScenarioError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", vars["id"]+".yaml"))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		logging.Logger.Errorf("Error writing scenario file: %v", err)
+	}
+}
+
+// writeScenarioError bildet die Fehler der Szenario-Endpunkte auf Statuscodes ab
+func writeScenarioError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, simulation.ErrScenarioNotFound):
+		writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, simulation.ErrScenarioReadOnly):
+		writeErrorResponse(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, simulation.ErrInvalidParameter):
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	default:
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,128 @@
+// backend/internal/api/schedule_handler.go
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/Kurs-24-06/aegis/backend/internal/simulation"
+	"github.com/gorilla/mux"
+)
+
+// getSchedulesHandler lists all schedules, the oldest first
+func (api *APIRouter) getSchedulesHandler(w http.ResponseWriter, r *http.Request) {
+	simService := simulation.GetService()
+	schedules, err := simService.GetSchedules()
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := Response{
+		Status: "success",
+		Data:   schedules,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// getScheduleHandler returns one schedule with its last and next run times
+func (api *APIRouter) getScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	simService := simulation.GetService()
+	schedule, err := simService.GetSchedule(vars["id"])
+	if err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+
+	response := Response{
+		Status: "success",
+		Data:   schedule,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// createScheduleHandler creates a schedule. The body takes name, cron (five fields or
+// a descriptor such as @daily), timezone (default UTC), infrastructureId, scenarioId,
+// parameters, overlap (skip or queue, default skip) and enabled (default true).
+func (api *APIRouter) createScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	var config simulation.ScheduleConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		logging.Logger.Errorf("Error parsing schedule config: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	simService := simulation.GetService()
+	schedule, err := simService.CreateSchedule(config)
+	if err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+
+	response := Response{
+		Status:  "success",
+		Message: "Schedule created successfully",
+		Data:    schedule,
+	}
+	writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// updateScheduleHandler replaces the configuration of a schedule. Without enabled the
+// schedule keeps its current state; the next run time is recalculated.
+func (api *APIRouter) updateScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var config simulation.ScheduleConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		logging.Logger.Errorf("Error parsing schedule config: %v", err)
+		writeErrorResponse(w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	simService := simulation.GetService()
+	schedule, err := simService.UpdateSchedule(vars["id"], config)
+	if err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+
+	response := Response{
+		Status:  "success",
+		Message: "Schedule updated successfully",
+		Data:    schedule,
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// deleteScheduleHandler deletes a schedule; simulations it started are kept
+func (api *APIRouter) deleteScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	simService := simulation.GetService()
+	if err := simService.DeleteSchedule(vars["id"]); err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+
+	response := Response{
+		Status:  "success",
+		Message: "Schedule deleted successfully",
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// writeScheduleError maps errors of the schedule endpoints
+func writeScheduleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, simulation.ErrScheduleNotFound):
+		writeErrorResponse(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, simulation.ErrInvalidParameter):
+		writeErrorResponse(w, http.StatusBadRequest, err.Error())
+	default:
+		writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,208 @@
+// backend/internal/api/stream_handler.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/Kurs-24-06/aegis/backend/internal/simulation"
+	"github.com/gorilla/mux"
+)
+
+// streamKeepAliveInterval ist der Abstand der Kommentarzeilen, die Proxys davon abhalten,
+// eine ruhige Verbindung zu schließen
+const streamKeepAliveInterval = 15 * time.Second
+
+// isTerminalStatus gibt an, ob eine Simulation in diesem Status keine Updates mehr liefert
+func isTerminalStatus(status simulation.Status) bool {
+	return status == simulation.StatusCompleted || status == simulation.StatusStopped || status == simulation.StatusFailed
+}
+
+// eventStream schreibt Updates einer Simulation als Server-Sent Events
+type eventStream struct {
+	w           http.ResponseWriter
+	controller  *http.ResponseController
+	lastEventID string // ID des zuletzt gesendeten Simulations-Events
+}
+
+// send schreibt eine SSE-Nachricht; nur Simulations-Events tragen eine ID, damit
+// Last-Event-ID beim Wiederverbinden immer auf ein Event verweist
+func (s *eventStream) send(eventName, id string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(s.w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", eventName, payload); err != nil {
+		return err
+	}
+	if id != "" {
+		s.lastEventID = id
+	}
+	return s.controller.Flush()
+}
+
+// sendUpdate schreibt ein Update der Engine
+func (s *eventStream) sendUpdate(update simulation.SimulationUpdate) error {
+	switch update.Type {
+	case simulation.UpdateEvent:
+		return s.send("event", update.Event.ID, update.Event)
+	case simulation.UpdateStatus:
+		return s.send("status", "", update.Status)
+	case simulation.UpdateResource:
+		return s.send("resource", "", update.Resource)
+	}
+	return nil
+}
+
+// replay sendet alle Events nach s.lastEventID, den aktuellen Stand der Ressourcen und
+// den aktuellen Status. Zurückgegeben werden die IDs der gesendeten Events und der Status.
+func (s *eventStream) replay(simService *simulation.Service, id string) (map[string]bool, *simulation.SimulationStatus, error) {
+	events, err := simService.GetEvents(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Ist die letzte ID unbekannt, wird der gesamte Verlauf gesendet
+	start := 0
+	for i, event := range events {
+		if event.ID == s.lastEventID {
+			start = i + 1
+			break
+		}
+	}
+
+	replayed := make(map[string]bool, len(events))
+	for _, event := range events {
+		replayed[event.ID] = true
+	}
+	for i := start; i < len(events); i++ {
+		if err := s.send("event", events[i].ID, events[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	resources, err := simService.GetAffectedResources(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range resources {
+		if err := s.send("resource", "", resources[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	status, err := simService.GetSimulationStatus(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.send("status", "", status); err != nil {
+		return nil, nil, err
+	}
+	return replayed, status, nil
+}
+
+// streamSimulationHandler überträgt Events, Status- und Ressourcenänderungen einer
+// Simulation als Server-Sent Events. Mit dem Header Last-Event-ID erhält ein Client
+// nach dem Wiederverbinden alle Events, die er verpasst hat. Sobald die Simulation
+// beendet ist, wird ein "end"-Event gesendet und die Verbindung geschlossen.
+func (api *APIRouter) streamSimulationHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	simService := simulation.GetService()
+	stream := &eventStream{
+		w:           w,
+		controller:  http.NewResponseController(w),
+		lastEventID: r.Header.Get("Last-Event-ID"),
+	}
+
+	headersSent := false
+	keepAlive := time.NewTicker(streamKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		// Erst abonnieren, dann den Stand senden, damit zwischendurch nichts verloren geht
+		updates, cancel, err := simService.Subscribe(id)
+		if err != nil {
+			if !headersSent {
+				writeErrorResponse(w, http.StatusNotFound, err.Error())
+			}
+			return
+		}
+
+		if !headersSent {
+			// Der Stream läuft länger als ein gewöhnlicher Request
+			stream.controller.SetWriteDeadline(time.Time{})
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("Connection", "keep-alive")
+			w.Header().Set("X-Accel-Buffering", "no")
+			w.WriteHeader(http.StatusOK)
+			headersSent = true
+		}
+
+		replayed, status, err := stream.replay(simService, id)
+		if err != nil {
+			cancel()
+			return
+		}
+		if isTerminalStatus(status.Status) {
+			cancel()
+			stream.send("end", "", map[string]interface{}{"status": status.Status})
+			return
+		}
+
+		resubscribe := stream.forward(r.Context(), updates, replayed, keepAlive)
+		cancel()
+		if !resubscribe {
+			return
+		}
+
+		// Der Client war zu langsam; ab dem letzten gesendeten Event fortsetzen
+		logging.Logger.Warnf("Stream der Simulation %s war zu langsam, setze ab Event %s fort", id, stream.lastEventID)
+	}
+}
+
+// forward leitet Updates an den Client weiter, bis die Simulation endet, der
+// Client die Verbindung trennt oder die Engine das Abonnement beendet. Gibt true
+// zurück, wenn das Abonnement wegen eines Rückstands beendet wurde und der Stream
+// neu aufgesetzt werden soll.
+func (s *eventStream) forward(ctx context.Context, updates <-chan simulation.SimulationUpdate, replayed map[string]bool, keepAlive *time.Ticker) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(s.w, ": keepalive\n\n"); err != nil {
+				return false
+			}
+			if err := s.controller.Flush(); err != nil {
+				return false
+			}
+		case update, ok := <-updates:
+			if !ok {
+				return true
+			}
+			// Events aus dem Abonnement, die bereits im Verlauf enthalten waren, überspringen
+			if update.Type == simulation.UpdateEvent && replayed[update.Event.ID] {
+				continue
+			}
+			if err := s.sendUpdate(update); err != nil {
+				return false
+			}
+			if update.Type == simulation.UpdateStatus && isTerminalStatus(update.Status.Status) {
+				s.send("end", "", map[string]interface{}{"status": update.Status.Status})
+				return false
+			}
+		}
+	}
+}
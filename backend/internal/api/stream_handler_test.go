@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/simulation"
+)
+
+// sseMessage ist eine empfangene Server-Sent-Events-Nachricht
+type sseMessage struct {
+	id    string
+	event string
+	data  string
+}
+
+// readStream liest alle Nachrichten, bis der Server die Verbindung schließt
+func readStream(t *testing.T, url, lastEventID string) []sseMessage {
+	t.Helper()
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unerwarteter Statuscode: %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("Unerwarteter Content-Type: %s", contentType)
+	}
+
+	var messages []sseMessage
+	var current sseMessage
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if current.event != "" {
+				messages = append(messages, current)
+			}
+			current = sseMessage{}
+		case strings.HasPrefix(line, "id: "):
+			current.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			current.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	return messages
+}
+
+func TestStreamReplaysAndCloses(t *testing.T) {
+	server := httptest.NewServer(NewAPIRouter().Handler())
+	defer server.Close()
+
+	simService := simulation.GetService()
+	sim, err := simService.CreateSimulation(simulation.SimulationConfig{
+		Name:       "Stream",
+		Parameters: map[string]interface{}{simulation.ParameterSpeed: simulation.SpeedInstant},
+	})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	if _, err := simService.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+
+	// Ein Stream zu einer laufenden Simulation endet mit ihrem Abschluss
+	url := server.URL + "/api/monitoring/simulations/" + sim.ID + "/stream"
+	messages := readStream(t, url, "")
+	if len(messages) == 0 || messages[len(messages)-1].event != "end" {
+		t.Fatalf("Stream sollte mit einem end-Event schließen, erhalten: %+v", messages)
+	}
+
+	events, _ := simService.GetEvents(sim.ID)
+	var streamed []string
+	for _, message := range messages {
+		if message.event == "event" {
+			streamed = append(streamed, message.id)
+		}
+	}
+	if len(streamed) != len(events) {
+		t.Fatalf("Erwartete Events: %d, Gestreamte Events: %d", len(events), len(streamed))
+	}
+	for i := range events {
+		if streamed[i] != events[i].ID {
+			t.Fatalf("Event %d fehlt oder ist in falscher Reihenfolge", i)
+		}
+	}
+
+	// Nach dem Wiederverbinden werden nur die verpassten Events gesendet
+	resumed := readStream(t, url, events[len(events)-3].ID)
+	var missed []string
+	for _, message := range resumed {
+		if message.event == "event" {
+			missed = append(missed, message.id)
+		}
+	}
+	if len(missed) != 2 || missed[0] != events[len(events)-2].ID || missed[1] != events[len(events)-1].ID {
+		t.Fatalf("Erwartet wurden die letzten beiden Events, erhalten: %v", missed)
+	}
+}
+
+func TestStreamUnknownSimulation(t *testing.T) {
+	server := httptest.NewServer(NewAPIRouter().Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/monitoring/simulations/unbekannt/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Erwarteter Statuscode: %d, Erhaltener Statuscode: %d", http.StatusNotFound, resp.StatusCode)
+	}
+}
@@ -0,0 +1,223 @@
+// backend/internal/api/websocket_handler.go
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/Kurs-24-06/aegis/backend/internal/simulation"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsWriteTimeout   = 10 * time.Second
+	wsPongTimeout    = 60 * time.Second
+	wsPingInterval   = wsPongTimeout * 9 / 10
+	wsMaxMessageSize = 64 * 1024
+)
+
+// Befehle, die ein Client über den WebSocket senden kann
+const (
+	wsCommandPause  = "pause"
+	wsCommandResume = "resume"
+	wsCommandStop   = "stop"
+	wsCommandInject = "inject"
+	wsCommandStatus = "status"
+)
+
+// wsCommand ist ein Befehl eines Clients. Die ID wählt der Client; sie wird in der
+// Antwort zurückgegeben, damit er Antworten seinen Befehlen zuordnen kann.
+type wsCommand struct {
+	ID      string                      `json:"id,omitempty"`
+	Command string                      `json:"command"`
+	Action  *simulation.DefensiveAction `json:"action,omitempty"`
+}
+
+// wsReply bestätigt ("ack") oder verwirft ("error") einen Befehl
+type wsReply struct {
+	Type    string      `json:"type"`
+	ID      string      `json:"id,omitempty"`
+	Command string      `json:"command"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// wsConnection serialisiert Schreibzugriffe, da eine WebSocket-Verbindung nur
+// einen gleichzeitigen Schreiber erlaubt
+type wsConnection struct {
+	conn  *websocket.Conn
+	mutex sync.Mutex
+}
+
+func (c *wsConnection) writeJSON(v interface{}) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return c.conn.WriteJSON(v)
+}
+
+func (c *wsConnection) writeControl(messageType int, data []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.conn.WriteControl(messageType, data, time.Now().Add(wsWriteTimeout))
+}
+
+// checkOrigin erlaubt WebSocket-Verbindungen von den konfigurierten Origins
+func (api *APIRouter) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range api.allowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// simulationWebSocketHandler öffnet einen bidirektionalen Kanal zu einer Simulation.
+// Der Server sendet dieselben Updates wie der SSE-Stream (type "event", "status",
+// "resource"); der Client steuert die Simulation mit Befehlen (siehe wsCommand), die
+// jeweils mit "ack" oder "error" beantwortet werden.
+func (api *APIRouter) simulationWebSocketHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	simService := simulation.GetService()
+	updates, cancel, err := simService.Subscribe(id)
+	if err != nil {
+		writeErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	defer cancel()
+
+	upgrader := websocket.Upgrader{CheckOrigin: api.checkOrigin}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Der Upgrader hat dem Client bereits geantwortet
+		logging.Logger.Warnf("WebSocket-Upgrade für Simulation %s fehlgeschlagen: %v", id, err)
+		return
+	}
+	defer conn.Close()
+
+	ws := &wsConnection{conn: conn}
+	conn.SetReadLimit(wsMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	// Aktuellen Stand senden, danach laufende Updates
+	if status, err := simService.GetSimulationStatus(id); err == nil {
+		ws.writeJSON(simulation.SimulationUpdate{Type: simulation.UpdateStatus, SimulationID: id, Status: status})
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go forwardToWebSocket(ws, id, updates, done)
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logging.Logger.Warnf("WebSocket der Simulation %s unerwartet geschlossen: %v", id, err)
+			}
+			return
+		}
+
+		var command wsCommand
+		if err := json.Unmarshal(message, &command); err != nil {
+			ws.writeJSON(wsReply{Type: "error", Error: "Invalid command format"})
+			continue
+		}
+		if err := ws.writeJSON(executeWebSocketCommand(simService, id, command)); err != nil {
+			return
+		}
+	}
+}
+
+// forwardToWebSocket leitet Updates an den Client weiter und hält die Verbindung mit
+// Pings offen. Fällt der Client zu weit zurück, wird die Verbindung geschlossen.
+func forwardToWebSocket(ws *wsConnection, id string, updates <-chan simulation.SimulationUpdate, done <-chan struct{}) {
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ping.C:
+			if err := ws.writeControl(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case update, ok := <-updates:
+			if !ok {
+				logging.Logger.Warnf("WebSocket der Simulation %s zu langsam, Verbindung wird geschlossen", id)
+				ws.writeControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "client too slow"))
+				ws.conn.Close()
+				return
+			}
+			if err := ws.writeJSON(update); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// executeWebSocketCommand führt einen Befehl über die Methoden des Service aus
+func executeWebSocketCommand(simService *simulation.Service, id string, command wsCommand) wsReply {
+	reply := wsReply{Type: "ack", ID: command.ID, Command: command.Command}
+	reject := func(message string) wsReply {
+		reply.Type = "error"
+		reply.Error = message
+		return reply
+	}
+
+	// Zustandswechsel gelten nur als bestätigt, wenn die Simulation danach im erwarteten Status ist
+	var expected simulation.Status
+	var err error
+	switch command.Command {
+	case wsCommandPause:
+		_, err = simService.PauseSimulation(id)
+		expected = simulation.StatusPaused
+	case wsCommandResume:
+		_, err = simService.ResumeSimulation(id)
+		expected = simulation.StatusRunning
+	case wsCommandStop:
+		_, err = simService.StopSimulation(id)
+		expected = simulation.StatusStopped
+	case wsCommandStatus:
+	case wsCommandInject:
+		if command.Action == nil {
+			return reject("Missing action for inject command")
+		}
+		event, err := simService.InjectDefensiveAction(id, *command.Action)
+		if err != nil {
+			return reject(err.Error())
+		}
+		reply.Data = event
+		return reply
+	default:
+		return reject(fmt.Sprintf("Unknown command %q", command.Command))
+	}
+	if err != nil {
+		return reject(err.Error())
+	}
+
+	status, err := simService.GetSimulationStatus(id)
+	if err != nil {
+		return reject(err.Error())
+	}
+	if expected != "" && status.Status != expected {
+		return reject(fmt.Sprintf("Command %s not possible in status %s", command.Command, status.Status))
+	}
+	reply.Data = status
+	return reply
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/simulation"
+	"github.com/gorilla/websocket"
+)
+
+// wsMessage enthält die Felder aller Nachrichten, die der Server senden kann
+type wsMessage struct {
+	Type    string          `json:"type"`
+	ID      string          `json:"id"`
+	Command string          `json:"command"`
+	Data    json.RawMessage `json:"data"`
+	Error   string          `json:"error"`
+}
+
+// sendCommand sendet einen Befehl und wartet auf die zugehörige Antwort
+func sendCommand(t *testing.T, conn *websocket.Conn, command map[string]interface{}) wsMessage {
+	t.Helper()
+
+	if err := conn.WriteJSON(command); err != nil {
+		t.Fatalf("Fehler beim Senden: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var message wsMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			t.Fatalf("Fehler beim Lesen: %v", err)
+		}
+		if (message.Type == "ack" || message.Type == "error") && message.ID == command["id"] {
+			return message
+		}
+	}
+}
+
+func TestWebSocketCommands(t *testing.T) {
+	server := httptest.NewServer(NewAPIRouter().Handler())
+	defer server.Close()
+
+	simService := simulation.GetService()
+	sim, err := simService.CreateSimulation(simulation.SimulationConfig{Name: "WebSocket"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	if _, err := simService.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/simulations/" + sim.ID + "/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Verbindungsaufbau fehlgeschlagen: %v", err)
+	}
+	defer conn.Close()
+
+	// Zuerst kommt der aktuelle Status
+	var first wsMessage
+	if err := conn.ReadJSON(&first); err != nil || first.Type != "status" {
+		t.Fatalf("Erste Nachricht sollte der Status sein: %+v, %v", first, err)
+	}
+
+	reply := sendCommand(t, conn, map[string]interface{}{"id": "1", "command": "pause"})
+	if reply.Type != "ack" || !strings.Contains(string(reply.Data), `"status":"paused"`) {
+		t.Fatalf("Pause sollte bestätigt werden: %+v", reply)
+	}
+	reply = sendCommand(t, conn, map[string]interface{}{"id": "2", "command": "pause"})
+	if reply.Type != "ack" {
+		t.Fatalf("Erneutes Pausieren sollte bestätigt werden: %+v", reply)
+	}
+	reply = sendCommand(t, conn, map[string]interface{}{"id": "3", "command": "resume"})
+	if reply.Type != "ack" || !strings.Contains(string(reply.Data), `"status":"running"`) {
+		t.Fatalf("Fortsetzen sollte bestätigt werden: %+v", reply)
+	}
+
+	// Abwehraktion auf einen Knoten der Infrastruktur
+	node := simulation.MockInfrastructureFor(sim.InfrastructureID)["nodes"].([]map[string]interface{})[0]
+	reply = sendCommand(t, conn, map[string]interface{}{
+		"id":      "4",
+		"command": "inject",
+		"action":  map[string]interface{}{"type": "isolate_node", "nodeId": node["id"]},
+	})
+	if reply.Type != "ack" || !strings.Contains(string(reply.Data), `"type":"defense"`) {
+		t.Fatalf("Abwehraktion sollte bestätigt werden: %+v", reply)
+	}
+
+	rejected := []map[string]interface{}{
+		{"id": "5", "command": "inject", "action": map[string]interface{}{"type": "isolate_node", "nodeId": "unbekannt"}},
+		{"id": "6", "command": "inject"},
+		{"id": "7", "command": "reboot"},
+	}
+	for _, command := range rejected {
+		if reply := sendCommand(t, conn, command); reply.Type != "error" || reply.Error == "" {
+			t.Fatalf("Befehl %v sollte mit Grund abgelehnt werden: %+v", command, reply)
+		}
+	}
+
+	reply = sendCommand(t, conn, map[string]interface{}{"id": "8", "command": "stop"})
+	if reply.Type != "ack" || !strings.Contains(string(reply.Data), `"status":"stopped"`) {
+		t.Fatalf("Stopp sollte bestätigt werden: %+v", reply)
+	}
+	reply = sendCommand(t, conn, map[string]interface{}{"id": "9", "command": "resume"})
+	if reply.Type != "error" {
+		t.Fatalf("Fortsetzen einer gestoppten Simulation sollte abgelehnt werden: %+v", reply)
+	}
+	reply = sendCommand(t, conn, map[string]interface{}{"id": "10", "command": "status"})
+	if reply.Type != "ack" || !strings.Contains(string(reply.Data), `"status":"stopped"`) {
+		t.Fatalf("Statusabfrage sollte bestätigt werden: %+v", reply)
+	}
+}
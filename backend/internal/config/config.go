@@ -14,6 +14,11 @@ type Config struct {
 	Server struct {
 		Port  int  `yaml:"port"`
 		Debug bool `yaml:"debug"`
+		// Zeitlimits des HTTP-Servers in Sekunden; 0 übernimmt den Standardwert
+		ReadTimeoutSeconds     int `yaml:"read_timeout_seconds"`
+		WriteTimeoutSeconds    int `yaml:"write_timeout_seconds"`
+		IdleTimeoutSeconds     int `yaml:"idle_timeout_seconds"`
+		ShutdownTimeoutSeconds int `yaml:"shutdown_timeout_seconds"`
 		CORS  struct {
 			AllowedOrigins []string `yaml:"allowed_origins"`
 			AllowedMethods []string `yaml:"allowed_methods"`
@@ -29,6 +34,7 @@ type Config struct {
 		Password string `yaml:"password"`
 		Name     string `yaml:"name"`
 		SSLMode  string `yaml:"sslMode"`
+		Path     string `yaml:"path"` // Datei der SQLite-Datenbank
 	} `yaml:"database"`
 
 	Redis struct {
@@ -41,6 +47,17 @@ type Config struct {
 		WorkerCount          int `yaml:"worker_count"`
 		BufferSize           int `yaml:"buffer_size"`
 		DefaultTimeoutSeconds int `yaml:"default_timeout_seconds"`
+		// Abstand, in dem fällige Zeitpläne geprüft werden; 0 führt keine Zeitpläne aus
+		ScheduleIntervalSeconds int `yaml:"schedule_interval_seconds"`
+		// Verzeichnis mit Szenariodateien (*.yaml), die beim Start geladen werden; leer lädt keine
+		ScenarioDirectory string `yaml:"scenario_directory"`
+
+		// Aufbewahrung beendeter Simulationen; 0 bewahrt unbegrenzt auf
+		Retention struct {
+			EventsAfterDays        int `yaml:"events_after_days"`
+			SimulationsAfterDays   int `yaml:"simulations_after_days"`
+			JanitorIntervalMinutes int `yaml:"janitor_interval_minutes"`
+		} `yaml:"retention"`
 	} `yaml:"simulation"`
 
 	Logging struct {
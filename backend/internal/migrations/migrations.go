@@ -0,0 +1,261 @@
+// backend/internal/migrations/migrations.go
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Die Migrationen liegen je Datenbank in einem eigenen Verzeichnis als
+// <Version>_<Name>.up.sql und <Version>_<Name>.down.sql
+//
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// Dialect bezeichnet die Datenbank, für die Migrationen geladen werden
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration ist eine versionierte Änderung des Schemas
+type Migration struct {
+	Version int
+	Name    string
+	up      string
+	down    string
+}
+
+// MigrationStatus beschreibt, ob eine Migration angewendet ist
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Migrator wendet die eingebetteten Migrationen auf eine Datenbank an und
+// vermerkt jede angewendete Version in der Tabelle schema_version
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+// NewMigrator lädt die Migrationen für eine Datenbank
+func NewMigrator(db *sql.DB, dialect Dialect) (*Migrator, error) {
+	migrations, err := load(dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+	}, nil
+}
+
+// load liest die Migrationen eines Dialekts, aufsteigend nach Version
+func load(dialect Dialect) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, string(dialect))
+	if err != nil {
+		return nil, fmt.Errorf("keine Migrationen für Datenbank %q", dialect)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("ungültiger Dateiname einer Migration: %s", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		content, err := files.ReadFile(path.Join(string(dialect), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("Version %d ist doppelt vergeben (%s, %s)", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.up = string(content)
+		} else {
+			migration.down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == "" || migration.down == "" {
+			return nil, fmt.Errorf("Migration %d_%s benötigt eine up- und eine down-Datei", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// placeholder gibt den n-ten Platzhalter einer Abfrage im Stil der Datenbank zurück
+func (m *Migrator) placeholder(n int) string {
+	if m.dialect == SQLite {
+		return fmt.Sprintf("?%d", n)
+	}
+	return fmt.Sprintf("$%d", n)
+}
+
+// ensureVersionTable legt die Tabelle schema_version an, falls sie fehlt
+func (m *Migrator) ensureVersionTable() error {
+	_, err := m.db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version    INTEGER PRIMARY KEY,
+			name       VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Anlegen der Tabelle schema_version: %v", err)
+	}
+	return nil
+}
+
+// applied lädt die angewendeten Versionen mit ihrem Zeitpunkt
+func (m *Migrator) applied() (map[int]time.Time, error) {
+	if err := m.ensureVersionTable(); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Query("SELECT version, applied_at FROM schema_version")
+	if err != nil {
+		return nil, fmt.Errorf("Fehler beim Laden der Schemaversionen: %v", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("Fehler beim Scannen der Schemaversion: %v", err)
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// Version gibt die höchste angewendete Version zurück, 0 für ein leeres Schema
+func (m *Migrator) Version() (int, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+
+	version := 0
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// Status gibt für jede bekannte Migration an, ob sie angewendet ist
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Up wendet alle ausstehenden Migrationen der Reihe nach an, jede in einer eigenen
+// Transaktion. Zurückgegeben werden die angewendeten Migrationen.
+func (m *Migrator) Up() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		insert := fmt.Sprintf("INSERT INTO schema_version (version, name, applied_at) VALUES (%s, %s, %s)",
+			m.placeholder(1), m.placeholder(2), m.placeholder(3))
+		err := m.inTransaction(migration.up, insert, migration.Version, migration.Name, time.Now().UTC())
+		if err != nil {
+			return done, fmt.Errorf("Migration %d_%s fehlgeschlagen: %v", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down nimmt die letzten steps angewendeten Migrationen in absteigender Reihenfolge
+// zurück. Zurückgegeben werden die zurückgenommenen Migrationen.
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		remove := fmt.Sprintf("DELETE FROM schema_version WHERE version = %s", m.placeholder(1))
+		if err := m.inTransaction(migration.down, remove, migration.Version); err != nil {
+			return done, fmt.Errorf("Rücknahme von Migration %d_%s fehlgeschlagen: %v", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// inTransaction führt ein Migrationsskript und die Änderung an schema_version gemeinsam aus
+func (m *Migrator) inTransaction(script, versionQuery string, args ...interface{}) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(script); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(versionQuery, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
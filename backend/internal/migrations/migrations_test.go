@@ -0,0 +1,99 @@
+// backend/internal/migrations/migrations_test.go
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count); err != nil {
+		t.Fatalf("Fehler beim Prüfen der Tabelle %s: %v", name, err)
+	}
+	return count > 0
+}
+
+func TestMigrationsLoadForEveryDialect(t *testing.T) {
+	for _, dialect := range []Dialect{Postgres, SQLite} {
+		migrations, err := load(dialect)
+		if err != nil {
+			t.Fatalf("Fehler beim Laden der Migrationen für %s: %v", dialect, err)
+		}
+		for i, migration := range migrations {
+			if migration.Version != i+1 {
+				t.Fatalf("%s: Erwartete Version %d, Erhalten: %d (%s)", dialect, i+1, migration.Version, migration.Name)
+			}
+		}
+	}
+
+	// Beide Datenbanken müssen dieselben Versionen kennen
+	postgres, _ := load(Postgres)
+	sqlite, _ := load(SQLite)
+	if len(postgres) != len(sqlite) {
+		t.Fatalf("Postgres hat %d Migrationen, SQLite %d", len(postgres), len(sqlite))
+	}
+}
+
+func TestMigrateUpAndDown(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Fehler beim Öffnen der Datenbank: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	migrator, err := NewMigrator(db, SQLite)
+	if err != nil {
+		t.Fatalf("Fehler beim Laden der Migrationen: %v", err)
+	}
+	latest := migrator.migrations[len(migrator.migrations)-1].Version
+
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatalf("Fehler beim Migrieren: %v", err)
+	}
+	if len(applied) != len(migrator.migrations) {
+		t.Fatalf("Erwartet: %d angewendete Migrationen, Erhalten: %d", len(migrator.migrations), len(applied))
+	}
+	if version, _ := migrator.Version(); version != latest {
+		t.Fatalf("Erwartete Version: %d, Erhalten: %d", latest, version)
+	}
+	if !tableExists(t, db, "simulations") {
+		t.Fatal("Tabelle simulations fehlt nach dem Migrieren")
+	}
+
+	// Ein zweiter Lauf hat nichts zu tun
+	if applied, err := migrator.Up(); err != nil || len(applied) != 0 {
+		t.Fatalf("Erwartet: keine weiteren Migrationen, Erhalten: %d (%v)", len(applied), err)
+	}
+
+	// Alles zurücknehmen
+	reverted, err := migrator.Down(len(migrator.migrations))
+	if err != nil {
+		t.Fatalf("Fehler beim Zurücknehmen: %v", err)
+	}
+	if len(reverted) != len(migrator.migrations) || reverted[0].Version != latest {
+		t.Fatalf("Migrationen wurden nicht in absteigender Reihenfolge zurückgenommen: %+v", reverted)
+	}
+	if version, _ := migrator.Version(); version != 0 {
+		t.Fatalf("Erwartete Version nach dem Zurücknehmen: 0, Erhalten: %d", version)
+	}
+	if tableExists(t, db, "simulations") {
+		t.Fatal("Tabelle simulations existiert nach dem Zurücknehmen noch")
+	}
+
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen des Status: %v", err)
+	}
+	for _, status := range statuses {
+		if status.Applied {
+			t.Fatalf("Migration %d sollte nicht angewendet sein", status.Version)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS affected_resources;
+DROP TABLE IF EXISTS simulation_events;
+DROP TABLE IF EXISTS simulations;
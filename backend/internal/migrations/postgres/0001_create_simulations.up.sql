@@ -0,0 +1,55 @@
+-- Tabellen des Simulations-Repositorys (siehe internal/simulation/repository.go)
+
+CREATE TABLE IF NOT EXISTS simulations (
+    id                 VARCHAR(36) PRIMARY KEY,
+    name               VARCHAR(255) NOT NULL,
+    description        TEXT NOT NULL DEFAULT '',
+    status             VARCHAR(32) NOT NULL,
+    start_time         TIMESTAMP WITH TIME ZONE,
+    end_time           TIMESTAMP WITH TIME ZONE,
+    infrastructure_id  VARCHAR(255) NOT NULL DEFAULT '',
+    scenario_id        VARCHAR(255) NOT NULL DEFAULT '',
+    progress           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    threats_detected   INTEGER NOT NULL DEFAULT 0,
+    results_json       TEXT,
+    parameters_json    TEXT,
+    seed               BIGINT NOT NULL DEFAULT 0,
+    error              TEXT,
+    paused_at          TIMESTAMP WITH TIME ZONE,
+    paused_duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_simulations_created_at ON simulations (created_at);
+
+CREATE TABLE IF NOT EXISTS simulation_events (
+    seq               BIGSERIAL,
+    id                VARCHAR(36) PRIMARY KEY,
+    simulation_id     VARCHAR(36) NOT NULL REFERENCES simulations (id) ON DELETE CASCADE,
+    event_type        VARCHAR(64) NOT NULL,
+    timestamp         TIMESTAMP WITH TIME ZONE NOT NULL,
+    description       TEXT,
+    resource_id       VARCHAR(255),
+    severity          VARCHAR(32) NOT NULL,
+    phase             VARCHAR(255),
+    outcome           VARCHAR(32),
+    detected          BOOLEAN NOT NULL DEFAULT FALSE,
+    blocked           BOOLEAN NOT NULL DEFAULT FALSE,
+    simulated_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+    details_json      TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_simulation_events_simulation ON simulation_events (simulation_id, seq);
+
+CREATE TABLE IF NOT EXISTS affected_resources (
+    id              VARCHAR(255) NOT NULL,
+    simulation_id   VARCHAR(36) NOT NULL REFERENCES simulations (id) ON DELETE CASCADE,
+    name            VARCHAR(255) NOT NULL,
+    type            VARCHAR(64) NOT NULL,
+    status          VARCHAR(32) NOT NULL,
+    threat_level    DOUBLE PRECISION NOT NULL DEFAULT 0,
+    attack_vector   TEXT,
+    vulnerabilities TEXT,
+    PRIMARY KEY (id, simulation_id)
+);
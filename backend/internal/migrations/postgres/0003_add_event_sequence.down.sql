@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_simulation_events_sequence;
+ALTER TABLE simulation_events DROP COLUMN sequence;
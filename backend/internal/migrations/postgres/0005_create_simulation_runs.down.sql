@@ -0,0 +1,22 @@
+-- Frühere Läufe gehen verloren; erhalten bleiben Events und Ressourcen des aktuellen Laufs
+
+DELETE FROM simulation_events e
+USING simulations s
+WHERE s.id = e.simulation_id AND s.run_id <> e.run_id;
+
+DELETE FROM affected_resources r
+USING simulations s
+WHERE s.id = r.simulation_id AND s.run_id <> r.run_id;
+
+ALTER TABLE affected_resources DROP CONSTRAINT affected_resources_pkey;
+ALTER TABLE affected_resources ADD PRIMARY KEY (id, simulation_id);
+ALTER TABLE affected_resources DROP COLUMN run_id;
+
+DROP INDEX IF EXISTS idx_simulation_events_run;
+ALTER TABLE simulation_events DROP COLUMN run_id;
+
+ALTER TABLE simulations DROP COLUMN cloned_from;
+ALTER TABLE simulations DROP COLUMN run_number;
+ALTER TABLE simulations DROP COLUMN run_id;
+
+DROP TABLE IF EXISTS simulation_runs;
@@ -0,0 +1,43 @@
+-- Jeder Start einer Simulation ist ein eigener Lauf mit eigenen Events, Ressourcen,
+-- Ergebnissen und Seed. Bereits gestartete Simulationen erhalten einen ersten Lauf,
+-- dessen ID ihrer eigenen entspricht.
+
+CREATE TABLE IF NOT EXISTS simulation_runs (
+    id               VARCHAR(36) PRIMARY KEY,
+    simulation_id    VARCHAR(36) NOT NULL REFERENCES simulations (id) ON DELETE CASCADE,
+    number           INTEGER NOT NULL,
+    status           VARCHAR(32) NOT NULL,
+    seed             BIGINT NOT NULL DEFAULT 0,
+    parameters_json  TEXT,
+    start_time       TIMESTAMP WITH TIME ZONE,
+    end_time         TIMESTAMP WITH TIME ZONE,
+    progress         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    threats_detected INTEGER NOT NULL DEFAULT 0,
+    results_json     TEXT,
+    error            TEXT,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (simulation_id, number)
+);
+
+ALTER TABLE simulations ADD COLUMN run_id VARCHAR(36) NOT NULL DEFAULT '';
+ALTER TABLE simulations ADD COLUMN run_number INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE simulations ADD COLUMN cloned_from VARCHAR(36) NOT NULL DEFAULT '';
+
+INSERT INTO simulation_runs
+(id, simulation_id, number, status, seed, parameters_json, start_time, end_time,
+progress, threats_detected, results_json, error, created_at)
+SELECT id, id, 1, status, seed, parameters_json, start_time, end_time,
+    progress, threats_detected, results_json, error, COALESCE(start_time, updated_at)
+FROM simulations
+WHERE status <> 'not_started';
+
+UPDATE simulations SET run_id = id, run_number = 1 WHERE status <> 'not_started';
+
+ALTER TABLE simulation_events ADD COLUMN run_id VARCHAR(36) NOT NULL DEFAULT '';
+UPDATE simulation_events SET run_id = simulation_id;
+CREATE INDEX IF NOT EXISTS idx_simulation_events_run ON simulation_events (run_id, sequence);
+
+ALTER TABLE affected_resources ADD COLUMN run_id VARCHAR(36) NOT NULL DEFAULT '';
+UPDATE affected_resources SET run_id = simulation_id;
+ALTER TABLE affected_resources DROP CONSTRAINT affected_resources_pkey;
+ALTER TABLE affected_resources ADD PRIMARY KEY (id, simulation_id, run_id);
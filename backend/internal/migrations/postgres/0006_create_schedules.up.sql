@@ -0,0 +1,22 @@
+-- Zeitpläne, nach denen Simulationen wiederkehrend erstellt und gestartet werden
+
+CREATE TABLE IF NOT EXISTS schedules (
+    id                 VARCHAR(36) PRIMARY KEY,
+    name               VARCHAR(255) NOT NULL,
+    description        TEXT NOT NULL DEFAULT '',
+    cron               VARCHAR(255) NOT NULL,
+    timezone           VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    infrastructure_id  VARCHAR(255) NOT NULL DEFAULT '',
+    scenario_id        VARCHAR(255) NOT NULL DEFAULT '',
+    parameters_json    TEXT,
+    overlap            VARCHAR(16) NOT NULL DEFAULT 'skip',
+    enabled            BOOLEAN NOT NULL DEFAULT TRUE,
+    pending            BOOLEAN NOT NULL DEFAULT FALSE,
+    next_run_at        TIMESTAMP WITH TIME ZONE,
+    last_run_at        TIMESTAMP WITH TIME ZONE,
+    last_simulation_id VARCHAR(36) NOT NULL DEFAULT '',
+    last_outcome       VARCHAR(16) NOT NULL DEFAULT '',
+    last_error         TEXT,
+    created_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
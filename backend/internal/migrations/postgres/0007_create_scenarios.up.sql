@@ -0,0 +1,13 @@
+-- Eigene Angriffsszenarien; die mitgelieferten Szenarien liefert die Engine selbst.
+-- Die Schritte werden als JSON gespeichert.
+
+CREATE TABLE IF NOT EXISTS scenarios (
+    id          VARCHAR(36) PRIMARY KEY,
+    name        VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    difficulty  VARCHAR(16) NOT NULL DEFAULT '',
+    duration    INTEGER NOT NULL DEFAULT 0,
+    steps_json  TEXT NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
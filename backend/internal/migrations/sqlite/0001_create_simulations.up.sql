@@ -0,0 +1,56 @@
+-- Tabellen des Simulations-Repositorys in SQLite; entspricht dem Postgres-Schema,
+-- seq hält die Reihenfolge der Events fest
+
+CREATE TABLE IF NOT EXISTS simulations (
+    id                 TEXT PRIMARY KEY,
+    name               TEXT NOT NULL,
+    description        TEXT NOT NULL DEFAULT '',
+    status             TEXT NOT NULL,
+    start_time         TIMESTAMP,
+    end_time           TIMESTAMP,
+    infrastructure_id  TEXT NOT NULL DEFAULT '',
+    scenario_id        TEXT NOT NULL DEFAULT '',
+    progress           REAL NOT NULL DEFAULT 0,
+    threats_detected   INTEGER NOT NULL DEFAULT 0,
+    results_json       TEXT,
+    parameters_json    TEXT,
+    seed               INTEGER NOT NULL DEFAULT 0,
+    error              TEXT,
+    paused_at          TIMESTAMP,
+    paused_duration_ms INTEGER NOT NULL DEFAULT 0,
+    created_at         TIMESTAMP NOT NULL,
+    updated_at         TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_simulations_created_at ON simulations (created_at);
+
+CREATE TABLE IF NOT EXISTS simulation_events (
+    seq               INTEGER PRIMARY KEY AUTOINCREMENT,
+    id                TEXT NOT NULL UNIQUE,
+    simulation_id     TEXT NOT NULL REFERENCES simulations (id) ON DELETE CASCADE,
+    event_type        TEXT NOT NULL,
+    timestamp         TIMESTAMP NOT NULL,
+    description       TEXT,
+    resource_id       TEXT,
+    severity          TEXT NOT NULL,
+    phase             TEXT,
+    outcome           TEXT,
+    detected          BOOLEAN NOT NULL DEFAULT 0,
+    blocked           BOOLEAN NOT NULL DEFAULT 0,
+    simulated_seconds REAL NOT NULL DEFAULT 0,
+    details_json      TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_simulation_events_simulation ON simulation_events (simulation_id, seq);
+
+CREATE TABLE IF NOT EXISTS affected_resources (
+    id              TEXT NOT NULL,
+    simulation_id   TEXT NOT NULL REFERENCES simulations (id) ON DELETE CASCADE,
+    name            TEXT NOT NULL,
+    type            TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    threat_level    REAL NOT NULL DEFAULT 0,
+    attack_vector   TEXT,
+    vulnerabilities TEXT,
+    PRIMARY KEY (id, simulation_id)
+);
@@ -0,0 +1,7 @@
+-- Gesicherter Zustand laufender Simulationen, damit sie einen Neustart überstehen
+
+CREATE TABLE IF NOT EXISTS simulation_checkpoints (
+    simulation_id TEXT PRIMARY KEY REFERENCES simulations (id) ON DELETE CASCADE,
+    state_json    TEXT NOT NULL,
+    created_at    TIMESTAMP NOT NULL
+);
@@ -0,0 +1,14 @@
+-- Fortlaufende Nummer der Events je Simulation; dient als Cursor beim Blättern.
+-- Bestehende Events werden in der Reihenfolge ihres Speicherns nummeriert.
+
+ALTER TABLE simulation_events ADD COLUMN sequence INTEGER NOT NULL DEFAULT 0;
+
+UPDATE simulation_events
+SET sequence = numbered.n
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY simulation_id ORDER BY seq) AS n
+    FROM simulation_events
+) AS numbered
+WHERE simulation_events.id = numbered.id;
+
+CREATE INDEX IF NOT EXISTS idx_simulation_events_sequence ON simulation_events (simulation_id, sequence);
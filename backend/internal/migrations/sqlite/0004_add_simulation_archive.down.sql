@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_simulations_end_time;
+ALTER TABLE simulations DROP COLUMN archived_at;
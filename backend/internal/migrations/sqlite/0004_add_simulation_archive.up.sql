@@ -0,0 +1,5 @@
+-- Archivierte Simulationen fehlen in der Standardliste; end_time dient der Aufbewahrungsfrist
+
+ALTER TABLE simulations ADD COLUMN archived_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_simulations_end_time ON simulations (status, end_time);
@@ -0,0 +1,34 @@
+-- Frühere Läufe gehen verloren; erhalten bleiben Events und Ressourcen des aktuellen Laufs
+
+DELETE FROM simulation_events
+WHERE run_id <> (SELECT run_id FROM simulations WHERE simulations.id = simulation_events.simulation_id);
+
+CREATE TABLE affected_resources_single (
+    id              TEXT NOT NULL,
+    simulation_id   TEXT NOT NULL REFERENCES simulations (id) ON DELETE CASCADE,
+    name            TEXT NOT NULL,
+    type            TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    threat_level    REAL NOT NULL DEFAULT 0,
+    attack_vector   TEXT,
+    vulnerabilities TEXT,
+    PRIMARY KEY (id, simulation_id)
+);
+
+INSERT INTO affected_resources_single
+(id, simulation_id, name, type, status, threat_level, attack_vector, vulnerabilities)
+SELECT r.id, r.simulation_id, r.name, r.type, r.status, r.threat_level, r.attack_vector, r.vulnerabilities
+FROM affected_resources r
+JOIN simulations s ON s.id = r.simulation_id AND s.run_id = r.run_id;
+
+DROP TABLE affected_resources;
+ALTER TABLE affected_resources_single RENAME TO affected_resources;
+
+DROP INDEX IF EXISTS idx_simulation_events_run;
+ALTER TABLE simulation_events DROP COLUMN run_id;
+
+ALTER TABLE simulations DROP COLUMN cloned_from;
+ALTER TABLE simulations DROP COLUMN run_number;
+ALTER TABLE simulations DROP COLUMN run_id;
+
+DROP TABLE IF EXISTS simulation_runs;
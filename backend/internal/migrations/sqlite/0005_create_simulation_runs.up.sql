@@ -0,0 +1,60 @@
+-- Jeder Start einer Simulation ist ein eigener Lauf mit eigenen Events, Ressourcen,
+-- Ergebnissen und Seed. Bereits gestartete Simulationen erhalten einen ersten Lauf,
+-- dessen ID ihrer eigenen entspricht.
+
+CREATE TABLE IF NOT EXISTS simulation_runs (
+    id               TEXT PRIMARY KEY,
+    simulation_id    TEXT NOT NULL REFERENCES simulations (id) ON DELETE CASCADE,
+    number           INTEGER NOT NULL,
+    status           TEXT NOT NULL,
+    seed             INTEGER NOT NULL DEFAULT 0,
+    parameters_json  TEXT,
+    start_time       TIMESTAMP,
+    end_time         TIMESTAMP,
+    progress         REAL NOT NULL DEFAULT 0,
+    threats_detected INTEGER NOT NULL DEFAULT 0,
+    results_json     TEXT,
+    error            TEXT,
+    created_at       TIMESTAMP NOT NULL,
+    UNIQUE (simulation_id, number)
+);
+
+ALTER TABLE simulations ADD COLUMN run_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE simulations ADD COLUMN run_number INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE simulations ADD COLUMN cloned_from TEXT NOT NULL DEFAULT '';
+
+INSERT INTO simulation_runs
+(id, simulation_id, number, status, seed, parameters_json, start_time, end_time,
+progress, threats_detected, results_json, error, created_at)
+SELECT id, id, 1, status, seed, parameters_json, start_time, end_time,
+    progress, threats_detected, results_json, error, COALESCE(start_time, updated_at)
+FROM simulations
+WHERE status <> 'not_started';
+
+UPDATE simulations SET run_id = id, run_number = 1 WHERE status <> 'not_started';
+
+ALTER TABLE simulation_events ADD COLUMN run_id TEXT NOT NULL DEFAULT '';
+UPDATE simulation_events SET run_id = simulation_id;
+CREATE INDEX IF NOT EXISTS idx_simulation_events_run ON simulation_events (run_id, sequence);
+
+-- SQLite kann den Primärschlüssel nicht ändern; die Tabelle wird neu angelegt
+CREATE TABLE affected_resources_runs (
+    id              TEXT NOT NULL,
+    simulation_id   TEXT NOT NULL REFERENCES simulations (id) ON DELETE CASCADE,
+    run_id          TEXT NOT NULL DEFAULT '',
+    name            TEXT NOT NULL,
+    type            TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    threat_level    REAL NOT NULL DEFAULT 0,
+    attack_vector   TEXT,
+    vulnerabilities TEXT,
+    PRIMARY KEY (id, simulation_id, run_id)
+);
+
+INSERT INTO affected_resources_runs
+(id, simulation_id, run_id, name, type, status, threat_level, attack_vector, vulnerabilities)
+SELECT id, simulation_id, simulation_id, name, type, status, threat_level, attack_vector, vulnerabilities
+FROM affected_resources;
+
+DROP TABLE affected_resources;
+ALTER TABLE affected_resources_runs RENAME TO affected_resources;
@@ -0,0 +1,22 @@
+-- Zeitpläne, nach denen Simulationen wiederkehrend erstellt und gestartet werden
+
+CREATE TABLE IF NOT EXISTS schedules (
+    id                 TEXT PRIMARY KEY,
+    name               TEXT NOT NULL,
+    description        TEXT NOT NULL DEFAULT '',
+    cron               TEXT NOT NULL,
+    timezone           TEXT NOT NULL DEFAULT 'UTC',
+    infrastructure_id  TEXT NOT NULL DEFAULT '',
+    scenario_id        TEXT NOT NULL DEFAULT '',
+    parameters_json    TEXT,
+    overlap            TEXT NOT NULL DEFAULT 'skip',
+    enabled            BOOLEAN NOT NULL DEFAULT 1,
+    pending            BOOLEAN NOT NULL DEFAULT 0,
+    next_run_at        TIMESTAMP,
+    last_run_at        TIMESTAMP,
+    last_simulation_id TEXT NOT NULL DEFAULT '',
+    last_outcome       TEXT NOT NULL DEFAULT '',
+    last_error         TEXT,
+    created_at         TIMESTAMP NOT NULL,
+    updated_at         TIMESTAMP NOT NULL
+);
@@ -0,0 +1,13 @@
+-- Eigene Angriffsszenarien; die mitgelieferten Szenarien liefert die Engine selbst.
+-- Die Schritte werden als JSON gespeichert.
+
+CREATE TABLE IF NOT EXISTS scenarios (
+    id          TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    difficulty  TEXT NOT NULL DEFAULT '',
+    duration    INTEGER NOT NULL DEFAULT 0,
+    steps_json  TEXT NOT NULL,
+    created_at  TIMESTAMP NOT NULL,
+    updated_at  TIMESTAMP NOT NULL
+);
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -81,8 +82,8 @@ func InstrumentHandler(handler http.Handler) http.Handler {
 		duration := time.Since(start).Seconds()
 		statusCode := rw.statusCode
 		
-		RequestDuration.WithLabelValues(r.Method, r.URL.Path, string(statusCode)).Observe(duration)
-		RequestTotal.WithLabelValues(r.Method, r.URL.Path, string(statusCode)).Inc()
+		RequestDuration.WithLabelValues(r.Method, r.URL.Path, strconv.Itoa(statusCode)).Observe(duration)
+		RequestTotal.WithLabelValues(r.Method, r.URL.Path, strconv.Itoa(statusCode)).Inc()
 	})
 }
 
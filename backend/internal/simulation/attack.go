@@ -0,0 +1,167 @@
+// backend/internal/simulation/attack.go
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// attackState verfolgt, wie weit sich ein Angriff im Netzwerk ausgebreitet hat.
+// Der Angreifer startet an einem Einstiegspunkt und kann sich nur entlang
+// existierender Verbindungen zu weiteren Knoten bewegen.
+type attackState struct {
+	topology    *topology
+	entryPoint  string
+	footholds   []string // Knoten mit Zugang des Angreifers, in Reihenfolge der Übernahme
+	hasFoothold map[string]bool
+}
+
+// attackStep beschreibt Quelle, Ziel und Weg einer einzelnen Angriffsaktion
+type attackStep struct {
+	Source     string // leer bei Aktionen von außerhalb des Netzwerks
+	Target     string
+	Connection *topologyConnection
+	Port       string
+}
+
+// newAttackState wählt einen Einstiegspunkt im Graphen, den der Selektor des ersten
+// Schritts zulässt. Passt kein Knoten, wird ohne Selektor gewählt; die Aktionen des
+// Schritts finden dann kein Ziel.
+func newAttackState(graph *topology, targets *TargetSelector, rng *rand.Rand) *attackState {
+	candidates := entryCandidates(graph, targets)
+	if len(candidates) == 0 {
+		candidates = entryCandidates(graph, nil)
+	}
+
+	return &attackState{
+		topology:    graph,
+		entryPoint:  candidates[rng.Intn(len(candidates))],
+		hasFoothold: make(map[string]bool),
+	}
+}
+
+// entryCandidates gibt die möglichen Einstiegspunkte zurück, die der Selektor zulässt.
+// Bevorzugt werden angebundene Workstations, da Angriffe typischerweise beim Benutzer
+// beginnen, danach beliebige angebundene Knoten.
+func entryCandidates(graph *topology, targets *TargetSelector) []string {
+	var candidates []string
+	for _, id := range graph.nodesOfType("workstation") {
+		if len(graph.edges(id)) > 0 && graph.nodes[id].matches(targets) {
+			candidates = append(candidates, id)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, id := range graph.nodeOrder {
+			if len(graph.edges(id)) > 0 && graph.nodes[id].matches(targets) {
+				candidates = append(candidates, id)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		for _, id := range graph.nodeOrder {
+			if graph.nodes[id].matches(targets) {
+				candidates = append(candidates, id)
+			}
+		}
+	}
+	return candidates
+}
+
+// next bestimmt Quelle und Ziel der nächsten Aktion eines Eventtyps. Lässt der Selektor
+// weder ein erreichbares noch ein bereits übernommenes Ziel zu, gibt next false zurück.
+func (a *attackState) next(eventType EventType, targets *TargetSelector, rng *rand.Rand) (attackStep, bool) {
+	// Ohne Zugang kann der Angreifer nur einen Einstiegspunkt von außen angreifen
+	if len(a.footholds) == 0 {
+		if !a.topology.nodes[a.entryPoint].matches(targets) {
+			candidates := entryCandidates(a.topology, targets)
+			if len(candidates) == 0 {
+				return attackStep{}, false
+			}
+			a.entryPoint = candidates[rng.Intn(len(candidates))]
+		}
+		return attackStep{Target: a.entryPoint}, true
+	}
+
+	switch eventType {
+	case EventTypeEscalation, EventTypeDataExfiltration, EventTypeSystem:
+		// Lokale Aktionen auf einem bereits übernommenen Knoten
+		return a.localStep(targets, rng)
+	}
+
+	// Netzwerkaktionen: nur über Verbindungen von übernommenen zu neuen Knoten
+	var candidates []attackStep
+	for _, source := range a.footholds {
+		for _, edge := range a.topology.edges(source) {
+			if !a.hasFoothold[edge.Neighbor] && a.topology.nodes[edge.Neighbor].matches(targets) {
+				candidates = append(candidates, attackStep{Source: source, Target: edge.Neighbor, Connection: edge.Connection})
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		// Alle erreichbaren Knoten sind bereits übernommen oder keine zulässigen Ziele
+		return a.localStep(targets, rng)
+	}
+
+	step := candidates[rng.Intn(len(candidates))]
+	if ports := step.Connection.Ports; len(ports) > 0 {
+		step.Port = ports[rng.Intn(len(ports))]
+	}
+	return step, true
+}
+
+// localStep wählt einen übernommenen Knoten, den der Selektor zulässt, für eine lokale Aktion
+func (a *attackState) localStep(targets *TargetSelector, rng *rand.Rand) (attackStep, bool) {
+	var candidates []string
+	for _, id := range a.footholds {
+		if a.topology.nodes[id].matches(targets) {
+			candidates = append(candidates, id)
+		}
+	}
+	if len(candidates) == 0 {
+		return attackStep{}, false
+	}
+	node := candidates[rng.Intn(len(candidates))]
+	return attackStep{Source: node, Target: node}, true
+}
+
+// gainFoothold vermerkt, dass der Angreifer Zugang zu einem Knoten erlangt hat
+func (a *attackState) gainFoothold(nodeID string) {
+	if a.hasFoothold[nodeID] {
+		return
+	}
+	a.hasFoothold[nodeID] = true
+	a.footholds = append(a.footholds, nodeID)
+}
+
+// vector beschreibt den Angriffsweg für AffectedResource.AttackVector
+func (s attackStep) vector() string {
+	switch {
+	case s.Connection != nil:
+		protocol := s.Connection.Protocol
+		if protocol == "" {
+			protocol = "TCP"
+		}
+		if s.Port != "" {
+			protocol = fmt.Sprintf("%s/%s", protocol, s.Port)
+		}
+		return fmt.Sprintf("Verbindung %s (%s -> %s) über %s", s.Connection.ID, s.Source, s.Target, protocol)
+	case s.Source == "":
+		return "Initialzugang von extern"
+	default:
+		return fmt.Sprintf("Lokal auf %s", s.Source)
+	}
+}
+
+// threatLevelFor leitet das Bedrohungsniveau einer Ressource aus ihrem Status ab
+func threatLevelFor(status ResourceStatus) float64 {
+	switch status {
+	case ResourceStatusVulnerable:
+		return 0.3
+	case ResourceStatusAttacked:
+		return 0.6
+	case ResourceStatusCompromised:
+		return 1.0
+	default:
+		return 0.1
+	}
+}
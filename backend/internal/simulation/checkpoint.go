@@ -0,0 +1,277 @@
+// backend/internal/simulation/checkpoint.go
+package simulation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+)
+
+// ErrCheckpointNotFound wird von einem Store zurückgegeben, wenn zu einer Simulation kein Checkpoint existiert
+var ErrCheckpointNotFound = errors.New("Checkpoint nicht gefunden")
+
+// Checkpoint ist der gesicherte interne Zustand des Workers einer Simulation. State ist
+// für den Store undurchsichtig; nur die Engine kann ihn wiederherstellen.
+type Checkpoint struct {
+	SimulationID string
+	State        []byte
+	CreatedAt    time.Time
+}
+
+// countingSource ist eine Zufallsquelle, die ihre Ziehungen zählt. Da sich der Zustand
+// von math/rand nicht sichern lässt, wird er beim Fortsetzen durch erneutes Ziehen
+// aus einer Quelle mit demselben Seed wiederhergestellt.
+type countingSource struct {
+	source rand.Source64
+	draws  uint64
+}
+
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{source: rand.NewSource(seed).(rand.Source64)}
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.source.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.source.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.source.Seed(seed)
+	s.draws = 0
+}
+
+// skip zieht n Werte, ohne sie zu verwenden
+func (s *countingSource) skip(n uint64) {
+	for i := uint64(0); i < n; i++ {
+		s.Uint64()
+	}
+}
+
+// runSnapshot ist die serialisierte Form eines runState. Der Plan wird mitgesichert,
+// damit eine Änderung des Szenarios einen laufenden Angriff nicht verändert.
+type runSnapshot struct {
+	Plan        *scenarioPlan         `json:"plan"`
+	Elapsed     time.Duration         `json:"elapsed"`
+	Phase       int                   `json:"phase"`
+	RandomDraws uint64                `json:"randomDraws"`
+	EntryPoint  string                `json:"entryPoint"`
+	Footholds   []string              `json:"footholds"`
+	Succeeded   []string              `json:"succeeded,omitempty"`
+	Untargeted  []string              `json:"untargeted,omitempty"`
+	Nodes       []*topologyNode       `json:"nodes"`
+	Connections []*topologyConnection `json:"connections"`
+}
+
+// snapshotState serialisiert den Zustand eines Workers
+func snapshotState(state *runState) ([]byte, error) {
+	graph := state.attack.topology
+	snapshot := runSnapshot{
+		Plan:        state.plan,
+		Elapsed:     state.elapsed,
+		Phase:       state.phase,
+		RandomDraws: state.source.draws,
+		EntryPoint:  state.attack.entryPoint,
+		Footholds:   state.attack.footholds,
+		Connections: graph.connections,
+	}
+	for _, step := range state.plan.Steps {
+		if state.succeeded[step.Name] {
+			snapshot.Succeeded = append(snapshot.Succeeded, step.Name)
+		}
+		if state.untargeted[step.Name] {
+			snapshot.Untargeted = append(snapshot.Untargeted, step.Name)
+		}
+	}
+	for _, id := range graph.nodeOrder {
+		snapshot.Nodes = append(snapshot.Nodes, graph.nodes[id])
+	}
+	return json.Marshal(snapshot)
+}
+
+// restoreState stellt den Zustand eines Workers aus einem Checkpoint wieder her
+func restoreState(data []byte, seed int64) (*runState, error) {
+	var snapshot runSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("Checkpoint ist beschädigt: %v", err)
+	}
+	if snapshot.Plan == nil || len(snapshot.Plan.Steps) == 0 {
+		return nil, fmt.Errorf("Checkpoint enthält keinen Szenarioplan")
+	}
+	if snapshot.Phase < -1 || snapshot.Phase >= len(snapshot.Plan.Steps) {
+		return nil, fmt.Errorf("Checkpoint verweist auf unbekannten Schritt %d", snapshot.Phase)
+	}
+
+	graph, err := newTopology(snapshot.Nodes, snapshot.Connections)
+	if err != nil {
+		return nil, fmt.Errorf("Infrastruktur im Checkpoint ist ungültig: %v", err)
+	}
+	if graph.nodes[snapshot.EntryPoint] == nil {
+		return nil, fmt.Errorf("Einstiegspunkt %s fehlt in der Infrastruktur", snapshot.EntryPoint)
+	}
+	attack := &attackState{
+		topology:    graph,
+		entryPoint:  snapshot.EntryPoint,
+		hasFoothold: make(map[string]bool),
+	}
+	for _, id := range snapshot.Footholds {
+		if graph.nodes[id] == nil {
+			return nil, fmt.Errorf("Knoten %s fehlt in der Infrastruktur", id)
+		}
+		attack.gainFoothold(id)
+	}
+
+	succeeded := make(map[string]bool)
+	for _, name := range snapshot.Succeeded {
+		succeeded[name] = true
+	}
+	untargeted := make(map[string]bool)
+	for _, name := range snapshot.Untargeted {
+		untargeted[name] = true
+	}
+
+	source := newCountingSource(seed)
+	source.skip(snapshot.RandomDraws)
+	return &runState{
+		rng:     rand.New(source),
+		source:  source,
+		plan:    snapshot.Plan,
+		attack:  attack,
+		elapsed: snapshot.Elapsed,
+		phase:   snapshot.Phase,
+		resumed: true,
+
+		succeeded:  succeeded,
+		untargeted: untargeted,
+	}, nil
+}
+
+// checkpointLocked sichert den Zustand des Workers einer Simulation im Store.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) checkpointLocked(simulationID string, state *runState) {
+	data, err := snapshotState(state)
+	if err != nil {
+		logging.Logger.Errorf("Checkpoint der Simulation %s konnte nicht gespeichert werden: %v", simulationID, err)
+		return
+	}
+	checkpoint := Checkpoint{SimulationID: simulationID, State: data, CreatedAt: e.clock.Now()}
+	e.queueWriteLocked(func(store Store) {
+		if err := store.SaveCheckpoint(checkpoint); err != nil {
+			logging.Logger.Errorf("Checkpoint der Simulation %s konnte nicht gespeichert werden: %v", simulationID, err)
+		}
+	})
+}
+
+// deleteCheckpointLocked verwirft den Checkpoint einer beendeten Simulation.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) deleteCheckpointLocked(simulationID string) {
+	e.queueWriteLocked(func(store Store) {
+		if err := store.DeleteCheckpoint(simulationID); err != nil {
+			logging.Logger.Errorf("Checkpoint der Simulation %s konnte nicht gelöscht werden: %v", simulationID, err)
+		}
+	})
+}
+
+// ResumeInterrupted setzt Simulationen fort, die beim Beenden des Servers liefen,
+// pausiert waren oder warteten. Sie übernehmen den Zustand ihres letzten Checkpoints;
+// fehlt dieser oder ist er unbrauchbar, wird die Simulation mit Begründung als
+// fehlgeschlagen markiert. Gibt die Anzahl fortgesetzter Simulationen zurück.
+func (e *Engine) ResumeInterrupted() int {
+	stored, err := e.store.GetAllSimulations()
+	if err != nil {
+		logging.Logger.Errorf("Unterbrochene Simulationen konnten nicht geladen werden: %v", err)
+		return 0
+	}
+
+	// Laufende und pausierte Simulationen belegten bereits einen Worker; wartende
+	// folgen in der Reihenfolge, in der sie eingereiht wurden
+	var interrupted []*Simulation
+	for _, simulation := range stored {
+		switch simulation.Status {
+		case StatusRunning, StatusPaused, StatusQueued:
+			interrupted = append(interrupted, simulation)
+		}
+	}
+	sort.SliceStable(interrupted, func(i, j int) bool {
+		a, b := interrupted[i], interrupted[j]
+		if (a.Status == StatusQueued) != (b.Status == StatusQueued) {
+			return b.Status == StatusQueued
+		}
+		return a.UpdatedAt.Before(b.UpdatedAt)
+	})
+
+	resumed := 0
+	for _, simulation := range interrupted {
+		if e.resumeFromStore(simulation) {
+			resumed++
+		}
+	}
+	return resumed
+}
+
+// resumeFromStore lädt eine unterbrochene Simulation und startet ihren Worker neu
+func (e *Engine) resumeFromStore(simulation *Simulation) bool {
+	id := simulation.ID
+
+	events, err := e.store.GetEvents(id, simulation.RunID)
+	if err != nil {
+		logging.Logger.Errorf("Events der Simulation %s konnten nicht geladen werden: %v", id, err)
+		return false
+	}
+	resources, err := e.store.GetAffectedResources(id, simulation.RunID)
+	if err != nil {
+		logging.Logger.Errorf("Ressourcen der Simulation %s konnten nicht geladen werden: %v", id, err)
+		return false
+	}
+
+	var state *runState
+	checkpoint, err := e.store.GetCheckpoint(id)
+	if err == nil {
+		state, err = restoreState(checkpoint.State, simulation.Seed)
+	} else if errors.Is(err, ErrCheckpointNotFound) {
+		err = errors.New("kein Checkpoint vorhanden")
+	}
+
+	e.mutex.Lock()
+	defer e.unlock()
+
+	if !e.registerLoadedLocked(simulation, events, resources) {
+		return false
+	}
+	if err != nil {
+		e.failLocked(simulation, fmt.Sprintf("Simulation konnte nach einem Neustart des Servers nicht fortgesetzt werden: %v", err))
+		return false
+	}
+	// Eine wartende Simulation hatte noch nicht begonnen und startet regulär
+	if simulation.Status == StatusQueued {
+		state.resumed = false
+	}
+
+	// Läufe ohne freien Worker warten wie neu gestartete Simulationen
+	if simulation.Status == StatusQueued || !e.scheduler.tryAcquire() {
+		position, err := e.scheduler.enqueue(id)
+		if err != nil {
+			e.failLocked(simulation, fmt.Sprintf("Simulation konnte nach einem Neustart des Servers nicht fortgesetzt werden: %v", err))
+			return false
+		}
+		simulation.Status = StatusQueued
+		simulation.QueuePosition = position
+		e.queuedRuns[id] = state
+		e.saveSimulationLocked(simulation)
+		logging.Logger.Infof("Simulation '%s' (ID: %s) nach Neustart wieder in der Warteschlange (Position %d)", simulation.Name, id, position)
+		return true
+	}
+
+	e.launchLocked(simulation, state)
+	logging.Logger.Infof("Simulation '%s' (ID: %s) nach Neustart fortgesetzt", simulation.Name, id)
+	return true
+}
@@ -0,0 +1,134 @@
+// backend/internal/simulation/checkpoint_test.go
+package simulation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// advanceToCompletion stellt die Uhr Tick für Tick vor, bis die Simulation abgeschlossen ist
+func advanceToCompletion(t *testing.T, engine *Engine, clock *fakeClock, id string) {
+	t.Helper()
+
+	for {
+		if status, _ := simulationStatus(engine, id); status == StatusCompleted {
+			return
+		}
+		before := simulationProgress(engine, id)
+		clock.Advance(baseTickInterval)
+		waitFor(t, "Fortschritt nach Tick", func() bool {
+			status, _ := simulationStatus(engine, id)
+			return status == StatusCompleted || simulationProgress(engine, id) > before
+		})
+	}
+}
+
+// attackTrace ist der Ablauf ohne Systemereignisse wie Pausieren oder Fortsetzen
+func attackTrace(t *testing.T, engine *Engine, id string) []string {
+	t.Helper()
+
+	var trace []string
+	for _, entry := range eventTrace(t, engine, id) {
+		if !strings.HasPrefix(entry, string(EventTypeSystem)+"|") {
+			trace = append(trace, entry)
+		}
+	}
+	return trace
+}
+
+func TestSimulationResumesFromCheckpoint(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	config := SimulationConfig{
+		Name:       "Checkpoint",
+		ScenarioID: "scenario-2",
+		Parameters: map[string]interface{}{ParameterSeed: 11.0},
+	}
+
+	// Ununterbrochener Lauf als Referenz
+	referenceClock := newFakeClock(start)
+	reference := NewEngine(WithClock(referenceClock))
+	expected, err := reference.CreateSimulation(config)
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	reference.StartSimulation(expected.ID)
+	waitFor(t, "Ticker von Worker und Watchdog", func() bool { return referenceClock.activeTickers() == 2 })
+	advanceToCompletion(t, reference, referenceClock, expected.ID)
+
+	// Derselbe Lauf wird nach einigen Ticks pausiert und der Server "neu gestartet"
+	store := NewMemoryStore()
+	firstClock := newFakeClock(start)
+	first := NewEngine(WithClock(firstClock), WithStore(store))
+	sim, err := first.CreateSimulation(config)
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	first.StartSimulation(sim.ID)
+	waitFor(t, "Ticker von Worker und Watchdog", func() bool { return firstClock.activeTickers() == 2 })
+	for tick := 0; tick < 5; tick++ {
+		before := simulationProgress(first, sim.ID)
+		firstClock.Advance(baseTickInterval)
+		waitFor(t, "Fortschritt nach Tick", func() bool { return simulationProgress(first, sim.ID) > before })
+	}
+	if _, err := first.PauseSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Pausieren der Simulation: %v", err)
+	}
+	waitFor(t, "Checkpoint der pausierten Simulation", func() bool {
+		checkpoint, err := store.GetCheckpoint(sim.ID)
+		return err == nil && len(checkpoint.State) > 0
+	})
+	progress := simulationProgress(first, sim.ID)
+
+	secondClock := newFakeClock(start.Add(time.Hour))
+	second := NewEngine(WithClock(secondClock), WithStore(store))
+	if resumed := second.ResumeInterrupted(); resumed != 1 {
+		t.Fatalf("Erwartet: 1 fortgesetzte Simulation, Erhalten: %d", resumed)
+	}
+	if status, _ := simulationStatus(second, sim.ID); status != StatusPaused {
+		t.Fatalf("Pausierte Simulation sollte pausiert bleiben, ist aber %s", status)
+	}
+	if resumedProgress := simulationProgress(second, sim.ID); resumedProgress != progress {
+		t.Fatalf("Fortschritt nach Neustart: %v, erwartet: %v", resumedProgress, progress)
+	}
+
+	if _, err := second.ResumeSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Fortsetzen der Simulation: %v", err)
+	}
+	waitFor(t, "Ticker von Worker und Watchdog", func() bool { return secondClock.activeTickers() == 2 })
+	advanceToCompletion(t, second, secondClock, sim.ID)
+
+	if !reflect.DeepEqual(attackTrace(t, reference, expected.ID), attackTrace(t, second, sim.ID)) {
+		t.Fatal("Fortgesetzter Lauf weicht vom ununterbrochenen Lauf ab")
+	}
+	if _, err := store.GetCheckpoint(sim.ID); err == nil {
+		t.Fatal("Checkpoint sollte nach Abschluss gelöscht sein")
+	}
+}
+
+func TestResumeWithoutCheckpointFails(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	store := NewMemoryStore()
+	first := NewEngine(WithClock(clock), WithStore(store))
+
+	sim, err := first.CreateSimulation(SimulationConfig{Name: "Ohne Checkpoint"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	first.StartSimulation(sim.ID)
+	defer first.StopSimulation(sim.ID)
+	store.DeleteCheckpoint(sim.ID)
+
+	second := NewEngine(WithClock(clock), WithStore(store))
+	if resumed := second.ResumeInterrupted(); resumed != 0 {
+		t.Fatalf("Erwartet: keine fortgesetzte Simulation, Erhalten: %d", resumed)
+	}
+	reloaded, err := second.GetSimulation(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Laden der Simulation: %v", err)
+	}
+	if reloaded.Status != StatusFailed || !strings.Contains(reloaded.Error, "kein Checkpoint") {
+		t.Fatalf("Simulation sollte mit Begründung fehlschlagen, ist aber %s (%q)", reloaded.Status, reloaded.Error)
+	}
+}
@@ -0,0 +1,200 @@
+// backend/internal/simulation/clock_test.go
+package simulation
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock ist eine manuell gesteuerte Clock für Tests
+type fakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+func newFakeClock(start time.Time) *fakeClock {
+	return &fakeClock{now: start}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ticker := &fakeTicker{clock: c, interval: d, next: c.now.Add(d), ch: make(chan time.Time, 1)}
+	c.tickers = append(c.tickers, ticker)
+	return ticker
+}
+
+// Advance stellt die Zeit vor und löst fällige Ticker aus
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, ticker := range c.tickers {
+		for !ticker.stopped && !ticker.next.After(c.now) {
+			select {
+			case ticker.ch <- ticker.next:
+			default:
+			}
+			ticker.next = ticker.next.Add(ticker.interval)
+		}
+	}
+}
+
+// activeTickers gibt die Anzahl der nicht gestoppten Ticker zurück
+func (c *fakeClock) activeTickers() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	count := 0
+	for _, ticker := range c.tickers {
+		if !ticker.stopped {
+			count++
+		}
+	}
+	return count
+}
+
+type fakeTicker struct {
+	clock    *fakeClock
+	interval time.Duration
+	next     time.Time
+	stopped  bool
+	ch       chan time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	t.stopped = true
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	t.interval = d
+	t.next = t.clock.now.Add(d)
+	t.stopped = false
+}
+
+// waitFor wartet, bis die Bedingung erfüllt ist, und bricht den Test sonst ab
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Zeitüberschreitung beim Warten auf: %s", description)
+}
+
+func TestEngineWithFakeClock(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock))
+
+	sim, err := engine.CreateSimulation(SimulationConfig{Name: "Fake Clock"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	if _, err := engine.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+	waitFor(t, "Ticker von Worker und Watchdog", func() bool { return clock.activeTickers() == 2 })
+
+	// Ohne Zeitfortschritt passiert nichts
+	if progress := simulationProgress(engine, sim.ID); progress != 0 {
+		t.Fatalf("Erwarteter Fortschritt: 0, Erhaltener Fortschritt: %v", progress)
+	}
+
+	plan, err := engine.loadScenarioPlan(defaultScenarioID)
+	if err != nil {
+		t.Fatalf("Fehler beim Laden des Szenarios: %v", err)
+	}
+
+	// Jedes Intervall schreibt die simulierte Zeit um genau ein Update fort
+	for step := 1; step <= 3; step++ {
+		clock.Advance(baseTickInterval)
+		expected := float64(step) * float64(baseTickInterval) / float64(plan.totalDuration())
+		waitFor(t, "Fortschritt nach Tick", func() bool {
+			return simulationProgress(engine, sim.ID) >= expected-1e-9
+		})
+	}
+
+	status, err := engine.GetSimulationStatus(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen des Status: %v", err)
+	}
+	if status.Runtime != "00:00:30" {
+		t.Fatalf("Erwartete Laufzeit: 00:00:30, Erhaltene Laufzeit: %s", status.Runtime)
+	}
+
+	engine.StopSimulation(sim.ID)
+}
+
+func TestInstantSimulation(t *testing.T) {
+	engine := NewEngine()
+
+	sim, err := engine.CreateSimulation(SimulationConfig{
+		Name:       "Instant",
+		Parameters: map[string]interface{}{ParameterSpeed: SpeedInstant},
+	})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	if _, err := engine.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+
+	waitFor(t, "Abschluss der Simulation", func() bool {
+		status, _ := engine.GetSimulationStatus(sim.ID)
+		return status.Status == StatusCompleted
+	})
+}
+
+func TestInvalidSpeedParameter(t *testing.T) {
+	engine := NewEngine()
+
+	for _, speed := range []interface{}{"5x", 0.5, "fast", true} {
+		_, err := engine.CreateSimulation(SimulationConfig{
+			Name:       "Ungültig",
+			Parameters: map[string]interface{}{ParameterSpeed: speed},
+		})
+		if !errors.Is(err, ErrInvalidParameter) {
+			t.Fatalf("Erwarteter Fehler für speed=%v, erhalten: %v", speed, err)
+		}
+	}
+
+	for _, speed := range []interface{}{1.0, "10x", "100", SpeedInstant} {
+		if _, err := engine.CreateSimulation(SimulationConfig{
+			Name:       "Gültig",
+			Parameters: map[string]interface{}{ParameterSpeed: speed},
+		}); err != nil {
+			t.Fatalf("Unerwarteter Fehler für speed=%v: %v", speed, err)
+		}
+	}
+}
+
+// simulationProgress liest den Fortschritt threadsicher aus
+func simulationProgress(engine *Engine, id string) float64 {
+	status, err := engine.GetSimulationStatus(id)
+	if err != nil {
+		return -1
+	}
+	return status.Progress
+}
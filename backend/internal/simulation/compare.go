@@ -0,0 +1,350 @@
+// backend/internal/simulation/compare.go
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRunNotFinished wird zurückgegeben, wenn ein Lauf verglichen werden soll, der noch
+// nicht begonnen oder noch nicht geendet hat
+var ErrRunNotFinished = errors.New("Lauf ist nicht beendet")
+
+// Change bewertet die Veränderung einer Kennzahl von Lauf A zu Lauf B aus Sicht des
+// Verteidigers
+type Change string
+
+const (
+	ChangeImproved  Change = "improved"
+	ChangeRegressed Change = "regressed"
+	ChangeUnchanged Change = "unchanged"
+)
+
+// Verdict ist das Gesamturteil eines Vergleichs
+type Verdict string
+
+const (
+	VerdictImprovement Verdict = "improvement" // mindestens eine Kennzahl besser, keine schlechter
+	VerdictRegression  Verdict = "regression"  // mindestens eine Kennzahl schlechter, keine besser
+	VerdictMixed       Verdict = "mixed"       // Kennzahlen in beide Richtungen verändert
+	VerdictUnchanged   Verdict = "unchanged"
+)
+
+// RunSelector wählt einen Lauf einer Simulation aus. Ohne RunID gilt der aktuelle Lauf.
+type RunSelector struct {
+	SimulationID string
+	RunID        string
+}
+
+// ComparedRun beschreibt einen der beiden verglichenen Läufe
+type ComparedRun struct {
+	SimulationID string     `json:"simulationId"`
+	Name         string     `json:"name"`
+	RunID        string     `json:"runId"`
+	RunNumber    int        `json:"runNumber"`
+	Status       Status     `json:"status"`
+	Seed         int64      `json:"seed"`
+	EndTime      *time.Time `json:"endTime,omitempty"`
+}
+
+// MetricComparison stellt eine Kennzahl beider Läufe gegenüber; Delta ist B - A
+type MetricComparison struct {
+	A      float64 `json:"a"`
+	B      float64 `json:"b"`
+	Delta  float64 `json:"delta"`
+	Change Change  `json:"change"`
+}
+
+// TimeComparison stellt die simulierte Zeit bis zur ersten Kompromittierung in Sekunden
+// gegenüber. Ohne Kompromittierung fehlt der Wert des Laufs.
+type TimeComparison struct {
+	A      *float64 `json:"a"`
+	B      *float64 `json:"b"`
+	Delta  *float64 `json:"delta,omitempty"`
+	Change Change   `json:"change"`
+}
+
+// SeverityComparison stellt die Anzahl der Events eines Schweregrads gegenüber
+type SeverityComparison struct {
+	A     int `json:"a"`
+	B     int `json:"b"`
+	Delta int `json:"delta"`
+}
+
+// PhaseComparison stellt einen Szenarioschritt gegenüber. Ein Schritt gilt als
+// erreicht, wenn dem Angreifer darin mindestens eine Aktion gelungen ist.
+type PhaseComparison struct {
+	Name       string `json:"name"`
+	ReachedA   bool   `json:"reachedA"`
+	ReachedB   bool   `json:"reachedB"`
+	SucceededA int    `json:"succeededA"`
+	SucceededB int    `json:"succeededB"`
+	DetectedA  int    `json:"detectedA"`
+	DetectedB  int    `json:"detectedB"`
+	Change     Change `json:"change"`
+}
+
+// RunComparison ist der Vergleich zweier Läufe auf derselben Infrastruktur. A ist der
+// Ausgangslauf, B der Lauf nach den Änderungen; alle Bewertungen beschreiben B
+// gegenüber A.
+type RunComparison struct {
+	A                     ComparedRun                     `json:"a"`
+	B                     ComparedRun                     `json:"b"`
+	InfrastructureID      string                          `json:"infrastructureId"`
+	CompromisedOnlyInA    []CompromisedAsset              `json:"compromisedOnlyInA"`
+	CompromisedOnlyInB    []CompromisedAsset              `json:"compromisedOnlyInB"`
+	CompromisedInBoth     []CompromisedAsset              `json:"compromisedInBoth"`
+	Compromised           MetricComparison                `json:"compromised"`
+	PhaseReach            MetricComparison                `json:"phaseReach"`
+	Phases                []PhaseComparison               `json:"phases"`
+	Severity              map[Severity]SeverityComparison `json:"severity"`
+	SevereEvents          MetricComparison                `json:"severeEvents"` // Events mit Schweregrad high oder critical
+	DetectionRate         MetricComparison                `json:"detectionRate"`
+	TimeToFirstCompromise TimeComparison                  `json:"timeToFirstCompromise"`
+	Verdict               Verdict                         `json:"verdict"`
+}
+
+// runData sind die gespeicherten Events und Ressourcen eines Laufs mit dem daraus
+// berechneten Bericht
+type runData struct {
+	run       ComparedRun
+	events    []SimulationEvent
+	resources []AffectedResource
+	results   *SimulationResults
+}
+
+// CompareRuns vergleicht zwei beendete Läufe auf derselben Infrastruktur. Grundlage
+// sind die gespeicherten Events und Ressourcen der Läufe, nicht ihre Berichte.
+func (e *Engine) CompareRuns(a, b RunSelector) (*RunComparison, error) {
+	dataA, infrastructureA, err := e.loadRunData(a)
+	if err != nil {
+		return nil, err
+	}
+	dataB, infrastructureB, err := e.loadRunData(b)
+	if err != nil {
+		return nil, err
+	}
+	if infrastructureA != infrastructureB {
+		return nil, fmt.Errorf("%w: die Läufe verwenden unterschiedliche Infrastrukturen (%q, %q)", ErrInvalidParameter, infrastructureA, infrastructureB)
+	}
+
+	comparison := compareRuns(dataA, dataB)
+	comparison.InfrastructureID = infrastructureA
+	return comparison, nil
+}
+
+// loadRunData lädt einen beendeten Lauf und gibt ihn mit der Infrastruktur seiner
+// Simulation zurück
+func (e *Engine) loadRunData(selector RunSelector) (*runData, string, error) {
+	simulation, err := e.GetSimulation(selector.SimulationID)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %s", ErrSimulationNotFound, selector.SimulationID)
+	}
+
+	e.mutex.RLock()
+	name, infrastructureID, runID := simulation.Name, simulation.InfrastructureID, simulation.RunID
+	e.mutex.RUnlock()
+
+	if selector.RunID != "" {
+		runID = selector.RunID
+	}
+	if runID == "" {
+		return nil, "", fmt.Errorf("%w: Simulation %s wurde noch nicht gestartet", ErrRunNotFinished, selector.SimulationID)
+	}
+
+	run, err := e.GetRun(selector.SimulationID, runID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !containsStatus(finishedStatuses, run.Status) {
+		return nil, "", fmt.Errorf("%w: Lauf %d der Simulation %s hat den Status %s", ErrRunNotFinished, run.Number, selector.SimulationID, run.Status)
+	}
+
+	events, err := e.store.GetEvents(selector.SimulationID, runID)
+	if err != nil {
+		return nil, "", err
+	}
+	resources, err := e.store.GetAffectedResources(selector.SimulationID, runID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Der Bericht wird aus den gespeicherten Daten neu berechnet
+	finished := &Simulation{Status: run.Status, Error: run.Error, StartTime: run.StartTime, EndTime: run.EndTime}
+	return &runData{
+		run: ComparedRun{
+			SimulationID: selector.SimulationID,
+			Name:         name,
+			RunID:        run.ID,
+			RunNumber:    run.Number,
+			Status:       run.Status,
+			Seed:         run.Seed,
+			EndTime:      run.EndTime,
+		},
+		events:    events,
+		resources: resources,
+		results:   buildResults(finished, events, resources, e.clock.Now()),
+	}, infrastructureID, nil
+}
+
+// compareRuns stellt die Kennzahlen zweier Läufe gegenüber und fällt das Urteil
+func compareRuns(a, b *runData) *RunComparison {
+	comparison := &RunComparison{
+		A:                  a.run,
+		B:                  b.run,
+		CompromisedOnlyInA: []CompromisedAsset{},
+		CompromisedOnlyInB: []CompromisedAsset{},
+		CompromisedInBoth:  []CompromisedAsset{},
+		Phases:             []PhaseComparison{},
+		Severity:           make(map[Severity]SeverityComparison),
+	}
+
+	// Kompromittierte Ressourcen
+	inA := make(map[string]bool)
+	for _, asset := range a.results.CompromisedAssets {
+		inA[asset.ID] = true
+	}
+	inB := make(map[string]bool)
+	for _, asset := range b.results.CompromisedAssets {
+		inB[asset.ID] = true
+		if inA[asset.ID] {
+			comparison.CompromisedInBoth = append(comparison.CompromisedInBoth, asset)
+		} else {
+			comparison.CompromisedOnlyInB = append(comparison.CompromisedOnlyInB, asset)
+		}
+	}
+	for _, asset := range a.results.CompromisedAssets {
+		if !inB[asset.ID] {
+			comparison.CompromisedOnlyInA = append(comparison.CompromisedOnlyInA, asset)
+		}
+	}
+	comparison.Compromised = compareMetric(float64(len(a.results.CompromisedAssets)), float64(len(b.results.CompromisedAssets)), false)
+
+	// Reichweite des Angriffs: Schritte in der Reihenfolge ihres ersten Auftretens in A, dann B
+	phaseIndex := make(map[string]int)
+	phaseFor := func(name string) *PhaseComparison {
+		index, exists := phaseIndex[name]
+		if !exists {
+			index = len(comparison.Phases)
+			phaseIndex[name] = index
+			comparison.Phases = append(comparison.Phases, PhaseComparison{Name: name})
+		}
+		return &comparison.Phases[index]
+	}
+	reachA, reachB := 0, 0
+	for _, phase := range a.results.Phases {
+		compared := phaseFor(phase.Name)
+		compared.ReachedA, compared.SucceededA, compared.DetectedA = phase.Succeeded > 0, phase.Succeeded, phase.Detected
+		if compared.ReachedA {
+			reachA++
+		}
+	}
+	for _, phase := range b.results.Phases {
+		compared := phaseFor(phase.Name)
+		compared.ReachedB, compared.SucceededB, compared.DetectedB = phase.Succeeded > 0, phase.Succeeded, phase.Detected
+		if compared.ReachedB {
+			reachB++
+		}
+	}
+	for i := range comparison.Phases {
+		phase := &comparison.Phases[i]
+		switch {
+		case phase.ReachedA && !phase.ReachedB:
+			phase.Change = ChangeImproved
+		case !phase.ReachedA && phase.ReachedB:
+			phase.Change = ChangeRegressed
+		default:
+			phase.Change = ChangeUnchanged
+		}
+	}
+	comparison.PhaseReach = compareMetric(float64(reachA), float64(reachB), false)
+
+	// Verteilung der Schweregrade
+	for severity := range severityRank {
+		countA, countB := a.results.SeverityCounts[severity], b.results.SeverityCounts[severity]
+		comparison.Severity[severity] = SeverityComparison{A: countA, B: countB, Delta: countB - countA}
+	}
+	severeA := a.results.SeverityCounts[SeverityHigh] + a.results.SeverityCounts[SeverityCritical]
+	severeB := b.results.SeverityCounts[SeverityHigh] + b.results.SeverityCounts[SeverityCritical]
+	comparison.SevereEvents = compareMetric(float64(severeA), float64(severeB), false)
+
+	comparison.DetectionRate = compareMetric(a.results.DetectionRate, b.results.DetectionRate, true)
+	comparison.TimeToFirstCompromise = compareFirstCompromise(a.events, b.events)
+
+	comparison.Verdict = verdict(
+		comparison.Compromised.Change,
+		comparison.PhaseReach.Change,
+		comparison.SevereEvents.Change,
+		comparison.DetectionRate.Change,
+		comparison.TimeToFirstCompromise.Change,
+	)
+	return comparison
+}
+
+// compareMetric bewertet eine Kennzahl; higherIsBetter gibt an, ob ein höherer Wert
+// für den Verteidiger besser ist
+func compareMetric(a, b float64, higherIsBetter bool) MetricComparison {
+	metric := MetricComparison{A: a, B: b, Delta: b - a, Change: ChangeUnchanged}
+	if a == b {
+		return metric
+	}
+	if (b > a) == higherIsBetter {
+		metric.Change = ChangeImproved
+	} else {
+		metric.Change = ChangeRegressed
+	}
+	return metric
+}
+
+// compareFirstCompromise bewertet die Zeit bis zur ersten Kompromittierung. Später ist
+// besser, gar keine Kompromittierung am besten.
+func compareFirstCompromise(eventsA, eventsB []SimulationEvent) TimeComparison {
+	comparison := TimeComparison{Change: ChangeUnchanged}
+	secondsA, okA := firstCompromise(eventsA)
+	secondsB, okB := firstCompromise(eventsB)
+	if okA {
+		comparison.A = &secondsA
+	}
+	if okB {
+		comparison.B = &secondsB
+	}
+
+	switch {
+	case okA && okB:
+		delta := secondsB - secondsA
+		comparison.Delta = &delta
+		if delta > 0 {
+			comparison.Change = ChangeImproved
+		} else if delta < 0 {
+			comparison.Change = ChangeRegressed
+		}
+	case okA:
+		comparison.Change = ChangeImproved
+	case okB:
+		comparison.Change = ChangeRegressed
+	}
+	return comparison
+}
+
+// verdict fasst die Bewertungen der einzelnen Kennzahlen zusammen
+func verdict(changes ...Change) Verdict {
+	improved, regressed := false, false
+	for _, change := range changes {
+		switch change {
+		case ChangeImproved:
+			improved = true
+		case ChangeRegressed:
+			regressed = true
+		}
+	}
+	switch {
+	case improved && regressed:
+		return VerdictMixed
+	case improved:
+		return VerdictImprovement
+	case regressed:
+		return VerdictRegression
+	}
+	return VerdictUnchanged
+}
@@ -0,0 +1,137 @@
+// backend/internal/simulation/compare_test.go
+package simulation
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// comparedRun berechnet den Bericht eines Laufs aus vorgegebenen Events und Ressourcen
+func comparedRun(events []SimulationEvent, resources []AffectedResource) *runData {
+	finished := &Simulation{Status: StatusCompleted}
+	return &runData{events: events, resources: resources, results: buildResults(finished, events, resources, finished.CreatedAt)}
+}
+
+// attackEvent erzeugt eine Angriffsaktion in einem Szenarioschritt
+func attackEvent(phase string, seconds float64, outcome EventOutcome, detected bool, impact ResourceStatus) SimulationEvent {
+	severity := SeverityMedium
+	if impact == ResourceStatusCompromised {
+		severity = SeverityCritical
+	}
+	return SimulationEvent{
+		Type:             EventTypeExploitation,
+		Severity:         severity,
+		Phase:            phase,
+		Outcome:          outcome,
+		Detected:         detected,
+		SimulatedSeconds: seconds,
+		Details:          map[string]interface{}{"impact": string(impact)},
+	}
+}
+
+func TestCompareRunsVerdict(t *testing.T) {
+	before := comparedRun([]SimulationEvent{
+		attackEvent("Erkundung", 10, OutcomeSucceeded, false, ResourceStatusAttacked),
+		attackEvent("Zugriff", 60, OutcomeSucceeded, false, ResourceStatusCompromised),
+		attackEvent("Ausweitung", 120, OutcomeSucceeded, true, ResourceStatusCompromised),
+	}, []AffectedResource{
+		{ID: "web", Status: ResourceStatusCompromised},
+		{ID: "db", Status: ResourceStatusCompromised},
+	})
+	after := comparedRun([]SimulationEvent{
+		attackEvent("Erkundung", 10, OutcomeSucceeded, true, ResourceStatusAttacked),
+		attackEvent("Zugriff", 90, OutcomeSucceeded, true, ResourceStatusCompromised),
+		attackEvent("Ausweitung", 120, OutcomeBlocked, true, ""),
+	}, []AffectedResource{
+		{ID: "web", Status: ResourceStatusCompromised},
+		{ID: "db", Status: ResourceStatusAttacked},
+	})
+
+	comparison := compareRuns(before, after)
+	if comparison.Verdict != VerdictImprovement {
+		t.Fatalf("Erwartetes Urteil: %s, Erhalten: %s", VerdictImprovement, comparison.Verdict)
+	}
+	if len(comparison.CompromisedOnlyInA) != 1 || comparison.CompromisedOnlyInA[0].ID != "db" ||
+		len(comparison.CompromisedOnlyInB) != 0 || len(comparison.CompromisedInBoth) != 1 {
+		t.Fatalf("Unerwartete Ressourcen: nur A %v, nur B %v, beide %v",
+			comparison.CompromisedOnlyInA, comparison.CompromisedOnlyInB, comparison.CompromisedInBoth)
+	}
+	if comparison.PhaseReach.A != 3 || comparison.PhaseReach.B != 2 || comparison.Phases[2].Change != ChangeImproved {
+		t.Fatalf("Unerwartete Reichweite: %+v, Schritte: %+v", comparison.PhaseReach, comparison.Phases)
+	}
+	if severity := comparison.Severity[SeverityCritical]; severity.A != 2 || severity.B != 1 || severity.Delta != -1 {
+		t.Fatalf("Unerwartete Anzahl kritischer Events: %+v", severity)
+	}
+	if comparison.DetectionRate.Change != ChangeImproved {
+		t.Fatalf("Die Erkennungsrate sollte gestiegen sein: %+v", comparison.DetectionRate)
+	}
+	if delay := comparison.TimeToFirstCompromise; delay.Delta == nil || *delay.Delta != 30 || delay.Change != ChangeImproved {
+		t.Fatalf("Unerwartete Zeit bis zur ersten Kompromittierung: %+v", delay)
+	}
+
+	// In umgekehrter Richtung ist derselbe Unterschied eine Verschlechterung
+	if reversed := compareRuns(after, before); reversed.Verdict != VerdictRegression {
+		t.Fatalf("Erwartetes Urteil: %s, Erhalten: %s", VerdictRegression, reversed.Verdict)
+	}
+}
+
+// assertCompareRuns prüft den Vergleich gespeicherter Läufe über die Engine
+func assertCompareRuns(t *testing.T, store Store) {
+	t.Helper()
+
+	engine := NewEngine(WithStore(store))
+	source := runInstant(t, engine, SimulationConfig{Name: "Vorher", InfrastructureID: "infra-1", ScenarioID: "scenario-2"})
+	clone, err := engine.CloneSimulation(source.ID, SimulationConfig{Name: "Nachher"})
+	if err != nil {
+		t.Fatalf("Fehler beim Kopieren der Simulation: %v", err)
+	}
+
+	// Die Kopie ist noch nicht gelaufen
+	if _, err := engine.CompareRuns(RunSelector{SimulationID: source.ID}, RunSelector{SimulationID: clone.ID}); !errors.Is(err, ErrRunNotFinished) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrRunNotFinished, err)
+	}
+
+	// Mit demselben Seed verläuft die Kopie gleich
+	rerun(t, engine, clone.ID)
+	comparison, err := engine.CompareRuns(RunSelector{SimulationID: source.ID}, RunSelector{SimulationID: clone.ID})
+	if err != nil {
+		t.Fatalf("Fehler beim Vergleichen: %v", err)
+	}
+	if comparison.Verdict != VerdictUnchanged || len(comparison.CompromisedOnlyInA)+len(comparison.CompromisedOnlyInB) != 0 {
+		t.Fatalf("Gleiche Läufe sollten unverändert sein, Urteil: %s", comparison.Verdict)
+	}
+	if comparison.InfrastructureID != "infra-1" || comparison.A.RunID != source.RunID || comparison.B.RunID != clone.RunID {
+		t.Fatalf("Unerwartete Läufe: %+v, %+v", comparison.A, comparison.B)
+	}
+	if comparison.Compromised.A != float64(len(source.Results.CompromisedAssets)) {
+		t.Fatalf("Erwartet: %d kompromittierte Ressourcen, Erhalten: %v", len(source.Results.CompromisedAssets), comparison.Compromised.A)
+	}
+
+	// Zwei Läufe derselben Simulation
+	firstRun := source.RunID
+	rerun(t, engine, source.ID)
+	if _, err := engine.CompareRuns(RunSelector{SimulationID: source.ID, RunID: firstRun}, RunSelector{SimulationID: source.ID}); err != nil {
+		t.Fatalf("Fehler beim Vergleichen zweier Läufe: %v", err)
+	}
+	if _, err := engine.CompareRuns(RunSelector{SimulationID: source.ID, RunID: "kein-lauf"}, RunSelector{SimulationID: clone.ID}); !errors.Is(err, ErrRunNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrRunNotFound, err)
+	}
+
+	// Unterschiedliche Infrastrukturen lassen sich nicht vergleichen
+	other := runInstant(t, engine, SimulationConfig{Name: "Andere", InfrastructureID: "infra-2", ScenarioID: "scenario-2"})
+	if _, err := engine.CompareRuns(RunSelector{SimulationID: source.ID}, RunSelector{SimulationID: other.ID}); !errors.Is(err, ErrInvalidParameter) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrInvalidParameter, err)
+	}
+	if _, err := engine.CompareRuns(RunSelector{SimulationID: "unbekannt"}, RunSelector{SimulationID: source.ID}); !errors.Is(err, ErrSimulationNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrSimulationNotFound, err)
+	}
+}
+
+func TestCompareRunsInMemory(t *testing.T) {
+	assertCompareRuns(t, NewMemoryStore())
+}
+
+func TestCompareRunsInSQLite(t *testing.T) {
+	assertCompareRuns(t, openSQLiteRepository(t, filepath.Join(t.TempDir(), "aegis.db")))
+}
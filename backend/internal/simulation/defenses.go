@@ -0,0 +1,131 @@
+// backend/internal/simulation/defenses.go
+package simulation
+
+import (
+	"math/rand"
+)
+
+// ControlType bezeichnet eine Abwehrmaßnahme auf einem Knoten
+type ControlType string
+
+const (
+	ControlEDR      ControlType = "edr"
+	ControlIDS      ControlType = "ids"
+	ControlFirewall ControlType = "firewall"
+	ControlMFA      ControlType = "mfa"
+	ControlLogging  ControlType = "logging"
+)
+
+// DefensiveControl beschreibt eine Abwehrmaßnahme und wie wirksam sie ist
+type DefensiveControl struct {
+	Type                 ControlType `json:"type"`
+	Coverage             float64     `json:"coverage"`             // Anteil der Aktionen, die die Maßnahme überhaupt erfasst
+	DetectionProbability float64     `json:"detectionProbability"` // Wahrscheinlichkeit, eine erfasste Aktion zu erkennen
+	BlockProbability     float64     `json:"blockProbability"`     // Wahrscheinlichkeit, eine erfasste Aktion zu verhindern
+}
+
+// defaultControls sind die Abwehrmaßnahmen je Knotentyp, wenn die Infrastruktur keine angibt
+var defaultControls = map[string][]DefensiveControl{
+	"workstation": {
+		{Type: ControlEDR, Coverage: 0.7, DetectionProbability: 0.6, BlockProbability: 0.3},
+		{Type: ControlMFA, Coverage: 0.5, DetectionProbability: 0.1, BlockProbability: 0.6},
+		{Type: ControlLogging, Coverage: 1.0, DetectionProbability: 0.15},
+	},
+	"server": {
+		{Type: ControlEDR, Coverage: 0.8, DetectionProbability: 0.7, BlockProbability: 0.4},
+		{Type: ControlFirewall, Coverage: 1.0, DetectionProbability: 0.1, BlockProbability: 0.3},
+		{Type: ControlMFA, Coverage: 0.6, DetectionProbability: 0.1, BlockProbability: 0.7},
+		{Type: ControlLogging, Coverage: 1.0, DetectionProbability: 0.3},
+	},
+	"router": {
+		{Type: ControlFirewall, Coverage: 1.0, DetectionProbability: 0.2, BlockProbability: 0.5},
+		{Type: ControlIDS, Coverage: 0.8, DetectionProbability: 0.6},
+	},
+}
+
+// fallbackControls gelten für Knotentypen ohne eigene Standardmaßnahmen
+var fallbackControls = []DefensiveControl{
+	{Type: ControlLogging, Coverage: 1.0, DetectionProbability: 0.2},
+}
+
+// controlsFor gibt Kopien der Standardmaßnahmen für einen Knotentyp zurück
+func controlsFor(nodeType string) []DefensiveControl {
+	controls, exists := defaultControls[nodeType]
+	if !exists {
+		controls = fallbackControls
+	}
+	return append([]DefensiveControl(nil), controls...)
+}
+
+// controlsFromList liest Abwehrmaßnahmen aus einer Infrastrukturdefinition
+func controlsFromList(value interface{}) []DefensiveControl {
+	var controls []DefensiveControl
+	for _, raw := range toMapList(value) {
+		control := DefensiveControl{
+			Type:                 ControlType(toString(raw["type"])),
+			Coverage:             toFloat(raw["coverage"]),
+			DetectionProbability: toFloat(raw["detectionProbability"]),
+			BlockProbability:     toFloat(raw["blockProbability"]),
+		}
+		if control.Type != "" {
+			controls = append(controls, control)
+		}
+	}
+	return controls
+}
+
+// appliesTo gibt an, ob eine Maßnahme eine Aktion des Eventtyps erfassen kann.
+// Netzwerkbasierte Maßnahmen greifen nur bei Aktionen über eine Verbindung.
+func (c DefensiveControl) appliesTo(eventType EventType, viaNetwork bool) bool {
+	switch c.Type {
+	case ControlEDR:
+		return eventType != EventTypeDiscovery && eventType != EventTypeSystem
+	case ControlIDS:
+		return viaNetwork || eventType == EventTypeDataExfiltration
+	case ControlFirewall:
+		return viaNetwork
+	case ControlMFA:
+		return eventType == EventTypeLateralMovement || eventType == EventTypeEscalation
+	case ControlLogging:
+		return eventType != EventTypeSystem
+	default:
+		return false
+	}
+}
+
+// defenseOutcome ist das Ergebnis der Abwehr für eine einzelne Angriffsaktion
+type defenseOutcome struct {
+	Detected   bool
+	Blocked    bool
+	DetectedBy ControlType
+	BlockedBy  ControlType
+}
+
+// evaluateDefenses prüft alle zuständigen Maßnahmen des Zielknotens gegen eine Aktion.
+// Pro zuständiger Maßnahme werden immer drei Zufallswerte gezogen, damit der Ablauf
+// unabhängig vom Ergebnis reproduzierbar bleibt.
+func evaluateDefenses(controls []DefensiveControl, eventType EventType, viaNetwork bool, rng *rand.Rand) defenseOutcome {
+	var outcome defenseOutcome
+	for _, control := range controls {
+		if !control.appliesTo(eventType, viaNetwork) {
+			continue
+		}
+
+		covered := rng.Float64() < control.Coverage
+		detected := rng.Float64() < control.DetectionProbability
+		blocked := rng.Float64() < control.BlockProbability
+		if !covered {
+			continue
+		}
+
+		if detected && !outcome.Detected {
+			outcome.Detected = true
+			outcome.DetectedBy = control.Type
+		}
+		if blocked && !outcome.Blocked {
+			outcome.Blocked = true
+			outcome.BlockedBy = control.Type
+		}
+	}
+	return outcome
+}
@@ -0,0 +1,71 @@
+// backend/internal/simulation/defenses_test.go
+package simulation
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestEvaluateDefenses(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	controls := []DefensiveControl{
+		{Type: ControlEDR, Coverage: 1, DetectionProbability: 1},
+		{Type: ControlFirewall, Coverage: 1, BlockProbability: 1},
+	}
+
+	// EDR erkennt Exploitation, die Firewall blockiert nur Aktionen über das Netzwerk
+	outcome := evaluateDefenses(controls, EventTypeExploitation, false, rng)
+	if !outcome.Detected || outcome.DetectedBy != ControlEDR || outcome.Blocked {
+		t.Fatalf("Unerwartetes Ergebnis für lokale Exploitation: %+v", outcome)
+	}
+	outcome = evaluateDefenses(controls, EventTypeLateralMovement, true, rng)
+	if !outcome.Blocked || outcome.BlockedBy != ControlFirewall {
+		t.Fatalf("Unerwartetes Ergebnis für Lateral Movement: %+v", outcome)
+	}
+
+	// Discovery wird von EDR nicht erfasst
+	outcome = evaluateDefenses(controls, EventTypeDiscovery, false, rng)
+	if outcome.Detected || outcome.Blocked {
+		t.Fatalf("Unerwartetes Ergebnis für Discovery: %+v", outcome)
+	}
+}
+
+func TestDetectionStatsFollowControls(t *testing.T) {
+	infrastructureWith := func(control map[string]interface{}) InfrastructureLoader {
+		return func(string) (map[string]interface{}, error) {
+			controls := []interface{}{control}
+			return map[string]interface{}{
+				"nodes": []map[string]interface{}{
+					{"id": "ws-1", "type": "workstation", "controls": controls},
+					{"id": "srv-1", "type": "server", "controls": controls},
+				},
+				"connections": []map[string]interface{}{
+					{"id": "c1", "source": "ws-1", "target": "srv-1", "protocol": "TCP", "ports": []string{"445"}},
+				},
+			}, nil
+		}
+	}
+
+	// Vollständige Protokollierung erkennt jede Aktion sofort
+	engine := NewEngine(WithInfrastructureLoader(infrastructureWith(map[string]interface{}{
+		"type": "logging", "coverage": 1.0, "detectionProbability": 1.0,
+	})))
+	sim := runInstant(t, engine, SimulationConfig{Name: "Überwacht", ScenarioID: "scenario-2"})
+	status, _ := engine.GetSimulationStatus(sim.ID)
+	if status.AttackSteps == 0 || status.DetectionRate != 1 || status.ThreatsDetected != status.AttackSteps {
+		t.Fatalf("Erwartet wurde vollständige Erkennung, erhalten: %+v", status)
+	}
+	if status.TimeToDetect != "00:00:00" {
+		t.Fatalf("Erwartete Time-to-Detect: 00:00:00, erhalten: %s", status.TimeToDetect)
+	}
+
+	// Ohne wirksame Maßnahmen bleibt der Angriff unbemerkt
+	engine = NewEngine(WithInfrastructureLoader(infrastructureWith(map[string]interface{}{
+		"type": "logging", "coverage": 0.0,
+	})))
+	sim = runInstant(t, engine, SimulationConfig{Name: "Blind", ScenarioID: "scenario-2"})
+	status, _ = engine.GetSimulationStatus(sim.ID)
+	if status.DetectedSteps != 0 || status.DetectionRate != 0 || status.TimeToDetect != "" {
+		t.Fatalf("Erwartet wurde keine Erkennung, erhalten: %+v", status)
+	}
+}
@@ -0,0 +1,145 @@
+// backend/internal/simulation/defensive_actions.go
+package simulation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidAction wird zurückgegeben, wenn eine Abwehraktion nicht angewendet werden kann
+var ErrInvalidAction = errors.New("ungültige Abwehraktion")
+
+// DefensiveActionType bezeichnet einen Eingriff des Blue Teams in eine laufende Simulation
+type DefensiveActionType string
+
+const (
+	// ActionIsolateNode trennt alle Verbindungen eines Knotens
+	ActionIsolateNode DefensiveActionType = "isolate_node"
+	// ActionEnableControl aktiviert eine Abwehrmaßnahme auf einem Knoten oder ersetzt sie
+	ActionEnableControl DefensiveActionType = "enable_control"
+	// ActionBlockConnection sperrt eine einzelne Verbindung
+	ActionBlockConnection DefensiveActionType = "block_connection"
+)
+
+// DefensiveAction ist ein Eingriff, der die Infrastruktur einer laufenden Simulation ändert
+type DefensiveAction struct {
+	Type         DefensiveActionType `json:"type"`
+	NodeID       string              `json:"nodeId,omitempty"`
+	ConnectionID string              `json:"connectionId,omitempty"`
+	Control      *DefensiveControl   `json:"control,omitempty"`
+}
+
+// InjectDefensiveAction wendet eine Abwehraktion sofort auf den Infrastrukturgraphen einer
+// laufenden, pausierten oder wartenden Simulation an. Die Aktion wird als Event vermerkt,
+// das zurückgegeben wird.
+func (e *Engine) InjectDefensiveAction(simulationID string, action DefensiveAction) (*SimulationEvent, error) {
+	e.ensureLoaded(simulationID)
+
+	e.mutex.Lock()
+	defer e.unlock()
+
+	simulation, exists := e.simulations[simulationID]
+	if !exists {
+		return nil, fmt.Errorf("Simulation mit ID %s nicht gefunden", simulationID)
+	}
+
+	var state *runState
+	switch simulation.Status {
+	case StatusRunning, StatusPaused:
+		if run := e.runFor(simulationID); run != nil {
+			state = run.state
+		}
+	case StatusQueued:
+		state = e.queuedRuns[simulationID]
+	}
+	if state == nil {
+		return nil, fmt.Errorf("%w: Simulation %s läuft nicht (Status: %s)", ErrInvalidAction, simulationID, simulation.Status)
+	}
+
+	description, err := applyDefensiveAction(state.attack.topology, action)
+	if err != nil {
+		return nil, err
+	}
+
+	event := SimulationEvent{
+		ID:               uuid.New().String(),
+		SimulationID:     simulationID,
+		Timestamp:        e.clock.Now(),
+		Type:             EventTypeDefense,
+		Description:      description,
+		ResourceID:       action.NodeID,
+		Severity:         SeverityInfo,
+		SimulatedSeconds: state.elapsed.Seconds(),
+		Details:          action,
+	}
+	if state.phase >= 0 {
+		event.Phase = state.plan.Steps[state.phase].Name
+	}
+	e.appendEventLocked(simulationID, event)
+	e.checkpointLocked(simulationID, state)
+
+	logging.Logger.Infof("Abwehraktion %s auf Simulation %s angewendet: %s", action.Type, simulationID, description)
+	return &event, nil
+}
+
+// applyDefensiveAction ändert den Graphen gemäß der Aktion und beschreibt die Änderung
+func applyDefensiveAction(graph *topology, action DefensiveAction) (string, error) {
+	switch action.Type {
+	case ActionIsolateNode:
+		node, exists := graph.nodes[action.NodeID]
+		if !exists {
+			return "", fmt.Errorf("%w: Knoten %q nicht gefunden", ErrInvalidAction, action.NodeID)
+		}
+		removed := graph.isolate(node.ID)
+		return fmt.Sprintf("Knoten %s isoliert (%d Verbindungen getrennt)", node.Name, removed), nil
+
+	case ActionBlockConnection:
+		if !graph.removeConnection(action.ConnectionID) {
+			return "", fmt.Errorf("%w: Verbindung %q nicht gefunden", ErrInvalidAction, action.ConnectionID)
+		}
+		return fmt.Sprintf("Verbindung %s gesperrt", action.ConnectionID), nil
+
+	case ActionEnableControl:
+		node, exists := graph.nodes[action.NodeID]
+		if !exists {
+			return "", fmt.Errorf("%w: Knoten %q nicht gefunden", ErrInvalidAction, action.NodeID)
+		}
+		if action.Control == nil {
+			return "", fmt.Errorf("%w: %s benötigt eine Maßnahme", ErrInvalidAction, ActionEnableControl)
+		}
+		control := *action.Control
+		if !knownControlType(control.Type) {
+			return "", fmt.Errorf("%w: unbekannte Maßnahme %q", ErrInvalidAction, control.Type)
+		}
+		for _, probability := range []float64{control.Coverage, control.DetectionProbability, control.BlockProbability} {
+			if probability < 0 || probability > 1 {
+				return "", fmt.Errorf("%w: Wahrscheinlichkeiten müssen zwischen 0 und 1 liegen", ErrInvalidAction)
+			}
+		}
+
+		// Eine vorhandene Maßnahme desselben Typs wird ersetzt
+		for i := range node.Controls {
+			if node.Controls[i].Type == control.Type {
+				node.Controls[i] = control
+				return fmt.Sprintf("Maßnahme %s auf %s angepasst", control.Type, node.Name), nil
+			}
+		}
+		node.Controls = append(node.Controls, control)
+		return fmt.Sprintf("Maßnahme %s auf %s aktiviert", control.Type, node.Name), nil
+
+	default:
+		return "", fmt.Errorf("%w: unbekannter Aktionstyp %q", ErrInvalidAction, action.Type)
+	}
+}
+
+// knownControlType gibt an, ob die Engine eine Maßnahme dieses Typs auswerten kann
+func knownControlType(controlType ControlType) bool {
+	switch controlType {
+	case ControlEDR, ControlIDS, ControlFirewall, ControlMFA, ControlLogging:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// backend/internal/simulation/defensive_actions_test.go
+package simulation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// testInfrastructure ist ein kleines Netz aus einer Workstation und zwei Servern in Reihe
+func testInfrastructure() map[string]interface{} {
+	return map[string]interface{}{
+		"nodes": []map[string]interface{}{
+			{"id": "ws-1", "name": "Workstation", "type": "workstation"},
+			{"id": "srv-1", "name": "App Server", "type": "server"},
+			{"id": "srv-2", "name": "DB Server", "type": "server"},
+		},
+		"connections": []map[string]interface{}{
+			{"id": "c1", "source": "ws-1", "target": "srv-1", "protocol": "TCP", "ports": []string{"445"}},
+			{"id": "c2", "source": "srv-1", "target": "srv-2", "protocol": "TCP", "ports": []string{"5432"}},
+		},
+	}
+}
+
+func TestApplyDefensiveAction(t *testing.T) {
+	graph, err := topologyFromMap(testInfrastructure())
+	if err != nil {
+		t.Fatalf("Fehler beim Aufbau des Graphen: %v", err)
+	}
+
+	if _, err := applyDefensiveAction(graph, DefensiveAction{Type: ActionBlockConnection, ConnectionID: "c2"}); err != nil {
+		t.Fatalf("Fehler beim Sperren der Verbindung: %v", err)
+	}
+	if len(graph.edges("srv-2")) != 0 || len(graph.edges("srv-1")) != 1 {
+		t.Fatalf("Verbindung c2 sollte entfernt sein: srv-1 %d, srv-2 %d Kanten", len(graph.edges("srv-1")), len(graph.edges("srv-2")))
+	}
+
+	if _, err := applyDefensiveAction(graph, DefensiveAction{Type: ActionIsolateNode, NodeID: "ws-1"}); err != nil {
+		t.Fatalf("Fehler beim Isolieren: %v", err)
+	}
+	if len(graph.edges("ws-1")) != 0 || len(graph.edges("srv-1")) != 0 || len(graph.connections) != 0 {
+		t.Fatal("ws-1 sollte keine Verbindungen mehr haben")
+	}
+
+	// Eine vorhandene Maßnahme desselben Typs wird ersetzt
+	edr := &DefensiveControl{Type: ControlEDR, Coverage: 1, DetectionProbability: 1, BlockProbability: 1}
+	if _, err := applyDefensiveAction(graph, DefensiveAction{Type: ActionEnableControl, NodeID: "srv-1", Control: edr}); err != nil {
+		t.Fatalf("Fehler beim Aktivieren der Maßnahme: %v", err)
+	}
+	var edrControls int
+	for _, control := range graph.nodes["srv-1"].Controls {
+		if control.Type == ControlEDR {
+			edrControls++
+			if control.BlockProbability != 1 {
+				t.Fatalf("EDR sollte ersetzt sein, erhalten: %+v", control)
+			}
+		}
+	}
+	if edrControls != 1 {
+		t.Fatalf("Erwartete EDR-Maßnahmen: 1, Erhalten: %d", edrControls)
+	}
+
+	invalid := []DefensiveAction{
+		{Type: ActionIsolateNode, NodeID: "unbekannt"},
+		{Type: ActionBlockConnection, ConnectionID: "c1"}, // bereits entfernt
+		{Type: ActionEnableControl, NodeID: "srv-1"},
+		{Type: ActionEnableControl, NodeID: "srv-1", Control: &DefensiveControl{Type: "antivirus"}},
+		{Type: ActionEnableControl, NodeID: "srv-1", Control: &DefensiveControl{Type: ControlIDS, Coverage: 2}},
+		{Type: "shutdown"},
+	}
+	for _, action := range invalid {
+		if _, err := applyDefensiveAction(graph, action); !errors.Is(err, ErrInvalidAction) {
+			t.Fatalf("Aktion %+v: Erwarteter Fehler %v, Erhalten: %v", action, ErrInvalidAction, err)
+		}
+	}
+}
+
+func TestIsolatedEntryPointStopsSpread(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock), WithWorkerPool(1, 1), WithInfrastructureLoader(func(string) (map[string]interface{}, error) {
+		return testInfrastructure(), nil
+	}))
+
+	// Die erste Simulation belegt den Worker, die zweite wartet
+	blocker, _ := engine.CreateSimulation(SimulationConfig{Name: "Blockiert"})
+	sim, err := engine.CreateSimulation(SimulationConfig{
+		Name:       "Isoliert",
+		ScenarioID: "scenario-2",
+		Parameters: map[string]interface{}{ParameterSpeed: SpeedInstant, ParameterSeed: 7.0},
+	})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	engine.StartSimulation(blocker.ID)
+	engine.StartSimulation(sim.ID)
+
+	// Der einzige Einstiegspunkt wird vom Netz getrennt, bevor der Angriff beginnt
+	event, err := engine.InjectDefensiveAction(sim.ID, DefensiveAction{Type: ActionIsolateNode, NodeID: "ws-1"})
+	if err != nil {
+		t.Fatalf("Fehler beim Anwenden der Abwehraktion: %v", err)
+	}
+	if event.Type != EventTypeDefense {
+		t.Fatalf("Erwarteter Eventtyp: %s, Erhalten: %s", EventTypeDefense, event.Type)
+	}
+
+	engine.StopSimulation(blocker.ID)
+	waitFor(t, "Abschluss der Simulation", func() bool {
+		status, _ := simulationStatus(engine, sim.ID)
+		return status == StatusCompleted
+	})
+
+	resources, _ := engine.GetAffectedResources(sim.ID)
+	for _, resource := range resources {
+		if resource.ID != "ws-1" {
+			t.Fatalf("Angriff hat sich trotz Isolation auf %s ausgebreitet", resource.ID)
+		}
+	}
+
+	// Nach dem Ende der Simulation werden keine Aktionen mehr angenommen
+	if _, err := engine.InjectDefensiveAction(sim.ID, DefensiveAction{Type: ActionIsolateNode, NodeID: "srv-1"}); !errors.Is(err, ErrInvalidAction) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhalten: %v", ErrInvalidAction, err)
+	}
+}
@@ -2,9 +2,9 @@
 package simulation
 
 import (
-	"context"
 	"fmt"
 	"math/rand"
+	"runtime/debug"
 	"sync"
 	"time"
 
@@ -19,26 +19,135 @@ type Engine struct {
 	affectedResources map[string][]AffectedResource
 	mutex          sync.RWMutex
 	stopChannels   map[string]chan struct{}
+	resumeChannels map[string]chan struct{}
+	clock          Clock
+	loadInfrastructure InfrastructureLoader
+	scheduler      *scheduler
+	queuedRuns     map[string]*runState // vorbereitete Läufe wartender Simulationen
+	runs           map[*activeRun]bool  // Worker, die einen Platz im Pool belegen
+	defaultTimeout time.Duration
+	watchdogActive bool
+	subscribers    map[string]map[*subscriber]bool
+	store          Store
+	workers        sync.WaitGroup // laufende Worker-Goroutinen
+	shuttingDown   bool           // gesetzt von Shutdown; danach startet kein Worker mehr
+	janitorInterval time.Duration // 0, wenn kein Janitor läuft
+	retention      RetentionPolicy
+	janitorStop    chan struct{}
+	scheduleMutex  sync.Mutex // serialisiert Änderungen und Ausführungen der Zeitpläne
+	writes         []func(Store) // unter e.mutex vorgemerkte Schreibvorgänge, siehe unlock
+	writeMutex     sync.Mutex    // hält die Schreibvorgänge in der Reihenfolge, in der sie vorgemerkt wurden
+}
+
+// runState hält den internen Zustand eines laufenden Simulations-Workers
+type runState struct {
+	rng     *rand.Rand
+	source  *countingSource // Quelle von rng; ihr Zählerstand wird im Checkpoint gesichert
+	plan    *scenarioPlan
+	attack  *attackState
+	elapsed time.Duration // simulierte Zeit seit dem Start
+	phase   int           // Index des aktuellen Schritts, -1 vor dem ersten Update
+	resumed bool          // aus einem Checkpoint wiederhergestellt
+
+	// Schritte mit mindestens einer erfolgreichen Aktion, für die Voraussetzungen späterer Schritte
+	succeeded map[string]bool
+	// Schritte, für die bereits vermerkt ist, dass ihr Selektor kein Ziel zulässt
+	untargeted map[string]bool
+}
+
+// EngineOption konfiguriert eine Engine bei der Erstellung
+type EngineOption func(*Engine)
+
+// WithClock setzt die Zeitquelle der Engine
+func WithClock(clock Clock) EngineOption {
+	return func(e *Engine) {
+		e.clock = clock
+	}
+}
+
+// WithInfrastructureLoader setzt die Quelle der Infrastrukturgraphen, auf denen simuliert wird
+func WithInfrastructureLoader(loader InfrastructureLoader) EngineOption {
+	return func(e *Engine) {
+		e.loadInfrastructure = loader
+	}
+}
+
+// WithWorkerPool begrenzt die Anzahl gleichzeitig laufender Simulationen auf workerCount.
+// Bis zu bufferSize weitere Simulationen warten auf einen freien Worker, darüber hinaus
+// wird der Start mit ErrQueueFull abgelehnt. workerCount <= 0 hebt die Begrenzung auf.
+func WithWorkerPool(workerCount, bufferSize int) EngineOption {
+	return func(e *Engine) {
+		e.scheduler = newScheduler(workerCount, bufferSize)
+	}
+}
+
+// WithDefaultTimeout legt fest, wie lange eine Simulation höchstens laufen darf, bevor
+// sie als fehlgeschlagen beendet wird. Der Parameter "timeoutSeconds" einer Simulation
+// hat Vorrang. 0 hebt die Begrenzung auf.
+func WithDefaultTimeout(timeout time.Duration) EngineOption {
+	return func(e *Engine) {
+		e.defaultTimeout = timeout
+	}
+}
+
+// WithStore setzt den Store, in den die Engine Simulationen, Events und Ressourcen schreibt
+func WithStore(store Store) EngineOption {
+	return func(e *Engine) {
+		e.store = store
+	}
 }
 
 // NewEngine erstellt eine neue Simulation-Engine
-func NewEngine() *Engine {
-	return &Engine{
+func NewEngine(opts ...EngineOption) *Engine {
+	engine := &Engine{
 		simulations:      make(map[string]*Simulation),
 		events:           make(map[string][]SimulationEvent),
 		affectedResources: make(map[string][]AffectedResource),
 		stopChannels:     make(map[string]chan struct{}),
+		resumeChannels:   make(map[string]chan struct{}),
+		clock:            RealClock(),
+		loadInfrastructure: defaultInfrastructureLoader,
+		scheduler:        newScheduler(0, 0),
+		queuedRuns:       make(map[string]*runState),
+		runs:             make(map[*activeRun]bool),
+		subscribers:      make(map[string]map[*subscriber]bool),
+		store:            NewMemoryStore(),
+	}
+
+	for _, opt := range opts {
+		opt(engine)
 	}
+	engine.startJanitor()
+
+	return engine
 }
 
 // CreateSimulation erstellt eine neue Simulation
 func (e *Engine) CreateSimulation(config SimulationConfig) (*Simulation, error) {
-	e.mutex.Lock()
-	defer e.mutex.Unlock()
+	return e.createSimulation(config, "")
+}
+
+// createSimulation erstellt eine neue Simulation; clonedFrom ist die Simulation, deren
+// Konfiguration kopiert wurde
+func (e *Engine) createSimulation(config SimulationConfig, clonedFrom string) (*Simulation, error) {
+	// Prüfe die Parameter, bevor die Simulation angelegt wird
+	if _, err := parseSpeed(config.Parameters); err != nil {
+		return nil, err
+	}
+	if _, err := parseTimeout(config.Parameters); err != nil {
+		return nil, err
+	}
+	seed, err := parseSeed(config.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.checkScenario(config.ScenarioID); err != nil {
+		return nil, err
+	}
 
 	// Generiere ID für die Simulation
 	id := uuid.New().String()
-	now := time.Now()
+	now := e.clock.Now()
 
 	// Erstelle die Simulation
 	simulation := &Simulation{
@@ -48,13 +157,23 @@ func (e *Engine) CreateSimulation(config SimulationConfig) (*Simulation, error)
 		Status:          StatusNotStarted,
 		InfrastructureID: config.InfrastructureID,
 		ScenarioID:      config.ScenarioID,
+		Parameters:      config.Parameters,
+		Seed:            seed,
 		Progress:        0,
 		ThreatsDetected: 0,
+		ClonedFrom:      clonedFrom,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
 
-	// Speichere die Simulation
+	// Speichere die Simulation, bevor sie für andere Aufrufer sichtbar wird
+	if err := e.store.SaveSimulation(simulation); err != nil {
+		return nil, fmt.Errorf("Simulation konnte nicht gespeichert werden: %v", err)
+	}
+
+	e.mutex.Lock()
+	defer e.unlock()
+
 	e.simulations[id] = simulation
 	e.events[id] = []SimulationEvent{}
 	e.affectedResources[id] = []AffectedResource{}
@@ -63,84 +182,279 @@ func (e *Engine) CreateSimulation(config SimulationConfig) (*Simulation, error)
 	return simulation, nil
 }
 
-// StartSimulation startet eine Simulation
+// StartSimulation startet eine Simulation. Ist kein Worker frei, wird sie in die
+// Warteschlange eingereiht; ist auch diese voll, wird ErrQueueFull zurückgegeben.
 func (e *Engine) StartSimulation(id string) (*Simulation, error) {
+	e.ensureLoaded(id)
+
 	e.mutex.Lock()
 	
 	simulation, exists := e.simulations[id]
 	if !exists {
-		e.mutex.Unlock()
+		e.unlock()
 		return nil, fmt.Errorf("Simulation mit ID %s nicht gefunden", id)
 	}
 
-	// Prüfe, ob die Simulation bereits läuft
-	if simulation.Status == StatusRunning {
-		e.mutex.Unlock()
+	// Prüfe, ob die Simulation bereits läuft oder auf einen Worker wartet
+	if simulation.Status == StatusRunning || simulation.Status == StatusPaused || simulation.Status == StatusQueued {
+		e.unlock()
+		return simulation, nil
+	}
+	if e.shuttingDown {
+		e.unlock()
+		return nil, ErrShuttingDown
+	}
+
+	// Der erste Lauf verwendet den beim Erstellen festgelegten Seed, jeder weitere einen
+	// neuen, sofern der Parameter "seed" keinen vorgibt
+	seed := simulation.Seed
+	if simulation.RunNumber > 0 {
+		seed, _ = parseSeed(simulation.Parameters)
+	}
+	state, err := e.prepareRun(simulation, seed)
+	if err != nil {
+		e.unlock()
+		return nil, err
+	}
+
+	// Erst wenn ein Worker oder ein Platz in der Warteschlange sicher ist, wird der neue
+	// Lauf angelegt; sonst blieben Events und Ergebnisse des vorigen Laufs nicht erhalten
+	if !e.scheduler.tryAcquire() {
+		// Alle Worker sind belegt: in die Warteschlange einreihen
+		position, err := e.scheduler.enqueue(id)
+		if err != nil {
+			e.unlock()
+			return nil, fmt.Errorf("%w (maximal %d wartende Simulationen)", err, e.scheduler.bufferSize)
+		}
+		e.beginRunLocked(simulation, seed)
+
+		now := e.clock.Now()
+		simulation.Status = StatusQueued
+		simulation.QueuePosition = position
+		simulation.UpdatedAt = now
+		e.queuedRuns[id] = state
+		e.checkpointLocked(id, state)
+		e.appendEventLocked(id, SimulationEvent{
+			ID:           uuid.New().String(),
+			SimulationID: id,
+			Timestamp:    now,
+			Type:         EventTypeSystem,
+			Description:  fmt.Sprintf("Simulation in Warteschlange eingereiht (Position %d)", position),
+			Severity:     SeverityInfo,
+		})
+		e.saveSimulationLocked(simulation)
+		e.unlock()
+
+		logging.Logger.Infof("Simulation '%s' (ID: %s) wartet auf einen Worker (Position %d)", simulation.Name, simulation.ID, position)
 		return simulation, nil
 	}
 
+	e.beginRunLocked(simulation, seed)
+	e.launchLocked(simulation, state)
+	e.unlock()
+
+	logging.Logger.Infof("Simulation '%s' (ID: %s) gestartet", simulation.Name, simulation.ID)
+	return simulation, nil
+}
+
+// prepareRun lädt Szenario und Infrastruktur einer Simulation und erstellt den
+// Zustand für einen Worker, dessen Zufallsentscheidungen aus seed folgen
+func (e *Engine) prepareRun(simulation *Simulation, seed int64) (*runState, error) {
+	// Lade die Schritte des Szenarios und das Netzwerk, auf dem angegriffen wird
+	plan, err := e.loadScenarioPlan(simulation.ScenarioID)
+	if err != nil {
+		return nil, err
+	}
+	infrastructure, err := e.loadInfrastructure(simulation.InfrastructureID)
+	if err != nil {
+		return nil, fmt.Errorf("Infrastruktur %s konnte nicht geladen werden: %v", simulation.InfrastructureID, err)
+	}
+	graph, err := topologyFromMap(infrastructure)
+	if err != nil {
+		return nil, fmt.Errorf("Infrastruktur %s ist ungültig: %v", simulation.InfrastructureID, err)
+	}
+
+	source := newCountingSource(seed)
+	rng := rand.New(source)
+	return &runState{
+		rng:    rng,
+		source: source,
+		plan:   plan,
+		attack: newAttackState(graph, plan.Steps[0].Targets, rng),
+		phase:  -1,
+
+		succeeded:  make(map[string]bool),
+		untargeted: make(map[string]bool),
+	}, nil
+}
+
+// launchLocked startet den Worker einer Simulation auf einem bereits belegten
+// Worker-Platz. Der Aufrufer muss e.mutex halten.
+func (e *Engine) launchLocked(simulation *Simulation, state *runState) {
+	id := simulation.ID
+
 	// Aktualisiere den Status
-	now := time.Now()
+	now := e.clock.Now()
+	description := "Simulation gestartet"
+	if state.resumed {
+		// Ein aus einem Checkpoint fortgesetzter Lauf behält seine Startzeit; die Zeit,
+		// in der der Server nicht lief, zählt wie eine Pause
+		if simulation.PausedAt == nil {
+			simulation.PausedDuration += now.Sub(simulation.UpdatedAt)
+		}
+		description = "Simulation nach Neustart des Servers fortgesetzt"
+	} else {
+		simulation.StartTime = &now
+	}
 	simulation.Status = StatusRunning
-	simulation.StartTime = &now
+	simulation.QueuePosition = 0
 	simulation.UpdatedAt = now
 
 	// Erstelle Stopp-Kanal
 	stopChan := make(chan struct{})
 	e.stopChannels[id] = stopChan
-	
-	e.mutex.Unlock()
+
+	// Eine pausiert unterbrochene Simulation bleibt pausiert, bis sie fortgesetzt wird
+	if simulation.PausedAt != nil {
+		simulation.Status = StatusPaused
+		e.resumeChannels[id] = make(chan struct{})
+	}
 
 	// Erstelle initiales Event
-	e.AddEvent(id, SimulationEvent{
+	e.appendEventLocked(id, SimulationEvent{
 		ID:           uuid.New().String(),
 		SimulationID: id,
 		Timestamp:    now,
 		Type:         EventTypeSystem,
-		Description:  "Simulation gestartet",
+		Description:  description,
 		Severity:     SeverityInfo,
 	})
+	e.saveSimulationLocked(simulation)
+	e.saveRunLocked(simulation)
+	e.checkpointLocked(id, state)
+
+	// Die Laufzeit wurde bereits beim Erstellen geprüft
+	speed, _ := parseSpeed(simulation.Parameters)
+	timeout, _ := parseTimeout(simulation.Parameters)
+	if timeout == 0 {
+		timeout = e.defaultTimeout
+	}
+	run := &activeRun{
+		simulationID:  id,
+		stopChan:      stopChan,
+		state:         state,
+		interval:      tickInterval(speed),
+		timeout:       timeout,
+		lastHeartbeat: now,
+	}
+	e.runs[run] = true
+	e.workers.Add(1)
+	e.startWatchdogLocked()
 
 	// Starte die Simulation in einem eigenen Goroutine
-	go e.runSimulation(id, stopChan)
+	go e.runSimulation(run, state)
+}
 
-	logging.Logger.Infof("Simulation '%s' (ID: %s) gestartet", simulation.Name, simulation.ID)
-	return simulation, nil
+// workerFinished gibt den Platz eines beendeten Workers frei, sofern das nicht
+// bereits der Watchdog getan hat
+func (e *Engine) workerFinished(run *activeRun) {
+	e.mutex.Lock()
+	defer e.unlock()
+
+	e.releaseRunLocked(run)
+}
+
+// releaseRunLocked gibt den Worker-Platz eines Laufs frei und startet die am längsten
+// wartende Simulation. Der Aufrufer muss e.mutex halten.
+func (e *Engine) releaseRunLocked(run *activeRun) {
+	if !e.runs[run] {
+		return
+	}
+	delete(e.runs, run)
+	e.scheduler.release()
+
+	// Beim Herunterfahren bleiben wartende Simulationen für den Neustart in der Warteschlange
+	for !e.shuttingDown {
+		id, ok := e.scheduler.dequeue()
+		if !ok {
+			break
+		}
+		simulation, state := e.simulations[id], e.queuedRuns[id]
+		delete(e.queuedRuns, id)
+		if simulation == nil || state == nil || simulation.Status != StatusQueued {
+			continue
+		}
+
+		e.scheduler.tryAcquire()
+		e.launchLocked(simulation, state)
+		logging.Logger.Infof("Simulation '%s' (ID: %s) aus der Warteschlange gestartet", simulation.Name, simulation.ID)
+		break
+	}
+
+	e.updateQueuePositionsLocked()
+}
+
+// updateQueuePositionsLocked aktualisiert die Positionen aller wartenden Simulationen.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) updateQueuePositionsLocked() {
+	for id := range e.queuedRuns {
+		simulation, exists := e.simulations[id]
+		if !exists {
+			continue
+		}
+		if position := e.scheduler.position(id); position != simulation.QueuePosition {
+			simulation.QueuePosition = position
+			e.saveSimulationLocked(simulation)
+		}
+	}
 }
 
 // StopSimulation stoppt eine laufende Simulation
 func (e *Engine) StopSimulation(id string) (*Simulation, error) {
+	e.ensureLoaded(id)
+
 	e.mutex.Lock()
 	
 	simulation, exists := e.simulations[id]
 	if !exists {
-		e.mutex.Unlock()
+		e.unlock()
 		return nil, fmt.Errorf("Simulation mit ID %s nicht gefunden", id)
 	}
 
-	// Prüfe, ob die Simulation läuft
-	if simulation.Status != StatusRunning && simulation.Status != StatusPaused {
-		e.mutex.Unlock()
+	// Prüfe, ob die Simulation läuft oder wartet
+	if simulation.Status != StatusRunning && simulation.Status != StatusPaused && simulation.Status != StatusQueued {
+		e.unlock()
 		return simulation, nil
 	}
 
+	// Wartende Simulationen verlassen die Warteschlange, ohne einen Worker belegt zu haben
+	if simulation.Status == StatusQueued {
+		e.scheduler.remove(id)
+		delete(e.queuedRuns, id)
+		simulation.QueuePosition = 0
+		e.updateQueuePositionsLocked()
+	}
+
 	// Sende Stopp-Signal
 	stopChan, exists := e.stopChannels[id]
 	if exists {
 		close(stopChan)
 		delete(e.stopChannels, id)
 	}
+	delete(e.resumeChannels, id)
 
 	// Aktualisiere den Status
-	now := time.Now()
+	now := e.clock.Now()
+	if simulation.PausedAt != nil {
+		simulation.PausedDuration += now.Sub(*simulation.PausedAt)
+		simulation.PausedAt = nil
+	}
 	simulation.Status = StatusStopped
 	simulation.EndTime = &now
 	simulation.UpdatedAt = now
 	
-	e.mutex.Unlock()
-
 	// Erstelle Event
-	e.AddEvent(id, SimulationEvent{
+	e.appendEventLocked(id, SimulationEvent{
 		ID:           uuid.New().String(),
 		SimulationID: id,
 		Timestamp:    now,
@@ -148,6 +462,10 @@ func (e *Engine) StopSimulation(id string) (*Simulation, error) {
 		Description:  "Simulation manuell gestoppt",
 		Severity:     SeverityInfo,
 	})
+	e.deleteCheckpointLocked(id)
+	e.storeResultsLocked(simulation)
+	e.saveSimulationLocked(simulation)
+	e.unlock()
 
 	logging.Logger.Infof("Simulation '%s' (ID: %s) gestoppt", simulation.Name, simulation.ID)
 	return simulation, nil
@@ -155,58 +473,127 @@ func (e *Engine) StopSimulation(id string) (*Simulation, error) {
 
 // PauseSimulation pausiert eine laufende Simulation
 func (e *Engine) PauseSimulation(id string) (*Simulation, error) {
+	e.ensureLoaded(id)
+
 	e.mutex.Lock()
-	defer e.mutex.Unlock()
-	
+
 	simulation, exists := e.simulations[id]
 	if !exists {
+		e.unlock()
 		return nil, fmt.Errorf("Simulation mit ID %s nicht gefunden", id)
 	}
 
 	// Prüfe, ob die Simulation läuft
 	if simulation.Status != StatusRunning {
+		e.unlock()
 		return simulation, nil
 	}
 
 	// Aktualisiere den Status
-	now := time.Now()
+	now := e.clock.Now()
 	simulation.Status = StatusPaused
+	simulation.PausedAt = &now
 	simulation.UpdatedAt = now
 
-	// TODO: Implementiere Pausier-Mechanismus für den Simulations-Worker
+	// Der Worker blockiert beim nächsten Tick, bis dieser Kanal geschlossen wird
+	e.resumeChannels[id] = make(chan struct{})
+
+	// Erstelle Event
+	e.appendEventLocked(id, SimulationEvent{
+		ID:           uuid.New().String(),
+		SimulationID: id,
+		Timestamp:    now,
+		Type:         EventTypeSystem,
+		Description:  "Simulation pausiert",
+		Severity:     SeverityInfo,
+	})
+	e.saveSimulationLocked(simulation)
+	if run := e.runFor(id); run != nil {
+		e.checkpointLocked(id, run.state)
+	}
+	e.unlock()
 
 	logging.Logger.Infof("Simulation '%s' (ID: %s) pausiert", simulation.Name, simulation.ID)
 	return simulation, nil
 }
 
-// GetSimulation gibt eine Simulation zurück
-func (e *Engine) GetSimulation(id string) (*Simulation, error) {
-	e.mutex.RLock()
-	defer e.mutex.RUnlock()
-	
+// ResumeSimulation setzt eine pausierte Simulation an der Stelle fort, an der sie angehalten wurde
+func (e *Engine) ResumeSimulation(id string) (*Simulation, error) {
+	e.ensureLoaded(id)
+
+	e.mutex.Lock()
+
 	simulation, exists := e.simulations[id]
 	if !exists {
+		e.unlock()
 		return nil, fmt.Errorf("Simulation mit ID %s nicht gefunden", id)
 	}
-	
+
+	// Prüfe, ob die Simulation pausiert ist
+	if simulation.Status != StatusPaused {
+		e.unlock()
+		return simulation, nil
+	}
+	if e.shuttingDown {
+		e.unlock()
+		return nil, ErrShuttingDown
+	}
+
+	// Aktualisiere den Status und verbuche die Pausenzeit
+	now := e.clock.Now()
+	if simulation.PausedAt != nil {
+		simulation.PausedDuration += now.Sub(*simulation.PausedAt)
+		simulation.PausedAt = nil
+	}
+	simulation.Status = StatusRunning
+	simulation.UpdatedAt = now
+
+	// Die Pause zählt nicht als ausgebliebenes Update
+	if run := e.runFor(id); run != nil {
+		run.lastHeartbeat = now
+	}
+
+	// Wecke den wartenden Worker auf
+	if resumeChan, exists := e.resumeChannels[id]; exists {
+		close(resumeChan)
+		delete(e.resumeChannels, id)
+	}
+
+	// Erstelle Event
+	e.appendEventLocked(id, SimulationEvent{
+		ID:           uuid.New().String(),
+		SimulationID: id,
+		Timestamp:    now,
+		Type:         EventTypeSystem,
+		Description:  "Simulation fortgesetzt",
+		Severity:     SeverityInfo,
+	})
+	e.saveSimulationLocked(simulation)
+	e.unlock()
+
+	logging.Logger.Infof("Simulation '%s' (ID: %s) fortgesetzt", simulation.Name, simulation.ID)
 	return simulation, nil
 }
 
-// GetSimulations gibt alle Simulationen zurück
-func (e *Engine) GetSimulations() []*Simulation {
+// GetSimulation gibt eine Simulation zurück
+func (e *Engine) GetSimulation(id string) (*Simulation, error) {
+	e.ensureLoaded(id)
+
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
 	
-	simulations := make([]*Simulation, 0, len(e.simulations))
-	for _, simulation := range e.simulations {
-		simulations = append(simulations, simulation)
+	simulation, exists := e.simulations[id]
+	if !exists {
+		return nil, fmt.Errorf("Simulation mit ID %s nicht gefunden", id)
 	}
 	
-	return simulations
+	return simulation, nil
 }
 
 // GetSimulationStatus gibt den Status einer Simulation zurück
 func (e *Engine) GetSimulationStatus(id string) (*SimulationStatus, error) {
+	e.ensureLoaded(id)
+
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
 	
@@ -215,23 +602,13 @@ func (e *Engine) GetSimulationStatus(id string) (*SimulationStatus, error) {
 		return nil, fmt.Errorf("Simulation mit ID %s nicht gefunden", id)
 	}
 	
-	var runtime string
-	if simulation.StartTime != nil {
-		var endTime time.Time
-		if simulation.EndTime != nil {
-			endTime = *simulation.EndTime
-		} else {
-			endTime = time.Now()
-		}
-		
-		duration := endTime.Sub(*simulation.StartTime)
-		hours := int(duration.Hours())
-		minutes := int(duration.Minutes()) % 60
-		seconds := int(duration.Seconds()) % 60
-		runtime = fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
-	} else {
-		runtime = "00:00:00"
-	}
+	return e.statusLocked(simulation), nil
+}
+
+// statusLocked berechnet den Status einer Simulation; der Aufrufer muss e.mutex halten
+func (e *Engine) statusLocked(simulation *Simulation) *SimulationStatus {
+	id := simulation.ID
+	runtime := formatRuntime(activeRuntime(simulation, e.clock.Now()))
 	
 	// Zähle kompromittierte Ressourcen
 	var compromisedResources int
@@ -241,37 +618,124 @@ func (e *Engine) GetSimulationStatus(id string) (*SimulationStatus, error) {
 		}
 	}
 	
-	return &SimulationStatus{
+	status := &SimulationStatus{
 		ID:                 simulation.ID,
 		Status:             simulation.Status,
+		QueuePosition:      simulation.QueuePosition,
+		Error:              simulation.Error,
 		Runtime:            runtime,
 		ThreatsDetected:    simulation.ThreatsDetected,
 		CompromisedResources: compromisedResources,
 		Progress:           simulation.Progress,
-	}, nil
+	}
+	applyDetectionStats(status, e.events[id])
+	
+	return status
+}
+
+// storeResultsLocked legt den Abschlussbericht einer beendeten Simulation in Results ab
+// und schließt damit ihren aktuellen Lauf ab. Der Aufrufer muss e.mutex halten.
+func (e *Engine) storeResultsLocked(simulation *Simulation) {
+	simulation.Results = buildResults(simulation, e.events[simulation.ID], e.affectedResources[simulation.ID], e.clock.Now())
+	e.saveRunLocked(simulation)
+}
+
+// applyDetectionStats ergänzt den Status um Erkennungsrate und Time-to-Detect
+func applyDetectionStats(status *SimulationStatus, events []SimulationEvent) {
+	firstAttack, firstDetection := -1.0, -1.0
+	for _, event := range events {
+		if event.Outcome == "" {
+			continue
+		}
+		status.AttackSteps++
+		if firstAttack < 0 {
+			firstAttack = event.SimulatedSeconds
+		}
+		if event.Blocked {
+			status.BlockedSteps++
+		}
+		if event.Detected {
+			status.DetectedSteps++
+			if firstDetection < 0 {
+				firstDetection = event.SimulatedSeconds
+			}
+		}
+	}
+
+	if status.AttackSteps > 0 {
+		status.DetectionRate = float64(status.DetectedSteps) / float64(status.AttackSteps)
+	}
+	if firstDetection >= 0 {
+		status.TimeToDetect = formatRuntime(time.Duration((firstDetection - firstAttack) * float64(time.Second)))
+	}
+}
+
+// activeRuntime berechnet die Laufzeit einer Simulation ohne die pausierten Zeiträume
+func activeRuntime(simulation *Simulation, now time.Time) time.Duration {
+	if simulation.StartTime == nil {
+		return 0
+	}
+
+	endTime := now
+	if simulation.EndTime != nil {
+		endTime = *simulation.EndTime
+	} else if simulation.PausedAt != nil {
+		// Während einer Pause bleibt die Laufzeit stehen
+		endTime = *simulation.PausedAt
+	}
+
+	duration := endTime.Sub(*simulation.StartTime) - simulation.PausedDuration
+	if duration < 0 {
+		return 0
+	}
+	return duration
+}
+
+// formatRuntime formatiert eine Laufzeit als HH:MM:SS
+func formatRuntime(duration time.Duration) string {
+	hours := int(duration.Hours())
+	minutes := int(duration.Minutes()) % 60
+	seconds := int(duration.Seconds()) % 60
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
 }
 
 // AddEvent fügt ein Event zu einer Simulation hinzu
 func (e *Engine) AddEvent(simulationID string, event SimulationEvent) {
 	e.mutex.Lock()
-	defer e.mutex.Unlock()
-	
+	defer e.unlock()
+
+	e.appendEventLocked(simulationID, event)
+}
+
+// appendEventLocked fügt ein Event hinzu; der Aufrufer muss e.mutex halten
+func (e *Engine) appendEventLocked(simulationID string, event SimulationEvent) {
 	if _, exists := e.events[simulationID]; !exists {
 		e.events[simulationID] = []SimulationEvent{}
 	}
 	
+	event.RunID = e.runIDLocked(simulationID)
+	event.Sequence = int64(len(e.events[simulationID])) + 1
 	e.events[simulationID] = append(e.events[simulationID], event)
+	e.queueWriteLocked(func(store Store) {
+		if err := store.SaveEvent(event); err != nil {
+			logging.Logger.Errorf("Event %s der Simulation %s konnte nicht gespeichert werden: %v", event.ID, simulationID, err)
+		}
+	})
 	
-	// Aktualisiere die Threatcounter, wenn es sich um ein Exploitation-Event handelt
-	if event.Type == EventTypeExploitation {
+	// Aktualisiere den Threatcounter, wenn die Abwehr eine Aktion erkannt hat
+	if event.Detected {
 		if simulation, exists := e.simulations[simulationID]; exists {
 			simulation.ThreatsDetected++
 		}
 	}
+
+	e.publishLocked(SimulationUpdate{Type: UpdateEvent, SimulationID: simulationID, Event: &event})
 }
 
 // GetEvents gibt die Events einer Simulation zurück
 func (e *Engine) GetEvents(simulationID string) ([]SimulationEvent, error) {
+	e.ensureLoaded(simulationID)
+
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
 	
@@ -290,17 +754,21 @@ func (e *Engine) GetEvents(simulationID string) ([]SimulationEvent, error) {
 // AddAffectedResource fügt eine betroffene Ressource zu einer Simulation hinzu
 func (e *Engine) AddAffectedResource(simulationID string, resource AffectedResource) {
 	e.mutex.Lock()
-	defer e.mutex.Unlock()
+	defer e.unlock()
 	
 	if _, exists := e.affectedResources[simulationID]; !exists {
 		e.affectedResources[simulationID] = []AffectedResource{}
 	}
 	
+	resource.RunID = e.runIDLocked(simulationID)
 	e.affectedResources[simulationID] = append(e.affectedResources[simulationID], resource)
+	e.saveResourceLocked(resource)
 }
 
 // GetAffectedResources gibt die betroffenen Ressourcen einer Simulation zurück
 func (e *Engine) GetAffectedResources(simulationID string) ([]AffectedResource, error) {
+	e.ensureLoaded(simulationID)
+
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
 	
@@ -318,8 +786,10 @@ func (e *Engine) GetAffectedResources(simulationID string) ([]AffectedResource,
 
 // UpdateResourceStatus aktualisiert den Status einer Ressource
 func (e *Engine) UpdateResourceStatus(simulationID string, resourceID string, status ResourceStatus) error {
+	e.ensureLoaded(simulationID)
+
 	e.mutex.Lock()
-	defer e.mutex.Unlock()
+	defer e.unlock()
 	
 	resources, exists := e.affectedResources[simulationID]
 	if !exists {
@@ -329,6 +799,7 @@ func (e *Engine) UpdateResourceStatus(simulationID string, resourceID string, st
 	for i, resource := range resources {
 		if resource.ID == resourceID {
 			resources[i].Status = status
+			e.saveResourceLocked(resources[i])
 			return nil
 		}
 	}
@@ -336,248 +807,313 @@ func (e *Engine) UpdateResourceStatus(simulationID string, resourceID string, st
 	return fmt.Errorf("Ressource mit ID %s nicht gefunden", resourceID)
 }
 
-// Füge diese Private-Methode am Ende der Datei hinzu
-func (e *Engine) runSimulation(id string, stopChan <-chan struct{}) {
-	// Initialisiere den simulation context
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+// tickResult beschreibt, wie ein Worker nach einem Update fortfährt
+type tickResult int
 
-	// Initialisiere die Phase und den Fortschritt
-	var progress float64 = 0
-	phase := 1
-	maxPhases := 5 // Anzahl der Phasen in einer typischen Penetration
-	
-	// Hauptsimulationsschleife
-	ticker := time.NewTicker(10 * time.Second) // Periodische Updates
-	defer ticker.Stop()
+const (
+	tickContinue tickResult = iota
+	tickPaused
+	tickFinished
+)
+
+// runSimulation ist der Worker einer laufenden Simulation. Er führt die Schritte des
+// Szenarios nacheinander aus; jedes Update entspricht baseTickInterval simulierter Zeit,
+// und der Zeitraffer-Faktor bestimmt, wie schnell diese Updates in Echtzeit aufeinander
+// folgen. Alle Zufallsentscheidungen stammen aus state.rng, sodass ein Lauf mit
+// demselben Seed reproduzierbar ist.
+func (e *Engine) runSimulation(run *activeRun, state *runState) {
+	id := run.simulationID
+
+	// Ein Absturz beendet die Simulation als fehlgeschlagen, statt sie als laufend
+	// stehen zu lassen. Der Worker-Platz wird bei jedem Ende des Workers freigegeben.
+	defer e.workers.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			logging.Logger.Errorf("Worker der Simulation %s abgestürzt: %v\n%s", id, r, debug.Stack())
+			e.failRun(run, fmt.Sprintf("Interner Fehler: %v", r))
+		}
+		e.workerFinished(run)
+	}()
+
+	// Hauptsimulationsschleife; im Modus "instant" ohne Ticker
+	var ticker Ticker
+	if run.interval > 0 {
+		ticker = e.clock.NewTicker(run.interval) // Periodische Updates
+		defer ticker.Stop()
+	}
 	
-	logging.Logger.Infof("Simulationsschleife für ID %s gestartet", id)
+	logging.Logger.Infof("Simulationsschleife für ID %s gestartet (Szenario: %s, Intervall: %v)", id, state.plan.Name, run.interval)
 
 	for {
-		select {
-		case <-stopChan:
-			logging.Logger.Infof("Simulation %s gestoppt", id)
-			cancel()
-			return
-		case <-ctx.Done():
-			logging.Logger.Infof("Simulation %s durch Kontext abgebrochen", id)
-			return
-		case <-ticker.C:
-			// Periodisches Update
-			e.mutex.Lock()
-			simulation, exists := e.simulations[id]
-			
-			if !exists || simulation.Status != StatusRunning {
-				e.mutex.Unlock()
+		// Auf das nächste Update warten
+		if ticker != nil {
+			select {
+			case <-run.stopChan:
+				logging.Logger.Infof("Simulation %s gestoppt", id)
 				return
+			case <-ticker.C():
 			}
-			
-			// Fortschritt aktualisieren (simuliere Fortschritt)
-			progress += 0.02 // Erhöhe den Fortschritt um 2%
-			if progress >= float64(phase)/float64(maxPhases) && phase < maxPhases {
-				// Fortschritt zur nächsten Phase
-				phase++
-				
-				// Ereignis für den Phasenübergang erzeugen
-				phaseName := ""
-				eventType := EventTypeSystem
-				
-				switch phase {
-				case 2:
-					phaseName = "Reconnaissance"
-					eventType = EventTypeDiscovery
-				case 3:
-					phaseName = "Initial Access"
-					eventType = EventTypeExploitation
-				case 4:
-					phaseName = "Privilege Escalation"
-					eventType = EventTypeEscalation
-				case 5:
-					phaseName = "Lateral Movement"
-					eventType = EventTypeLateralMovement
-				}
-				
-				if phaseName != "" {
-					now := time.Now()
-					
-					// Erstelle ein Event für den Übergang
-					e.mutex.Unlock() // Unlock vor dem Aufrufen von AddEvent, die auch den Mutex verwendet
-					e.AddEvent(id, SimulationEvent{
-						ID:           uuid.New().String(),
-						SimulationID: id,
-						Timestamp:    now,
-						Type:         eventType,
-						Description:  fmt.Sprintf("Phase gestartet: %s", phaseName),
-						Severity:     SeverityInfo,
-					})
-					e.mutex.Lock() // Lock wieder erhalten
-				}
+		} else {
+			select {
+			case <-run.stopChan:
+				logging.Logger.Infof("Simulation %s gestoppt", id)
+				return
+			default:
 			}
-			
-			if progress >= 1.0 {
-				// Simulation abgeschlossen
-				now := time.Now()
-				simulation.Status = StatusCompleted
-				simulation.EndTime = &now
-				simulation.Progress = 1.0
-				simulation.UpdatedAt = now
-				delete(e.stopChannels, id)
-				e.mutex.Unlock()
-				
-				// Erstelle ein Abschlussereignis
-				e.AddEvent(id, SimulationEvent{
-					ID:           uuid.New().String(),
-					SimulationID: id,
-					Timestamp:    now,
-					Type:         EventTypeSystem,
-					Description:  "Simulation erfolgreich abgeschlossen",
-					Severity:     SeverityInfo,
-				})
-				
-				logging.Logger.Infof("Simulation %s abgeschlossen", id)
+		}
+
+		// Periodisches Update
+		result, resumeChan := e.tick(run, state)
+		switch result {
+		case tickFinished:
+			return
+		case tickPaused:
+			// Pausiert: Fortschritt und Phase bleiben eingefroren, bis fortgesetzt oder gestoppt wird
+			select {
+			case <-run.stopChan:
+				logging.Logger.Infof("Simulation %s gestoppt", id)
 				return
+			case <-resumeChan:
 			}
-			
-			// Fortschritt aktualisieren
-			simulation.Progress = progress
-			simulation.UpdatedAt = time.Now()
-			e.mutex.Unlock()
-			
-			// Zufälliges Ereignis generieren (für eine realistischere Simulation)
-			if rand.Float64() < 0.3 { // 30% Chance für ein Ereignis
-				e.generateRandomEvent(id, phase)
+
+			// Das nächste Update erfolgt ein volles Intervall nach dem Fortsetzen
+			if ticker != nil {
+				ticker.Reset(run.interval)
 			}
+			continue
 		}
 	}
 }
 
-// generateRandomEvent generiert ein zufälliges Ereignis für eine Simulation
-func (e *Engine) generateRandomEvent(simulationID string, phase int) {
-	// Ressourcen-IDs für die Simulation abrufen
-	resources, err := e.GetAffectedResources(simulationID)
-	if err != nil || len(resources) == 0 {
-		// Wenn keine Ressourcen vorhanden sind, erstelle eine neue
-		resourceID := fmt.Sprintf("resource-%s", uuid.New().String()[0:8])
-		
-		resourceTypes := []string{"server", "workstation", "router", "database"}
-		resourceType := resourceTypes[rand.Intn(len(resourceTypes))]
-		
-		resourceName := fmt.Sprintf("%s-%d", resourceType, rand.Intn(100))
-		
-		resource := AffectedResource{
-			ID:           resourceID,
-			SimulationID: simulationID,
-			Name:         resourceName,
-			Type:         resourceType,
-			Status:       ResourceStatusVulnerable,
-			ThreatLevel:  0.2,
+// tick schreibt die simulierte Zeit eines Laufs um ein Update fort, wechselt bei Bedarf
+// in den nächsten Schritt des Szenarios und schließt die Simulation am Ende ab.
+// Für pausierte Simulationen wird der Kanal zurückgegeben, der das Fortsetzen signalisiert.
+func (e *Engine) tick(run *activeRun, state *runState) (tickResult, <-chan struct{}) {
+	e.mutex.Lock()
+	defer e.unlock()
+
+	id := run.simulationID
+	simulation, exists := e.simulations[id]
+	if exists && simulation.Status == StatusPaused {
+		return tickPaused, e.resumeChannels[id]
+	}
+	if !exists || simulation.Status != StatusRunning || !e.runs[run] || e.stopChannels[id] != run.stopChan {
+		return tickFinished, nil
+	}
+
+	now := e.clock.Now()
+	run.lastHeartbeat = now
+
+	// Simulierte Zeit und Fortschritt aktualisieren
+	plan := state.plan
+	total := plan.totalDuration()
+	state.elapsed += baseTickInterval
+	if state.elapsed > total {
+		state.elapsed = total
+	}
+	progress := float64(state.elapsed) / float64(total)
+
+	if current := plan.stepAt(state.elapsed); current != state.phase {
+		// Übergang zum nächsten Schritt des Szenarios
+		state.phase = current
+		step := plan.Steps[state.phase]
+		description := fmt.Sprintf("Phase gestartet: %s", step.Name)
+		if missing := step.missingPrecondition(state.succeeded); missing != "" {
+			description = fmt.Sprintf("Phase übersprungen: %s (Voraussetzung %s nicht erfüllt)", step.Name, missing)
 		}
-		
-		e.AddAffectedResource(simulationID, resource)
-		resources = append(resources, resource)
+		e.appendEventLocked(id, SimulationEvent{
+			ID:           uuid.New().String(),
+			SimulationID: id,
+			Timestamp:    now,
+			Type:         step.EventTypes[0],
+			Description:  description,
+			Severity:     SeverityInfo,
+			Phase:        step.Name,
+			SimulatedSeconds: state.elapsed.Seconds(),
+		})
 	}
+
+	if progress >= 1.0 {
+		// Simulation abgeschlossen
+		simulation.Status = StatusCompleted
+		simulation.EndTime = &now
+		simulation.Progress = 1.0
+		simulation.UpdatedAt = now
+		delete(e.stopChannels, id)
+
+		// Erstelle ein Abschlussereignis
+		e.appendEventLocked(id, SimulationEvent{
+			ID:           uuid.New().String(),
+			SimulationID: id,
+			Timestamp:    now,
+			Type:         EventTypeSystem,
+			Description:  "Simulation erfolgreich abgeschlossen",
+			Severity:     SeverityInfo,
+		})
+		e.deleteCheckpointLocked(id)
+		e.storeResultsLocked(simulation)
+		e.saveSimulationLocked(simulation)
+
+		logging.Logger.Infof("Simulation %s abgeschlossen", id)
+		return tickFinished, nil
+	}
+
+	// Fortschritt aktualisieren
+	simulation.Progress = progress
+	simulation.UpdatedAt = now
+	e.saveSimulationLocked(simulation)
+
+	// Zufälliges Ereignis generieren (für eine realistischere Simulation). In einem
+	// übersprungenen Schritt handelt der Angreifer nicht.
+	skipped := plan.Steps[state.phase].missingPrecondition(state.succeeded) != ""
+	if state.rng.Float64() < 0.3 && !skipped { // 30% Chance für ein Ereignis
+		e.generateRandomEventLocked(id, state)
+	}
+
+	// Nach jedem Update sichern, damit Checkpoint, Events und Ressourcen zusammenpassen
+	e.checkpointLocked(id, state)
+	return tickContinue, nil
+}
+
+// generateRandomEventLocked generiert ein zufälliges Ereignis für den aktuellen Schritt einer Simulation.
+// Ziel und Weg der Aktion ergeben sich aus der bisherigen Ausbreitung im Netzwerk.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) generateRandomEventLocked(simulationID string, state *runState) {
+	rng := state.rng
+	step := &state.plan.Steps[state.phase]
 	
-	// Wähle eine zufällige Ressource
-	resourceIndex := rand.Intn(len(resources))
-	resource := resources[resourceIndex]
-	
-	// Basierend auf der Phase, generiere ein Ereignis
-	eventType := EventTypeSystem
-	description := ""
-	severity := SeverityInfo
+	// Eventtyp, Schweregrad und Aktion aus der Definition des Schritts wählen
+	eventType := step.EventTypes[rng.Intn(len(step.EventTypes))]
+	severity := step.Severities[rng.Intn(len(step.Severities))]
+	description := fmt.Sprintf("Aktion im Schritt %s", step.Name)
+	if actions := step.actionsFor(eventType); len(actions) > 0 {
+		description = actions[rng.Intn(len(actions))]
+	}
 	
-	switch phase {
-	case 1, 2:
-		// Reconnaissance Phase
-		eventType = EventTypeDiscovery
-		descriptions := []string{
-			"Port-Scan durchgeführt",
-			"DNS-Informationen abgerufen",
-			"Webserver-Header analysiert",
-			"Offene Dienste identifiziert",
-			"Betriebssystem-Fingerprinting durchgeführt",
-		}
-		description = descriptions[rand.Intn(len(descriptions))]
-		severities := []Severity{SeverityInfo, SeverityLow}
-		severity = severities[rand.Intn(len(severities))]
-		
-	case 3:
-		// Initial Access Phase
-		eventType = EventTypeExploitation
-		descriptions := []string{
-			"Versuch einer SQL-Injection",
-			"Ausnutzung einer bekannten Schwachstelle",
-			"Brute-Force-Angriff auf Login-Formular",
-			"Phishing-E-Mail gesendet",
-			"Fehlkonfiguration ausgenutzt",
-		}
-		description = descriptions[rand.Intn(len(descriptions))]
-		severities := []Severity{SeverityMedium, SeverityHigh}
-		severity = severities[rand.Intn(len(severities))]
-		
-		// Update resource status
-		if rand.Float64() < 0.7 { // 70% Chance für erfolgreiche Exploitation
-			e.UpdateResourceStatus(simulationID, resource.ID, ResourceStatusAttacked)
-		}
-		
-	case 4:
-		// Privilege Escalation Phase
-		eventType = EventTypeEscalation
-		descriptions := []string{
-			"Privilege Escalation über unsichere Berechtigung",
-			"Ausnutzung einer Kernel-Schwachstelle",
-			"Passwort in Klartext gefunden",
-			"Lateral Movement zu kritischem System",
-			"Zugangsdaten gestohlen",
+	// Ziel im Netzwerk bestimmen; ohne zulässiges Ziel handelt der Angreifer nicht
+	attack, found := state.attack.next(eventType, step.Targets, rng)
+	if !found {
+		if !state.untargeted[step.Name] {
+			state.untargeted[step.Name] = true
+			e.appendEventLocked(simulationID, SimulationEvent{
+				ID:           uuid.New().String(),
+				SimulationID: simulationID,
+				Timestamp:    e.clock.Now(),
+				Type:         eventType,
+				Description:  fmt.Sprintf("Kein zulässiges Ziel im Schritt %s", step.Name),
+				Severity:     SeverityInfo,
+				Phase:        step.Name,
+				SimulatedSeconds: state.elapsed.Seconds(),
+				Details:      map[string]interface{}{"phase": state.phase + 1, "targets": step.Targets},
+			})
 		}
-		description = descriptions[rand.Intn(len(descriptions))]
-		severities := []Severity{SeverityHigh, SeverityCritical}
-		severity = severities[rand.Intn(len(severities))]
-		
-		// Update resource status
-		if rand.Float64() < 0.6 { // 60% Chance für erfolgreiche Eskalation
-			e.UpdateResourceStatus(simulationID, resource.ID, ResourceStatusCompromised)
-		}
-		
-	case 5:
-		// Lateral Movement & Data Exfiltration Phase
-		if rand.Float64() < 0.5 {
-			eventType = EventTypeLateralMovement
-			descriptions := []string{
-				"Bewegung zum nächsten Netzwerksegment",
-				"Verwendung gestohlener Anmeldeinformationen",
-				"Remote-Codeausführung",
-				"Nutzung des Pass-the-Hash-Angriffs",
-				"Erstellung eines neuen Administratorkontos",
-			}
-			description = descriptions[rand.Intn(len(descriptions))]
-		} else {
-			eventType = EventTypeDataExfiltration
-			descriptions := []string{
-				"Datenexfiltration über verschlüsselten Tunnel",
-				"Kopieren sensibler Dateien",
-				"E-Mail-Extraktion über SMTP",
-				"Datenbank-Dump erstellt",
-				"Ausführen von Ransomware",
-			}
-			description = descriptions[rand.Intn(len(descriptions))]
+		return
+	}
+	target := state.attack.topology.nodes[attack.Target]
+	
+	// Abwehrmaßnahmen des Ziels prüfen, dann den Erfolg der Aktion auswürfeln
+	defense := evaluateDefenses(target.Controls, eventType, attack.Connection != nil, rng)
+	success := rng.Float64() < step.SuccessProbability && !defense.Blocked
+	outcome := OutcomeFailed
+	switch {
+	case defense.Blocked:
+		outcome = OutcomeBlocked
+	case success:
+		outcome = OutcomeSucceeded
+		state.succeeded[step.Name] = true
+	}
+	
+	// Ressourcenstatus anpassen
+	status := ResourceStatusNormal
+	if impact := step.impactFor(eventType); success && impact != "" {
+		status = impact
+		if impact.rank() >= ResourceStatusAttacked.rank() {
+			// Der Angreifer kann sich von diesem Knoten aus weiterbewegen
+			state.attack.gainFoothold(target.ID)
 		}
-		severities := []Severity{SeverityHigh, SeverityCritical}
-		severity = severities[rand.Intn(len(severities))]
+	}
+	e.recordResourceImpactLocked(simulationID, target, status, attack.vector())
+	
+	details := map[string]interface{}{
+		"resource": target.Name,
+		"phase":    state.phase + 1,
+		"vector":   attack.vector(),
+	}
+	if len(step.Techniques) > 0 {
+		details["techniques"] = step.Techniques
+	}
+	if defense.Detected {
+		details["detectedBy"] = defense.DetectedBy
+	}
+	if defense.Blocked {
+		details["blockedBy"] = defense.BlockedBy
+	}
+	if status != ResourceStatusNormal {
+		details["impact"] = string(status)
+	}
+	if attack.Source != "" {
+		details["source"] = attack.Source
+	}
+	if attack.Connection != nil {
+		details["connection"] = attack.Connection.ID
+		details["protocol"] = attack.Connection.Protocol
+		details["port"] = attack.Port
 	}
 	
 	// Erstelle das Ereignis
-	now := time.Now()
+	now := e.clock.Now()
 	event := SimulationEvent{
 		ID:           uuid.New().String(),
 		SimulationID: simulationID,
 		Timestamp:    now,
 		Type:         eventType,
 		Description:  description,
-		ResourceID:   resource.ID,
+		ResourceID:   target.ID,
 		Severity:     severity,
-		Details:      map[string]interface{}{"resource": resource.Name, "phase": phase},
+		Phase:        step.Name,
+		Outcome:      outcome,
+		Detected:     defense.Detected,
+		Blocked:      defense.Blocked,
+		SimulatedSeconds: state.elapsed.Seconds(),
+		Details:      details,
 	}
 	
-	e.AddEvent(simulationID, event)
+	e.appendEventLocked(simulationID, event)
+}
+
+// recordResourceImpactLocked legt die Ressource zu einem angegriffenen Knoten an oder stuft ihren
+// Status hoch. Gespeichert wird der Angriffsweg, über den der Angreifer die Ressource erreicht hat.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) recordResourceImpactLocked(simulationID string, node *topologyNode, status ResourceStatus, vector string) {
+	resources := e.affectedResources[simulationID]
+	for i := range resources {
+		if resources[i].ID != node.ID {
+			continue
+		}
+		if status.rank() > resources[i].Status.rank() {
+			breached := status.rank() >= ResourceStatusAttacked.rank() && resources[i].Status.rank() < ResourceStatusAttacked.rank()
+			if resources[i].AttackVector == "" || breached {
+				resources[i].AttackVector = vector
+			}
+			resources[i].Status = status
+			resources[i].ThreatLevel = threatLevelFor(status)
+			e.saveResourceLocked(resources[i])
+		}
+		return
+	}
+
+	resource := AffectedResource{
+		ID:           node.ID,
+		SimulationID: simulationID,
+		RunID:        e.runIDLocked(simulationID),
+		Name:         node.Name,
+		Type:         node.Type,
+		Status:       status,
+		ThreatLevel:  threatLevelFor(status),
+	}
+	if status.rank() > ResourceStatusNormal.rank() {
+		resource.AttackVector = vector
+	}
+	e.affectedResources[simulationID] = append(resources, resource)
+	e.saveResourceLocked(resource)
 }
@@ -0,0 +1,181 @@
+// backend/internal/simulation/engine_test.go
+package simulation
+
+import (
+	"fmt"
+	"testing"
+)
+
+// runInstant führt eine Simulation im Modus "instant" bis zum Abschluss aus
+func runInstant(t *testing.T, engine *Engine, config SimulationConfig) *Simulation {
+	t.Helper()
+
+	if config.Parameters == nil {
+		config.Parameters = map[string]interface{}{}
+	}
+	config.Parameters[ParameterSpeed] = SpeedInstant
+
+	sim, err := engine.CreateSimulation(config)
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	if _, err := engine.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+	waitFor(t, "Abschluss der Simulation", func() bool {
+		status, _ := engine.GetSimulationStatus(sim.ID)
+		return status.Status == StatusCompleted
+	})
+	return sim
+}
+
+// eventTrace beschreibt den Ablauf einer Simulation ohne IDs und Zeitstempel
+func eventTrace(t *testing.T, engine *Engine, id string) []string {
+	t.Helper()
+
+	events, err := engine.GetEvents(id)
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen der Events: %v", err)
+	}
+	resources, err := engine.GetAffectedResources(id)
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen der Ressourcen: %v", err)
+	}
+
+	var trace []string
+	for _, event := range events {
+		trace = append(trace, fmt.Sprintf("%s|%s|%s|%s", event.Type, event.Severity, event.ResourceID, event.Description))
+	}
+	for _, resource := range resources {
+		trace = append(trace, fmt.Sprintf("%s|%s", resource.ID, resource.Status))
+	}
+	return trace
+}
+
+func TestSeededRunsAreReproducible(t *testing.T) {
+	engine := NewEngine()
+
+	first := runInstant(t, engine, SimulationConfig{
+		Name:       "Seed A",
+		Parameters: map[string]interface{}{ParameterSeed: 42.0},
+	})
+	second := runInstant(t, engine, SimulationConfig{
+		Name:       "Seed B",
+		Parameters: map[string]interface{}{ParameterSeed: "42"},
+	})
+
+	if first.Seed != 42 || second.Seed != 42 {
+		t.Fatalf("Erwarteter Seed: 42, Erhalten: %d und %d", first.Seed, second.Seed)
+	}
+
+	firstTrace := eventTrace(t, engine, first.ID)
+	secondTrace := eventTrace(t, engine, second.ID)
+	if len(firstTrace) != len(secondTrace) {
+		t.Fatalf("Unterschiedliche Anzahl von Einträgen: %d und %d", len(firstTrace), len(secondTrace))
+	}
+	for i := range firstTrace {
+		if firstTrace[i] != secondTrace[i] {
+			t.Fatalf("Abweichung an Position %d: %q und %q", i, firstTrace[i], secondTrace[i])
+		}
+	}
+
+	// Ohne Seed wird einer erzeugt und an der Simulation gespeichert
+	generated, err := engine.CreateSimulation(SimulationConfig{Name: "Ohne Seed"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	if generated.Seed == 0 {
+		t.Fatal("Es wurde kein Seed erzeugt")
+	}
+}
+
+func TestScenarioStepsDriveTheRun(t *testing.T) {
+	engine := NewEngine()
+
+	// Jede Phase des Szenarios wird in der definierten Reihenfolge gestartet
+	for _, scenarioID := range []string{"scenario-1", "scenario-2", "scenario-3"} {
+		plan, err := engine.loadScenarioPlan(scenarioID)
+		if err != nil {
+			t.Fatalf("Fehler beim Laden von %s: %v", scenarioID, err)
+		}
+
+		sim := runInstant(t, engine, SimulationConfig{
+			Name:       plan.Name,
+			ScenarioID: scenarioID,
+			Parameters: map[string]interface{}{ParameterSeed: 7.0},
+		})
+		events, _ := engine.GetEvents(sim.ID)
+
+		var phases []string
+		for _, event := range events {
+			if event.Description == "Phase gestartet: "+event.Phase {
+				phases = append(phases, event.Phase)
+			}
+		}
+		if len(phases) != len(plan.Steps) {
+			t.Fatalf("%s: Erwartete Phasen: %d, Erhaltene Phasen: %v", scenarioID, len(plan.Steps), phases)
+		}
+		for i, step := range plan.Steps {
+			if phases[i] != step.Name {
+				t.Fatalf("%s: Erwartete Phase %d: %s, Erhalten: %s", scenarioID, i+1, step.Name, phases[i])
+			}
+		}
+	}
+
+	// Der Compliance Check erzeugt nur Discovery-Events und kompromittiert nichts
+	compliance := runInstant(t, engine, SimulationConfig{Name: "Compliance", ScenarioID: "Compliance Check"})
+	events, _ := engine.GetEvents(compliance.ID)
+	for _, event := range events {
+		if event.Type != EventTypeDiscovery && event.Type != EventTypeSystem {
+			t.Fatalf("Unerwarteter Eventtyp im Compliance Check: %s", event.Type)
+		}
+	}
+	status, _ := engine.GetSimulationStatus(compliance.ID)
+	if status.CompromisedResources != 0 {
+		t.Fatalf("Compliance Check hat %d Ressourcen kompromittiert", status.CompromisedResources)
+	}
+}
+
+func TestAttackSpreadsAlongConnections(t *testing.T) {
+	infrastructure := map[string]interface{}{
+		"nodes": []map[string]interface{}{
+			{"id": "ws-1", "name": "Workstation", "type": "workstation"},
+			{"id": "srv-1", "name": "App Server", "type": "server"},
+			{"id": "srv-2", "name": "DB Server", "type": "server"},
+			{"id": "srv-isolated", "name": "Isolated Server", "type": "server"},
+		},
+		"connections": []map[string]interface{}{
+			{"id": "c1", "source": "ws-1", "target": "srv-1", "protocol": "TCP", "ports": []string{"445"}},
+			{"id": "c2", "source": "srv-1", "target": "srv-2", "protocol": "TCP", "ports": []string{"5432"}},
+		},
+	}
+	engine := NewEngine(WithInfrastructureLoader(func(string) (map[string]interface{}, error) {
+		return infrastructure, nil
+	}))
+
+	for seed := 1; seed <= 5; seed++ {
+		sim := runInstant(t, engine, SimulationConfig{
+			Name:       "Propagation",
+			ScenarioID: "scenario-2",
+			Parameters: map[string]interface{}{ParameterSeed: float64(seed)},
+		})
+
+		resources, _ := engine.GetAffectedResources(sim.ID)
+		for _, resource := range resources {
+			switch resource.ID {
+			case "srv-isolated":
+				t.Fatalf("Seed %d: Knoten ohne Verbindung wurde angegriffen", seed)
+			case "ws-1":
+				// Einstiegspunkt
+			case "srv-1":
+				if resource.Status.rank() >= ResourceStatusAttacked.rank() && resource.AttackVector != "Verbindung c1 (ws-1 -> srv-1) über TCP/445" {
+					t.Fatalf("Seed %d: unerwarteter Angriffsweg für srv-1: %s", seed, resource.AttackVector)
+				}
+			case "srv-2":
+				if resource.Status.rank() >= ResourceStatusAttacked.rank() && resource.AttackVector != "Verbindung c2 (srv-1 -> srv-2) über TCP/5432" {
+					t.Fatalf("Seed %d: unerwarteter Angriffsweg für srv-2: %s", seed, resource.AttackVector)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// backend/internal/simulation/events.go
+package simulation
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor wird zurückgegeben, wenn ein Cursor nicht von QueryEvents stammt
+var ErrInvalidCursor = errors.New("ungültiger Cursor")
+
+// severityRank ordnet die Schweregrade aufsteigend
+var severityRank = map[Severity]int{
+	SeverityInfo:     0,
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// ValidSeverity prüft, ob ein Schweregrad bekannt ist
+func ValidSeverity(severity Severity) bool {
+	_, ok := severityRank[severity]
+	return ok
+}
+
+// eventTypes sind alle Eventtypen, die eine Simulation erzeugen kann
+var eventTypes = map[EventType]bool{
+	EventTypeDiscovery:        true,
+	EventTypeEscalation:       true,
+	EventTypeExploitation:     true,
+	EventTypeLateralMovement:  true,
+	EventTypeDataExfiltration: true,
+	EventTypeSystem:           true,
+	EventTypeDefense:          true,
+}
+
+// ValidEventType prüft, ob ein Eventtyp bekannt ist
+func ValidEventType(eventType EventType) bool {
+	return eventTypes[eventType]
+}
+
+// severitiesFrom gibt alle Schweregrade zurück, die mindestens so hoch wie min sind
+func severitiesFrom(min Severity) []Severity {
+	var severities []Severity
+	for severity, rank := range severityRank {
+		if rank >= severityRank[min] {
+			severities = append(severities, severity)
+		}
+	}
+	return severities
+}
+
+// EventFilter schränkt die Events einer Simulation ein. Leere Felder filtern nicht;
+// mehrere Werte eines Feldes sind alternativ, verschiedene Felder müssen alle zutreffen.
+type EventFilter struct {
+	Types       []EventType
+	Severities  []Severity
+	MinSeverity Severity   // nur Events mit mindestens diesem Schweregrad
+	ResourceID  string
+	Since       *time.Time // einschließlich
+	Until       *time.Time // ausschließlich
+}
+
+// matches prüft, ob ein Event den Filter erfüllt
+func (f EventFilter) matches(event SimulationEvent) bool {
+	if len(f.Types) > 0 && !containsType(f.Types, event.Type) {
+		return false
+	}
+	if len(f.Severities) > 0 && !containsSeverity(f.Severities, event.Severity) {
+		return false
+	}
+	if f.MinSeverity != "" && severityRank[event.Severity] < severityRank[f.MinSeverity] {
+		return false
+	}
+	if f.ResourceID != "" && event.ResourceID != f.ResourceID {
+		return false
+	}
+	if f.Since != nil && event.Timestamp.Before(*f.Since) {
+		return false
+	}
+	if f.Until != nil && !event.Timestamp.Before(*f.Until) {
+		return false
+	}
+	return true
+}
+
+func containsType(types []EventType, eventType EventType) bool {
+	for _, t := range types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSeverity(severities []Severity, severity Severity) bool {
+	for _, s := range severities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// EventQuery ist eine Abfrage von Events an einen Store: ein Filter und der
+// gewünschte Ausschnitt, begrenzt über die Sequenznummern der Events
+type EventQuery struct {
+	EventFilter
+	AfterSequence  int64 // nur Events mit größerer Sequenz, 0 ohne Grenze
+	BeforeSequence int64 // nur Events mit kleinerer Sequenz, 0 ohne Grenze
+	Latest         bool  // die letzten statt der ersten Limit Events des Ausschnitts
+	Limit          int   // 0 ohne Begrenzung
+}
+
+// matches prüft, ob ein Event in den Ausschnitt der Abfrage fällt
+func (q EventQuery) matches(event SimulationEvent) bool {
+	if q.AfterSequence > 0 && event.Sequence <= q.AfterSequence {
+		return false
+	}
+	if q.BeforeSequence > 0 && event.Sequence >= q.BeforeSequence {
+		return false
+	}
+	return q.EventFilter.matches(event)
+}
+
+// apply wählt die passenden Events aus einer nach Sequenz sortierten Liste aus
+func (q EventQuery) apply(events []SimulationEvent) []SimulationEvent {
+	result := []SimulationEvent{}
+	for _, event := range events {
+		if q.matches(event) {
+			result = append(result, event)
+		}
+	}
+	if q.Limit > 0 && len(result) > q.Limit {
+		if q.Latest {
+			result = result[len(result)-q.Limit:]
+		} else {
+			result = result[:q.Limit]
+		}
+	}
+	return result
+}
+
+// EventPage ist eine Seite von Events, aufsteigend nach Sequenz. NextCursor führt
+// zu neueren Events und ist bei einer nicht leeren Seite immer gesetzt, damit ein
+// Client auch auf künftige Events warten kann; PrevCursor führt zu älteren Events
+// und fehlt, wenn es keine gibt.
+type EventPage struct {
+	Events     []SimulationEvent `json:"events"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+	PrevCursor string            `json:"prevCursor,omitempty"`
+}
+
+// Ein Cursor zeigt hinter ("next") oder vor ("prev") ein Event. Für Clients ist er
+// undurchsichtig; sie geben ihn unverändert zurück.
+const (
+	cursorNext = "next"
+	cursorPrev = "prev"
+)
+
+func encodeCursor(direction string, sequence int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%d", direction, sequence)))
+}
+
+func decodeCursor(cursor string) (string, int64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", 0, ErrInvalidCursor
+	}
+	direction, value, found := strings.Cut(string(data), ":")
+	if !found || (direction != cursorNext && direction != cursorPrev) {
+		return "", 0, ErrInvalidCursor
+	}
+	sequence, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || sequence < 0 {
+		return "", 0, ErrInvalidCursor
+	}
+	return direction, sequence, nil
+}
+
+// QueryEvents gibt eine Seite gefilterter Events des aktuellen Laufs einer Simulation zurück. Ohne
+// Cursor sind das die letzten limit Events; ein Cursor einer vorherigen Seite blättert
+// vor oder zurück. limit <= 0 liefert alle passenden Events. Die Abfrage geht an den
+// Store, damit Datenbanken nur die benötigten Events laden.
+func (e *Engine) QueryEvents(simulationID string, filter EventFilter, cursor string, limit int) (*EventPage, error) {
+	e.ensureLoaded(simulationID)
+
+	e.mutex.RLock()
+	runID := e.runIDLocked(simulationID)
+	_, exists := e.simulations[simulationID]
+	e.mutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, simulationID)
+	}
+
+	direction, sequence := cursorPrev, int64(0)
+	if cursor != "" {
+		var err error
+		if direction, sequence, err = decodeCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Ein Event mehr laden, um zu erkennen, ob es in Blätterrichtung weitergeht
+	query := EventQuery{EventFilter: filter}
+	if limit > 0 {
+		query.Limit = limit + 1
+	}
+	if direction == cursorNext {
+		query.AfterSequence = sequence
+	} else {
+		query.BeforeSequence = sequence
+		query.Latest = true
+	}
+
+	events, err := e.store.QueryEvents(simulationID, runID, query)
+	if err != nil {
+		return nil, err
+	}
+	more := limit > 0 && len(events) > limit
+	if more {
+		if direction == cursorNext {
+			events = events[:limit]
+		} else {
+			events = events[1:]
+		}
+	}
+
+	page := &EventPage{Events: events}
+	if len(events) == 0 {
+		// Eine leere Seite in Vorwärtsrichtung bleibt an derselben Stelle
+		if direction == cursorNext {
+			page.NextCursor = encodeCursor(cursorNext, sequence)
+		} else if cursor == "" {
+			page.NextCursor = encodeCursor(cursorNext, 0)
+		}
+		return page, nil
+	}
+	page.NextCursor = encodeCursor(cursorNext, events[len(events)-1].Sequence)
+	if (direction == cursorNext && sequence > 0) || more {
+		page.PrevCursor = encodeCursor(cursorPrev, events[0].Sequence)
+	}
+	return page, nil
+}
@@ -0,0 +1,156 @@
+// backend/internal/simulation/events_test.go
+package simulation
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// eventIDs gibt die IDs der Events in ihrer Reihenfolge zurück
+func eventIDs(events []SimulationEvent) []string {
+	ids := []string{}
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+// pageThrough blättert vom Ende bis zum Anfang zurück und von dort wieder vorwärts und
+// prüft, dass beide Richtungen genau die erwarteten Events liefern
+func pageThrough(t *testing.T, engine *Engine, id string, filter EventFilter, expected []string) {
+	t.Helper()
+
+	const limit = 7
+	page, err := engine.QueryEvents(id, filter, "", limit)
+	if err != nil {
+		t.Fatalf("Fehler beim Abfragen der Events: %v", err)
+	}
+	backward := eventIDs(page.Events)
+	first := page
+	for first.PrevCursor != "" {
+		first, err = engine.QueryEvents(id, filter, first.PrevCursor, limit)
+		if err != nil {
+			t.Fatalf("Fehler beim Zurückblättern: %v", err)
+		}
+		backward = append(eventIDs(first.Events), backward...)
+	}
+	if !reflect.DeepEqual(backward, expected) {
+		t.Fatalf("Zurückblättern: Erwartet %d Events, Erhalten %d", len(expected), len(backward))
+	}
+
+	forward := eventIDs(first.Events)
+	for cursor := first.NextCursor; ; {
+		next, err := engine.QueryEvents(id, filter, cursor, limit)
+		if err != nil {
+			t.Fatalf("Fehler beim Vorblättern: %v", err)
+		}
+		if len(next.Events) == 0 {
+			if next.NextCursor != cursor {
+				t.Fatal("Eine leere Seite sollte auf dieselbe Stelle verweisen")
+			}
+			break
+		}
+		forward = append(forward, eventIDs(next.Events)...)
+		cursor = next.NextCursor
+	}
+	if !reflect.DeepEqual(forward, expected) {
+		t.Fatalf("Vorblättern: Erwartet %d Events, Erhalten %d", len(expected), len(forward))
+	}
+}
+
+// assertEventQueries prüft Filter und Blättern der Events auf einem Store
+func assertEventQueries(t *testing.T, store Store) {
+	t.Helper()
+
+	engine := NewEngine(WithStore(store))
+	sim := runInstant(t, engine, SimulationConfig{
+		Name:       "Events",
+		ScenarioID: "scenario-2",
+		Parameters: map[string]interface{}{ParameterSeed: 3.0},
+	})
+
+	events, err := engine.GetEvents(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen der Events: %v", err)
+	}
+	for i, event := range events {
+		if event.Sequence != int64(i+1) {
+			t.Fatalf("Event %d hat Sequenz %d", i+1, event.Sequence)
+		}
+	}
+
+	var resourceID string
+	for _, event := range events {
+		if event.ResourceID != "" {
+			resourceID = event.ResourceID
+			break
+		}
+	}
+	since, until := events[len(events)/3].Timestamp, events[2*len(events)/3].Timestamp
+
+	cases := []struct {
+		name   string
+		filter EventFilter
+		want   func(SimulationEvent) bool
+	}{
+		{"ohne Filter", EventFilter{}, func(SimulationEvent) bool { return true }},
+		{"Typ", EventFilter{Types: []EventType{EventTypeSystem, EventTypeDiscovery}}, func(e SimulationEvent) bool {
+			return e.Type == EventTypeSystem || e.Type == EventTypeDiscovery
+		}},
+		{"Schweregrad", EventFilter{Severities: []Severity{SeverityMedium}}, func(e SimulationEvent) bool {
+			return e.Severity == SeverityMedium
+		}},
+		{"Mindestschweregrad", EventFilter{MinSeverity: SeverityHigh}, func(e SimulationEvent) bool {
+			return e.Severity == SeverityHigh || e.Severity == SeverityCritical
+		}},
+		{"Ressource", EventFilter{ResourceID: resourceID}, func(e SimulationEvent) bool {
+			return e.ResourceID == resourceID
+		}},
+		{"Zeitraum", EventFilter{Since: &since, Until: &until}, func(e SimulationEvent) bool {
+			return !e.Timestamp.Before(since) && e.Timestamp.Before(until)
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var expected []SimulationEvent
+			for _, event := range events {
+				if c.want(event) {
+					expected = append(expected, event)
+				}
+			}
+			if len(expected) == 0 {
+				t.Fatal("Der Lauf enthält keine passenden Events")
+			}
+			pageThrough(t, engine, sim.ID, c.filter, eventIDs(expected))
+		})
+	}
+
+	if _, err := engine.QueryEvents(sim.ID, EventFilter{}, "kein-cursor", 10); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrInvalidCursor, err)
+	}
+	if _, err := engine.QueryEvents("unbekannt", EventFilter{}, "", 10); !errors.Is(err, ErrSimulationNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrSimulationNotFound, err)
+	}
+}
+
+func TestQueryEventsInMemory(t *testing.T) {
+	assertEventQueries(t, NewMemoryStore())
+}
+
+func TestQueryEventsInSQLite(t *testing.T) {
+	assertEventQueries(t, openSQLiteRepository(t, filepath.Join(t.TempDir(), "aegis.db")))
+}
+
+func TestValidEventType(t *testing.T) {
+	for eventType := range scenarioEventTypes {
+		if !ValidEventType(eventType) {
+			t.Fatalf("Der Eventtyp %s sollte gültig sein", eventType)
+		}
+	}
+	if !ValidEventType(EventTypeDefense) || ValidEventType("atack") {
+		t.Fatal("Unerwartetes Ergebnis für defense oder atack")
+	}
+}
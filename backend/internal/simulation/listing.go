@@ -0,0 +1,282 @@
+// backend/internal/simulation/listing.go
+package simulation
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+)
+
+// SimulationSort ist das Feld, nach dem die Liste der Simulationen sortiert wird
+type SimulationSort string
+
+const (
+	SortCreatedAt SimulationSort = "createdAt"
+	SortUpdatedAt SimulationSort = "updatedAt"
+	SortName      SimulationSort = "name"
+	SortStatus    SimulationSort = "status"
+)
+
+// sortColumns ordnet jedem Sortierfeld seine Spalte in der Datenbank zu
+var sortColumns = map[SimulationSort]string{
+	SortCreatedAt: "created_at",
+	SortUpdatedAt: "updated_at",
+	SortName:      "name",
+	SortStatus:    "status",
+}
+
+// ValidSimulationSort prüft, ob nach einem Feld sortiert werden kann
+func ValidSimulationSort(field SimulationSort) bool {
+	_, ok := sortColumns[field]
+	return ok
+}
+
+// SimulationFilter schränkt die Liste der Simulationen ein. Leere Felder filtern nicht.
+type SimulationFilter struct {
+	Statuses         []Status
+	ScenarioID       string
+	InfrastructureID string
+	CreatedAfter     *time.Time // einschließlich
+	CreatedBefore    *time.Time // ausschließlich
+	Search           string     // Teil des Namens, ohne Beachtung der Groß- und Kleinschreibung
+	Archived         *bool      // nur archivierte (true) oder nicht archivierte (false) Simulationen
+}
+
+// matches prüft, ob eine Simulation den Filter erfüllt
+func (f SimulationFilter) matches(simulation *Simulation) bool {
+	if len(f.Statuses) > 0 && !containsStatus(f.Statuses, simulation.Status) {
+		return false
+	}
+	if f.ScenarioID != "" && simulation.ScenarioID != f.ScenarioID {
+		return false
+	}
+	if f.InfrastructureID != "" && simulation.InfrastructureID != f.InfrastructureID {
+		return false
+	}
+	if f.CreatedAfter != nil && simulation.CreatedAt.Before(*f.CreatedAfter) {
+		return false
+	}
+	if f.CreatedBefore != nil && !simulation.CreatedAt.Before(*f.CreatedBefore) {
+		return false
+	}
+	if f.Archived != nil && *f.Archived != (simulation.ArchivedAt != nil) {
+		return false
+	}
+	if f.Search != "" && !strings.Contains(strings.ToLower(simulation.Name), strings.ToLower(f.Search)) {
+		return false
+	}
+	return true
+}
+
+func containsStatus(statuses []Status, status Status) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// listCursor ist die Position hinter der letzten Simulation einer Seite: ihr Wert im
+// Sortierfeld und ihre ID, die bei gleichen Werten die Reihenfolge festlegt
+type listCursor struct {
+	Sort  SimulationSort `json:"s"`
+	Value string         `json:"v"`
+	ID    string         `json:"id"`
+}
+
+// sortValue gibt den Wert einer Simulation im Sortierfeld zurück. Zeitpunkte werden so
+// formatiert, dass sie wieder eingelesen werden können.
+func sortValue(simulation *Simulation, field SimulationSort) string {
+	switch field {
+	case SortUpdatedAt:
+		return simulation.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case SortName:
+		return simulation.Name
+	case SortStatus:
+		return string(simulation.Status)
+	default:
+		return simulation.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+func encodeListCursor(cursor listCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(value string, field SimulationSort) (*listCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor listCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	// Ein Cursor gilt nur für die Sortierung, mit der er erzeugt wurde
+	if cursor.Sort != field {
+		return nil, ErrInvalidCursor
+	}
+	if field == SortCreatedAt || field == SortUpdatedAt {
+		if _, err := time.Parse(time.RFC3339Nano, cursor.Value); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return &cursor, nil
+}
+
+// SimulationQuery ist eine Abfrage der Liste der Simulationen. Geblättert wird entweder
+// über Offset oder über einen Cursor der vorherigen Seite.
+type SimulationQuery struct {
+	SimulationFilter
+	Sort       SimulationSort // Standard: createdAt
+	Descending bool
+	Offset     int
+	Limit      int    // 0 ohne Begrenzung
+	Cursor     string // NextCursor einer vorherigen Seite
+}
+
+// sortField gibt das Sortierfeld der Abfrage zurück
+func (q SimulationQuery) sortField() SimulationSort {
+	if q.Sort == "" {
+		return SortCreatedAt
+	}
+	return q.Sort
+}
+
+// compare vergleicht zwei Simulationen in der Reihenfolge der Abfrage
+func (q SimulationQuery) compare(a, b *Simulation) int {
+	field := q.sortField()
+	result := 0
+	switch field {
+	case SortCreatedAt:
+		result = a.CreatedAt.Compare(b.CreatedAt)
+	case SortUpdatedAt:
+		result = a.UpdatedAt.Compare(b.UpdatedAt)
+	default:
+		result = strings.Compare(sortValue(a, field), sortValue(b, field))
+	}
+	if result == 0 {
+		result = strings.Compare(a.ID, b.ID)
+	}
+	if q.Descending {
+		return -result
+	}
+	return result
+}
+
+// afterCursor prüft, ob eine Simulation in der Reihenfolge der Abfrage hinter dem Cursor liegt
+func (q SimulationQuery) afterCursor(simulation *Simulation, cursor *listCursor) bool {
+	field := q.sortField()
+	result := 0
+	switch field {
+	case SortCreatedAt, SortUpdatedAt:
+		value, _ := time.Parse(time.RFC3339Nano, cursor.Value)
+		at := simulation.CreatedAt
+		if field == SortUpdatedAt {
+			at = simulation.UpdatedAt
+		}
+		result = at.Compare(value)
+	default:
+		result = strings.Compare(sortValue(simulation, field), cursor.Value)
+	}
+	if result == 0 {
+		result = strings.Compare(simulation.ID, cursor.ID)
+	}
+	if q.Descending {
+		return result < 0
+	}
+	return result > 0
+}
+
+// apply filtert, sortiert und begrenzt eine Liste von Simulationen. Zurückgegeben
+// werden die Seite und die Anzahl aller passenden Simulationen.
+func (q SimulationQuery) apply(simulations []*Simulation) ([]*Simulation, int, error) {
+	var cursor *listCursor
+	if q.Cursor != "" {
+		var err error
+		if cursor, err = decodeListCursor(q.Cursor, q.sortField()); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	matching := []*Simulation{}
+	for _, simulation := range simulations {
+		if q.matches(simulation) {
+			matching = append(matching, simulation)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return q.compare(matching[i], matching[j]) < 0
+	})
+	total := len(matching)
+
+	page := matching
+	if cursor != nil {
+		start := sort.Search(len(page), func(i int) bool { return q.afterCursor(page[i], cursor) })
+		page = page[start:]
+	}
+	if q.Offset > 0 {
+		if q.Offset >= len(page) {
+			page = page[:0]
+		} else {
+			page = page[q.Offset:]
+		}
+	}
+	if q.Limit > 0 && len(page) > q.Limit {
+		page = page[:q.Limit]
+	}
+	return page, total, nil
+}
+
+// SimulationPage ist eine Seite der Liste der Simulationen. Total ist die Anzahl
+// aller Simulationen, die den Filter erfüllen; NextCursor fehlt auf der letzten Seite.
+type SimulationPage struct {
+	Simulations []*Simulation `json:"simulations"`
+	Total       int           `json:"total"`
+	NextCursor  string        `json:"nextCursor,omitempty"`
+}
+
+// QuerySimulations gibt eine sortierte und gefilterte Seite aller Simulationen zurück.
+// Die Abfrage geht an den Store, der jede Änderung gleich nach dem Freigeben von e.mutex
+// erhält. Zurückgegeben werden seine Kopien; so passen Filter, Reihenfolge und Inhalt der
+// Seite zusammen, und Aufrufer verändern keine Simulation der Engine.
+func (e *Engine) QuerySimulations(query SimulationQuery) (*SimulationPage, error) {
+	if !ValidSimulationSort(query.sortField()) {
+		return nil, fmt.Errorf("%w: Sortierung nach %q nicht möglich", ErrInvalidParameter, query.Sort)
+	}
+
+	// Eine Simulation mehr laden, um zu erkennen, ob es eine weitere Seite gibt
+	limit := query.Limit
+	if limit > 0 {
+		query.Limit = limit + 1
+	}
+	simulations, total, err := e.store.QuerySimulations(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &SimulationPage{Simulations: simulations, Total: total}
+	if limit > 0 && len(simulations) > limit {
+		page.Simulations = simulations[:limit]
+		last := page.Simulations[limit-1]
+		page.NextCursor = encodeListCursor(listCursor{Sort: query.sortField(), Value: sortValue(last, query.sortField()), ID: last.ID})
+	}
+	return page, nil
+}
+
+// GetSimulations gibt alle Simulationen zurück, die neuesten zuerst
+func (e *Engine) GetSimulations() []*Simulation {
+	page, err := e.QuerySimulations(SimulationQuery{Descending: true})
+	if err != nil {
+		logging.Logger.Errorf("Simulationen konnten nicht aus dem Store geladen werden: %v", err)
+		return []*Simulation{}
+	}
+	return page.Simulations
+}
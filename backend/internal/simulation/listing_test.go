@@ -0,0 +1,153 @@
+// backend/internal/simulation/listing_test.go
+package simulation
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// simulationNames gibt die Namen der Simulationen in ihrer Reihenfolge zurück
+func simulationNames(simulations []*Simulation) []string {
+	names := []string{}
+	for _, simulation := range simulations {
+		names = append(names, simulation.Name)
+	}
+	return names
+}
+
+// assertSimulationQueries prüft Sortierung, Filter und Blättern der Liste auf einem Store
+func assertSimulationQueries(t *testing.T, store Store) {
+	t.Helper()
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	engine := NewEngine(WithClock(clock), WithStore(store))
+
+	// Angelegt im Abstand von einer Minute, in dieser Reihenfolge
+	for _, config := range []SimulationConfig{
+		{Name: "Delta", ScenarioID: "scenario-1"},
+		{Name: "alpha", ScenarioID: "scenario-2"},
+		{Name: "Charlie", ScenarioID: "scenario-1"},
+		{Name: "Bravo 100%", ScenarioID: "scenario-2"},
+		{Name: "Echo", ScenarioID: "scenario-1"},
+	} {
+		if _, err := engine.CreateSimulation(config); err != nil {
+			t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+		}
+		clock.Advance(time.Minute)
+	}
+	after := start.Add(time.Minute)
+	before := start.Add(4 * time.Minute)
+
+	cases := []struct {
+		name  string
+		query SimulationQuery
+		want  []string
+	}{
+		{"neueste zuerst", SimulationQuery{Descending: true}, []string{"Echo", "Bravo 100%", "Charlie", "alpha", "Delta"}},
+		{"nach Name", SimulationQuery{Sort: SortName}, []string{"Bravo 100%", "Charlie", "Delta", "Echo", "alpha"}},
+		{"Szenario", SimulationQuery{SimulationFilter: SimulationFilter{ScenarioID: "scenario-2"}}, []string{"alpha", "Bravo 100%"}},
+		{"Suche", SimulationQuery{SimulationFilter: SimulationFilter{Search: "AR"}}, []string{"Charlie"}},
+		{"Suche mit Platzhalter", SimulationQuery{SimulationFilter: SimulationFilter{Search: "0%"}}, []string{"Bravo 100%"}},
+		{"Zeitraum", SimulationQuery{SimulationFilter: SimulationFilter{CreatedAfter: &after, CreatedBefore: &before}}, []string{"alpha", "Charlie", "Bravo 100%"}},
+		{"Status", SimulationQuery{SimulationFilter: SimulationFilter{Statuses: []Status{StatusCompleted}}}, []string{}},
+		{"Seite", SimulationQuery{Sort: SortName, Offset: 2, Limit: 2}, []string{"Delta", "Echo"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			page, err := engine.QuerySimulations(c.query)
+			if err != nil {
+				t.Fatalf("Fehler beim Abfragen der Simulationen: %v", err)
+			}
+			if names := simulationNames(page.Simulations); !reflect.DeepEqual(names, c.want) {
+				t.Fatalf("Erwartet: %v, Erhalten: %v", c.want, names)
+			}
+		})
+	}
+
+	// Blättern mit Cursor liefert jede Simulation genau einmal und zählt alle
+	for _, sort := range []SimulationSort{SortCreatedAt, SortUpdatedAt, SortName, SortStatus} {
+		full, _ := engine.QuerySimulations(SimulationQuery{Sort: sort, Descending: true})
+		query := SimulationQuery{Sort: sort, Descending: true, Limit: 2}
+		var paged []*Simulation
+		for {
+			page, err := engine.QuerySimulations(query)
+			if err != nil {
+				t.Fatalf("Fehler beim Blättern nach %s: %v", sort, err)
+			}
+			if page.Total != 5 {
+				t.Fatalf("Erwartete Gesamtzahl: 5, Erhalten: %d", page.Total)
+			}
+			paged = append(paged, page.Simulations...)
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		if !reflect.DeepEqual(simulationNames(paged), simulationNames(full.Simulations)) {
+			t.Fatalf("Blättern nach %s: Erwartet %v, Erhalten %v", sort, simulationNames(full.Simulations), simulationNames(paged))
+		}
+	}
+
+	// Ein Cursor gilt nur für seine Sortierung
+	page, _ := engine.QuerySimulations(SimulationQuery{Sort: SortName, Limit: 2})
+	if _, err := engine.QuerySimulations(SimulationQuery{Sort: SortStatus, Cursor: page.NextCursor}); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrInvalidCursor, err)
+	}
+}
+
+func TestQuerySimulationsInMemory(t *testing.T) {
+	assertSimulationQueries(t, NewMemoryStore())
+}
+
+func TestQuerySimulationsInSQLite(t *testing.T) {
+	assertSimulationQueries(t, openSQLiteRepository(t, filepath.Join(t.TempDir(), "aegis.db")))
+}
+
+// laggingStore übernimmt keine Änderungen an Simulationen mehr, sobald lagging gesetzt
+// ist, wie ein Store, der hinter dem Speicher der Engine zurückliegt
+type laggingStore struct {
+	*MemoryStore
+	lagging bool
+}
+
+func (s *laggingStore) SaveSimulation(sim *Simulation) error {
+	if s.lagging {
+		return nil
+	}
+	return s.MemoryStore.SaveSimulation(sim)
+}
+
+func TestQuerySimulationsReturnsConsistentCopies(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	store := &laggingStore{MemoryStore: NewMemoryStore()}
+	engine := NewEngine(WithClock(clock), WithStore(store))
+
+	sim, err := engine.CreateSimulation(SimulationConfig{Name: "Abweichend"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	store.lagging = true
+	if _, err := engine.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+	defer engine.StopSimulation(sim.ID)
+
+	// Jede Simulation der Seite erfüllt den Filter, nach dem der Store gesucht hat
+	page, err := engine.QuerySimulations(SimulationQuery{SimulationFilter: SimulationFilter{Statuses: []Status{StatusNotStarted}}})
+	if err != nil {
+		t.Fatalf("Fehler beim Abfragen der Simulationen: %v", err)
+	}
+	if len(page.Simulations) != 1 || page.Simulations[0].Status != StatusNotStarted {
+		t.Fatalf("Erwartet: 1 Simulation mit Status %s, Erhalten: %+v", StatusNotStarted, page.Simulations)
+	}
+
+	// Änderungen an der Seite erreichen die Engine nicht
+	page.Simulations[0].Name = "Verändert"
+	if current, _ := engine.GetSimulation(sim.ID); current.Name != "Abweichend" || current.Status != StatusRunning {
+		t.Fatalf("Die Simulation der Engine wurde verändert: %s (%s)", current.Name, current.Status)
+	}
+}
@@ -3,6 +3,7 @@ package simulation
 
 import (
 	"fmt"
+	"hash/fnv"
 	"math/rand"
 	"time"
 
@@ -11,6 +12,19 @@ import (
 
 // GenerateMockInfrastructure erzeugt eine Mock-Infrastruktur für Demonstrationszwecke
 func GenerateMockInfrastructure() map[string]interface{} {
+	return generateMockInfrastructure(rand.New(rand.NewSource(rand.Int63())))
+}
+
+// MockInfrastructureFor erzeugt die Mock-Infrastruktur zu einer Infrastruktur-ID.
+// Dieselbe ID ergibt immer dasselbe Netzwerk, sodass API und Engine denselben Graphen sehen.
+func MockInfrastructureFor(infrastructureID string) map[string]interface{} {
+	hash := fnv.New64a()
+	hash.Write([]byte(infrastructureID))
+	return generateMockInfrastructure(rand.New(rand.NewSource(int64(hash.Sum64()))))
+}
+
+// generateMockInfrastructure erzeugt eine Mock-Infrastruktur aus der angegebenen Zufallsquelle
+func generateMockInfrastructure(rng *rand.Rand) map[string]interface{} {
 	// Mock-Netzwerkdefinition
 	var nodes []map[string]interface{}
 	var connections []map[string]interface{}
@@ -80,14 +94,14 @@ func GenerateMockInfrastructure() map[string]interface{} {
 	// Verbindungen zwischen Routern und Servern
 	for i := 0; i < routerCount; i++ {
 		for j := 0; j < serverCount; j++ {
-			if rand.Float64() < 0.7 { // 70% Chance für eine Verbindung
+			if rng.Float64() < 0.7 { // 70% Chance für eine Verbindung
 				status := "normal"
 				if j == 2 {
 					status = "warning"
 				}
 				
 				connection := map[string]interface{}{
-					"id":       uuid.New().String()[0:8],
+					"id":       fmt.Sprintf("%08x", rng.Uint32()),
 					"source":   fmt.Sprintf("router-%d", i+1),
 					"target":   fmt.Sprintf("server-%d", j+1),
 					"status":   status,
@@ -102,14 +116,14 @@ func GenerateMockInfrastructure() map[string]interface{} {
 	// Verbindungen zwischen Routern und Workstations
 	for i := 0; i < routerCount; i++ {
 		for j := 0; j < workstationCount; j++ {
-			if rand.Float64() < 0.5 { // 50% Chance für eine Verbindung
+			if rng.Float64() < 0.5 { // 50% Chance für eine Verbindung
 				status := "normal"
 				if j == 3 {
 					status = "critical"
 				}
 				
 				connection := map[string]interface{}{
-					"id":       uuid.New().String()[0:8],
+					"id":       fmt.Sprintf("%08x", rng.Uint32()),
 					"source":   fmt.Sprintf("router-%d", i+1),
 					"target":   fmt.Sprintf("workstation-%d", j+1),
 					"status":   status,
@@ -124,9 +138,9 @@ func GenerateMockInfrastructure() map[string]interface{} {
 	// Verbindungen zwischen Servern
 	for i := 0; i < serverCount; i++ {
 		for j := i + 1; j < serverCount; j++ {
-			if rand.Float64() < 0.3 { // 30% Chance für eine Verbindung
+			if rng.Float64() < 0.3 { // 30% Chance für eine Verbindung
 				connection := map[string]interface{}{
-					"id":       uuid.New().String()[0:8],
+					"id":       fmt.Sprintf("%08x", rng.Uint32()),
 					"source":   fmt.Sprintf("server-%d", i+1),
 					"target":   fmt.Sprintf("server-%d", j+1),
 					"status":   "normal",
@@ -144,103 +158,160 @@ func GenerateMockInfrastructure() map[string]interface{} {
 	}
 }
 
-// GenerateMockSimulationScenarios erzeugt Mock-Simulationsszenarien
-func GenerateMockSimulationScenarios() []map[string]interface{} {
-	return []map[string]interface{}{
+// builtInScenarios erzeugt die mitgelieferten Szenarien. Jeder Schritt legt fest,
+// welche Eventtypen und Schweregrade er erzeugt, mit welcher Wahrscheinlichkeit eine
+// Aktion gelingt und wie lange er dauert.
+func builtInScenarios() []*Scenario {
+	scenarios := []*Scenario{
 		{
-			"id":          "scenario-1",
-			"name":        "Basic Penetration Test",
-			"description": "Ein grundlegender Penetrationstest, der Reconnaissance, Scanning und Exploitation umfasst",
-			"difficulty":  "easy",
-			"duration":    3600, // 1 Stunde in Sekunden
-			"steps": []map[string]interface{}{
+			ID:          "scenario-1",
+			Name:        "Basic Penetration Test",
+			Description: "Ein grundlegender Penetrationstest, der Reconnaissance, Scanning und Exploitation umfasst",
+			Difficulty:  DifficultyEasy,
+			Steps: []ScenarioStep{
 				{
-					"id":          1,
-					"name":        "Reconnaissance",
-					"description": "Informationen über das Ziel sammeln",
-					"duration":    900, // 15 Minuten
+					Name:               "Reconnaissance",
+					Description:        "Informationen über das Ziel sammeln",
+					Duration:           900, // 15 Minuten
+					EventTypes:         []EventType{EventTypeDiscovery},
+					Severities:         []Severity{SeverityInfo, SeverityLow},
+					SuccessProbability: 0.9,
 				},
 				{
-					"id":          2,
-					"name":        "Scanning",
-					"description": "Scannen nach offenen Ports und Diensten",
-					"duration":    1200, // 20 Minuten
+					Name:               "Scanning",
+					Description:        "Scannen nach offenen Ports und Diensten",
+					Duration:           1200, // 20 Minuten
+					EventTypes:         []EventType{EventTypeDiscovery},
+					Severities:         []Severity{SeverityLow, SeverityMedium},
+					SuccessProbability: 0.8,
+					Impact:             ResourceStatusVulnerable,
 				},
 				{
-					"id":          3,
-					"name":        "Exploitation",
-					"description": "Ausnutzen von Schwachstellen",
-					"duration":    1500, // 25 Minuten
+					Name:               "Exploitation",
+					Description:        "Ausnutzen von Schwachstellen",
+					Duration:           1500, // 25 Minuten
+					EventTypes:         []EventType{EventTypeExploitation, EventTypeEscalation},
+					Severities:         []Severity{SeverityMedium, SeverityHigh},
+					SuccessProbability: 0.5,
 				},
 			},
 		},
 		{
-			"id":          "scenario-2",
-			"name":        "Advanced Ransomware Simulation",
-			"description": "Eine fortgeschrittene Ransomware-Angriffssimulation",
-			"difficulty":  "hard",
-			"duration":    7200, // 2 Stunden in Sekunden
-			"steps": []map[string]interface{}{
+			ID:          "scenario-2",
+			Name:        "Advanced Ransomware Simulation",
+			Description: "Eine fortgeschrittene Ransomware-Angriffssimulation",
+			Difficulty:  DifficultyHard,
+			Steps: []ScenarioStep{
 				{
-					"id":          1,
-					"name":        "Initial Access",
-					"description": "Zugang über Phishing erlangen",
-					"duration":    1200, // 20 Minuten
+					Name:               "Initial Access",
+					Description:        "Zugang über Phishing erlangen",
+					Duration:           1200, // 20 Minuten
+					EventTypes:         []EventType{EventTypeExploitation},
+					Severities:         []Severity{SeverityMedium, SeverityHigh},
+					SuccessProbability: 0.6,
 				},
 				{
-					"id":          2,
-					"name":        "Privilege Escalation",
-					"description": "Rechte erhöhen",
-					"duration":    1800, // 30 Minuten
+					Name:               "Privilege Escalation",
+					Description:        "Rechte erhöhen",
+					Duration:           1800, // 30 Minuten
+					EventTypes:         []EventType{EventTypeEscalation},
+					Severities:         []Severity{SeverityHigh, SeverityCritical},
+					SuccessProbability: 0.5,
 				},
 				{
-					"id":          3,
-					"name":        "Lateral Movement",
-					"description": "Lateral durch das Netzwerk bewegen",
-					"duration":    1500, // 25 Minuten
+					Name:               "Lateral Movement",
+					Description:        "Lateral durch das Netzwerk bewegen",
+					Duration:           1500, // 25 Minuten
+					EventTypes:         []EventType{EventTypeLateralMovement},
+					Severities:         []Severity{SeverityHigh},
+					SuccessProbability: 0.6,
 				},
 				{
-					"id":          4,
-					"name":        "Data Exfiltration",
-					"description": "Sensible Daten extrahieren",
-					"duration":    1500, // 25 Minuten
+					Name:               "Data Exfiltration",
+					Description:        "Sensible Daten extrahieren",
+					Duration:           1500, // 25 Minuten
+					EventTypes:         []EventType{EventTypeDataExfiltration},
+					Severities:         []Severity{SeverityHigh, SeverityCritical},
+					SuccessProbability: 0.5,
 				},
 				{
-					"id":          5,
-					"name":        "Encryption",
-					"description": "Dateien verschlüsseln und Lösegeld fordern",
-					"duration":    1200, // 20 Minuten
+					Name:               "Encryption",
+					Description:        "Dateien verschlüsseln und Lösegeld fordern",
+					Duration:           1200, // 20 Minuten
+					EventTypes:         []EventType{EventTypeExploitation},
+					Severities:         []Severity{SeverityCritical},
+					SuccessProbability: 0.7,
+					Impact:             ResourceStatusCompromised,
+					Actions: []string{
+						"Dateien auf Netzlaufwerk verschlüsselt",
+						"Schattenkopien gelöscht",
+						"Lösegeldforderung hinterlegt",
+						"Backup-Dienst deaktiviert",
+					},
 				},
 			},
 		},
 		{
-			"id":          "scenario-3",
-			"name":        "Compliance Check",
-			"description": "Überprüfung der Einhaltung von Sicherheitsrichtlinien",
-			"difficulty":  "medium",
-			"duration":    4500, // 1:15 Stunden in Sekunden
-			"steps": []map[string]interface{}{
+			ID:          "scenario-3",
+			Name:        "Compliance Check",
+			Description: "Überprüfung der Einhaltung von Sicherheitsrichtlinien",
+			Difficulty:  DifficultyMedium,
+			Steps: []ScenarioStep{
 				{
-					"id":          1,
-					"name":        "Configuration Audit",
-					"description": "Überprüfung der Konfigurationseinstellungen",
-					"duration":    1800, // 30 Minuten
+					Name:               "Configuration Audit",
+					Description:        "Überprüfung der Konfigurationseinstellungen",
+					Duration:           1800, // 30 Minuten
+					EventTypes:         []EventType{EventTypeDiscovery},
+					Severities:         []Severity{SeverityInfo, SeverityLow, SeverityMedium},
+					SuccessProbability: 0.35,
+					Impact:             ResourceStatusVulnerable,
+					Actions: []string{
+						"Unverschlüsselter Dienst gefunden",
+						"Veraltete TLS-Version aktiviert",
+						"Standardkonfiguration nicht gehärtet",
+						"Protokollierung deaktiviert",
+					},
 				},
 				{
-					"id":          2,
-					"name":        "Access Control Validation",
-					"description": "Validierung der Zugriffskontrollen",
-					"duration":    1500, // 25 Minuten
+					Name:               "Access Control Validation",
+					Description:        "Validierung der Zugriffskontrollen",
+					Duration:           1500, // 25 Minuten
+					EventTypes:         []EventType{EventTypeDiscovery},
+					Severities:         []Severity{SeverityLow, SeverityMedium},
+					SuccessProbability: 0.3,
+					Impact:             ResourceStatusVulnerable,
+					Actions: []string{
+						"Verwaistes Benutzerkonto gefunden",
+						"Administratorrechte ohne MFA",
+						"Gemeinsam genutztes Dienstkonto entdeckt",
+						"Zu weit gefasste Dateifreigabe",
+					},
 				},
 				{
-					"id":          3,
-					"name":        "Policy Compliance",
-					"description": "Überprüfung der Einhaltung von Unternehmensrichtlinien",
-					"duration":    1200, // 20 Minuten
+					Name:               "Policy Compliance",
+					Description:        "Überprüfung der Einhaltung von Unternehmensrichtlinien",
+					Duration:           1200, // 20 Minuten
+					EventTypes:         []EventType{EventTypeDiscovery},
+					Severities:         []Severity{SeverityInfo, SeverityLow},
+					SuccessProbability: 0.25,
+					Impact:             ResourceStatusVulnerable,
+					Actions: []string{
+						"Passwortrichtlinie geprüft",
+						"Patch-Stand mit Richtlinie abgeglichen",
+						"Aufbewahrungsfristen für Logs geprüft",
+						"Verschlüsselung ruhender Daten geprüft",
+					},
 				},
 			},
 		},
 	}
+
+	// Schritt-IDs und Gesamtdauer ergeben sich aus den Schritten
+	for _, scenario := range scenarios {
+		scenario.BuiltIn = true
+		scenario.normalize()
+	}
+	return scenarios
 }
 
 // GenerateMockSimulationResults erzeugt Ergebnisse für eine simulierte Simulation
@@ -10,6 +10,7 @@ type Status string
 
 const (
 	StatusNotStarted Status = "not_started"
+	StatusQueued     Status = "queued" // wartet auf einen freien Worker
 	StatusRunning    Status = "running"
 	StatusPaused     Status = "paused"
 	StatusCompleted  Status = "completed"
@@ -27,6 +28,7 @@ const (
 	EventTypeLateralMovement EventType = "lateral_movement"
 	EventTypeDataExfiltration EventType = "data_exfiltration"
 	EventTypeSystem         EventType = "system"
+	EventTypeDefense        EventType = "defense" // Eingriff des Blue Teams
 )
 
 // Severity repräsentiert den Schweregrad eines Ereignisses
@@ -40,6 +42,15 @@ const (
 	SeverityCritical Severity = "critical"
 )
 
+// EventOutcome beschreibt den Ausgang einer Angriffsaktion
+type EventOutcome string
+
+const (
+	OutcomeSucceeded EventOutcome = "succeeded"
+	OutcomeFailed    EventOutcome = "failed"
+	OutcomeBlocked   EventOutcome = "blocked"
+)
+
 // ResourceStatus repräsentiert den Status einer betroffenen Ressource
 type ResourceStatus string
 
@@ -50,19 +61,43 @@ const (
 	ResourceStatusCompromised  ResourceStatus = "compromised"
 )
 
+// rank ordnet die Ressourcenstatus nach Schwere, damit ein Status nie herabgestuft wird
+func (s ResourceStatus) rank() int {
+	switch s {
+	case ResourceStatusVulnerable:
+		return 1
+	case ResourceStatusAttacked:
+		return 2
+	case ResourceStatusCompromised:
+		return 3
+	default:
+		return 0
+	}
+}
+
 // Simulation repräsentiert eine Sicherheitssimulation
 type Simulation struct {
 	ID              string      `json:"id"`
 	Name            string      `json:"name"`
 	Description     string      `json:"description"`
 	Status          Status      `json:"status"`
+	QueuePosition   int         `json:"queuePosition,omitempty"` // Position in der Warteschlange, solange Status "queued" ist
 	StartTime       *time.Time  `json:"startTime,omitempty"`
 	EndTime         *time.Time  `json:"endTime,omitempty"`
 	InfrastructureID string     `json:"infrastructureId"`
 	ScenarioID      string      `json:"scenarioId"`
+	Parameters      map[string]interface{} `json:"parameters,omitempty"`
+	Seed            int64       `json:"seed"`
 	Progress        float64     `json:"progress"`
 	ThreatsDetected int         `json:"threatsDetected"`
-	Results         interface{} `json:"results,omitempty"`
+	Results         *SimulationResults `json:"results,omitempty"` // Abschlussbericht, sobald die Simulation beendet ist
+	Error           string      `json:"error,omitempty"` // Grund, wenn Status "failed" ist
+	PausedAt        *time.Time    `json:"pausedAt,omitempty"`
+	PausedDuration  time.Duration `json:"-"` // Summe aller abgeschlossenen Pausen
+	ArchivedAt      *time.Time    `json:"archivedAt,omitempty"` // archivierte Simulationen fehlen in der Standardliste
+	RunID           string        `json:"runId,omitempty"`      // aktueller Lauf; leer, solange die Simulation nie gestartet wurde
+	RunNumber       int           `json:"runNumber,omitempty"`  // Nummer des aktuellen Laufs, ab 1
+	ClonedFrom      string        `json:"clonedFrom,omitempty"` // Simulation, deren Konfiguration kopiert wurde
 	CreatedAt       time.Time   `json:"createdAt"`
 	UpdatedAt       time.Time   `json:"updatedAt"`
 }
@@ -71,11 +106,18 @@ type Simulation struct {
 type SimulationEvent struct {
 	ID            string     `json:"id"`
 	SimulationID  string     `json:"simulationId"`
+	RunID         string     `json:"runId,omitempty"`
+	Sequence      int64      `json:"sequence"` // fortlaufende Nummer innerhalb des Laufs, ab 1
 	Timestamp     time.Time  `json:"timestamp"`
 	Type          EventType  `json:"type"`
 	Description   string     `json:"description"`
 	ResourceID    string     `json:"resourceId,omitempty"`
 	Severity      Severity   `json:"severity"`
+	Phase         string     `json:"phase,omitempty"`
+	Outcome       EventOutcome `json:"outcome,omitempty"` // nur bei Angriffsaktionen gesetzt
+	Detected      bool       `json:"detected"`
+	Blocked       bool       `json:"blocked"`
+	SimulatedSeconds float64 `json:"simulatedSeconds"` // simulierte Zeit seit dem Start
 	Details       interface{} `json:"details,omitempty"`
 }
 
@@ -83,6 +125,7 @@ type SimulationEvent struct {
 type AffectedResource struct {
 	ID              string          `json:"id"`
 	SimulationID    string          `json:"simulationId"`
+	RunID           string          `json:"runId,omitempty"`
 	Name            string          `json:"name"`
 	Type            string          `json:"type"`
 	Status          ResourceStatus  `json:"status"`
@@ -104,8 +147,15 @@ type SimulationConfig struct {
 type SimulationStatus struct {
 	ID                  string  `json:"id"`
 	Status              Status  `json:"status"`
+	QueuePosition       int     `json:"queuePosition,omitempty"`
+	Error               string  `json:"error,omitempty"`
 	Runtime             string  `json:"runtime"`
 	ThreatsDetected     int     `json:"threatsDetected"`
 	CompromisedResources int    `json:"compromisedResources"`
 	Progress            float64 `json:"progress"`
+	AttackSteps         int     `json:"attackSteps"`
+	DetectedSteps       int     `json:"detectedSteps"`
+	BlockedSteps        int     `json:"blockedSteps"`
+	DetectionRate       float64 `json:"detectionRate"`
+	TimeToDetect        string  `json:"timeToDetect,omitempty"` // simulierte Zeit von der ersten Aktion bis zur ersten Erkennung
 }
\ No newline at end of file
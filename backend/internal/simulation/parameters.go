@@ -0,0 +1,141 @@
+// backend/internal/simulation/parameters.go
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bekannte Schlüssel in SimulationConfig.Parameters
+const (
+	// ParameterSpeed legt den Zeitraffer-Faktor fest: 1, 10, 100 oder "instant"
+	ParameterSpeed = "speed"
+	// ParameterSeed legt den Startwert des Zufallsgenerators fest
+	ParameterSeed = "seed"
+	// ParameterTimeout überschreibt die maximale Laufzeit in Sekunden
+	ParameterTimeout = "timeoutSeconds"
+)
+
+// SpeedInstant kennzeichnet eine Simulation, die ohne Wartezeit durchläuft
+const SpeedInstant = "instant"
+
+// baseTickInterval ist die simulierte Zeit, die pro Update der Engine vergeht
+const baseTickInterval = 10 * time.Second
+
+// allowedSpeeds enthält die unterstützten Zeitraffer-Faktoren
+var allowedSpeeds = []float64{1, 10, 100}
+
+// ErrInvalidParameter wird zurückgegeben, wenn ein Simulationsparameter ungültig ist
+var ErrInvalidParameter = errors.New("ungültiger Simulationsparameter")
+
+// parseSpeed liest den Zeitraffer-Faktor aus den Parametern.
+// Ein Faktor von 0 steht für "instant".
+func parseSpeed(parameters map[string]interface{}) (float64, error) {
+	value, exists := parameters[ParameterSpeed]
+	if !exists || value == nil {
+		return 1, nil
+	}
+
+	var factor float64
+	switch v := value.(type) {
+	case float64:
+		factor = v
+	case int:
+		factor = float64(v)
+	case string:
+		text := strings.ToLower(strings.TrimSpace(v))
+		if text == SpeedInstant {
+			return 0, nil
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSuffix(text, "x"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s muss 1, 10, 100 oder \"%s\" sein", ErrInvalidParameter, ParameterSpeed, SpeedInstant)
+		}
+		factor = parsed
+	default:
+		return 0, fmt.Errorf("%w: %s hat einen ungültigen Typ", ErrInvalidParameter, ParameterSpeed)
+	}
+
+	for _, allowed := range allowedSpeeds {
+		if factor == allowed {
+			return factor, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s muss 1, 10, 100 oder \"%s\" sein", ErrInvalidParameter, ParameterSpeed, SpeedInstant)
+}
+
+// tickInterval berechnet das reale Intervall zwischen zwei Updates für einen Zeitraffer-Faktor
+func tickInterval(speed float64) time.Duration {
+	if speed <= 0 {
+		return 0
+	}
+	return time.Duration(float64(baseTickInterval) / speed)
+}
+
+// maxGeneratedSeed hält erzeugte Seeds im Bereich, den JSON-Clients verlustfrei darstellen können
+const maxGeneratedSeed = 1 << 53
+
+// parseSeed liest den Startwert des Zufallsgenerators aus den Parametern.
+// Ist kein Startwert angegeben, wird ein neuer erzeugt.
+func parseSeed(parameters map[string]interface{}) (int64, error) {
+	value, exists := parameters[ParameterSeed]
+	if !exists || value == nil {
+		return rand.Int63n(maxGeneratedSeed), nil
+	}
+
+	switch v := value.(type) {
+	case float64:
+		// JSON-Zahlen kommen als float64 an und müssen ganzzahlig sein
+		if v != math.Trunc(v) || math.Abs(v) > maxGeneratedSeed {
+			return 0, fmt.Errorf("%w: %s muss eine ganze Zahl sein", ErrInvalidParameter, ParameterSeed)
+		}
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case string:
+		seed, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s muss eine ganze Zahl sein", ErrInvalidParameter, ParameterSeed)
+		}
+		return seed, nil
+	default:
+		return 0, fmt.Errorf("%w: %s hat einen ungültigen Typ", ErrInvalidParameter, ParameterSeed)
+	}
+}
+
+// parseTimeout liest die maximale Laufzeit aus den Parametern.
+// Ohne Angabe wird 0 zurückgegeben und die Standardlaufzeit der Engine verwendet.
+func parseTimeout(parameters map[string]interface{}) (time.Duration, error) {
+	value, exists := parameters[ParameterTimeout]
+	if !exists || value == nil {
+		return 0, nil
+	}
+
+	var seconds float64
+	switch v := value.(type) {
+	case float64:
+		seconds = v
+	case int:
+		seconds = float64(v)
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s muss eine Anzahl Sekunden sein", ErrInvalidParameter, ParameterTimeout)
+		}
+		seconds = parsed
+	default:
+		return 0, fmt.Errorf("%w: %s hat einen ungültigen Typ", ErrInvalidParameter, ParameterTimeout)
+	}
+
+	if seconds <= 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return 0, fmt.Errorf("%w: %s muss größer als 0 sein", ErrInvalidParameter, ParameterTimeout)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -5,73 +5,117 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
 )
 
-// Repository ist die Datenzugriffsschicht für Simulationen
+// sqlDialect unterscheidet die SQL-Varianten der unterstützten Datenbanken
+type sqlDialect int
+
+const (
+	dialectPostgres sqlDialect = iota
+	dialectSQLite
+)
+
+// placeholderPattern findet Platzhalter im Postgres-Stil ($1, $2, ...)
+var placeholderPattern = regexp.MustCompile(`\$(\d+)`)
+
+// Repository ist die Datenzugriffsschicht für Simulationen. Die Abfragen sind im
+// Postgres-Stil geschrieben und werden für SQLite umgeschrieben.
 type Repository struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect sqlDialect
 }
 
-// NewRepository erstellt ein neues Repository
+// NewRepository erstellt ein neues Repository auf einer Postgres-Datenbank
 func NewRepository(db *sql.DB) *Repository {
 	return &Repository{
-		db: db,
+		db:      db,
+		dialect: dialectPostgres,
 	}
 }
 
-// SaveSimulation speichert eine Simulation in der Datenbank
-func (r *Repository) SaveSimulation(sim *Simulation) error {
-	// Konvertiere results zu JSON
-	var resultsJSON []byte
-	var err error
-	if sim.Results != nil {
-		resultsJSON, err = json.Marshal(sim.Results)
-		if err != nil {
-			return fmt.Errorf("Fehler beim Serialisieren der Ergebnisse: %v", err)
+// rebind passt die Platzhalter einer Abfrage an die Datenbank an. SQLite kennt
+// nummerierte Platzhalter als ?1, ?2, ...
+func (r *Repository) rebind(query string) string {
+	if r.dialect == dialectSQLite {
+		return placeholderPattern.ReplaceAllString(query, "?$1")
+	}
+	return query
+}
+
+func (r *Repository) exec(query string, args ...interface{}) (sql.Result, error) {
+	return r.db.Exec(r.rebind(query), utcArgs(args)...)
+}
+
+func (r *Repository) query(query string, args ...interface{}) (*sql.Rows, error) {
+	return r.db.Query(r.rebind(query), utcArgs(args)...)
+}
+
+func (r *Repository) queryRow(query string, args ...interface{}) *sql.Row {
+	return r.db.QueryRow(r.rebind(query), utcArgs(args)...)
+}
+
+// utcArgs rechnet alle Zeitpunkte unter den Argumenten in UTC um. SQLite speichert
+// Zeitpunkte als Text mit dem Offset des Werts und vergleicht sie als Text; nur mit
+// einheitlichem Offset ergeben Vergleiche wie timestamp >= $1 die richtige Reihenfolge.
+func utcArgs(args []interface{}) []interface{} {
+	converted := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch value := arg.(type) {
+		case time.Time:
+			arg = value.UTC()
+		case *time.Time:
+			if value != nil {
+				utc := value.UTC()
+				arg = &utc
+			}
 		}
+		converted[i] = arg
 	}
+	return converted
+}
 
-	// Prüfe, ob die Simulation bereits existiert
-	var exists bool
-	err = r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM simulations WHERE id = $1)", sim.ID).Scan(&exists)
+// SaveSimulation speichert eine Simulation in der Datenbank
+func (r *Repository) SaveSimulation(sim *Simulation) error {
+	// Konvertiere Ergebnisse und Parameter zu JSON
+	resultsJSON, err := jsonColumn(sim.Results)
 	if err != nil {
-		return fmt.Errorf("Fehler beim Prüfen der Existenz der Simulation: %v", err)
+		return fmt.Errorf("Fehler beim Serialisieren der Ergebnisse: %v", err)
 	}
-
-	if exists {
-		// Update vorhandene Simulation
-		query := `
-			UPDATE simulations
-			SET name = $1, description = $2, status = $3, start_time = $4, end_time = $5,
-				infrastructure_id = $6, scenario_id = $7, progress = $8, threats_detected = $9,
-				results_json = $10, updated_at = $11
-			WHERE id = $12
-		`
-		_, err = r.db.Exec(
-			query,
-			sim.Name, sim.Description, string(sim.Status), sim.StartTime, sim.EndTime,
-			sim.InfrastructureID, sim.ScenarioID, sim.Progress, sim.ThreatsDetected,
-			resultsJSON, time.Now(), sim.ID,
-		)
-	} else {
-		// Neue Simulation einfügen
-		query := `
-			INSERT INTO simulations
-			(id, name, description, status, start_time, end_time, infrastructure_id,
-			scenario_id, progress, threats_detected, results_json, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-		`
-		_, err = r.db.Exec(
-			query,
-			sim.ID, sim.Name, sim.Description, string(sim.Status), sim.StartTime, sim.EndTime,
-			sim.InfrastructureID, sim.ScenarioID, sim.Progress, sim.ThreatsDetected,
-			resultsJSON, sim.CreatedAt, sim.UpdatedAt,
-		)
+	parametersJSON, err := jsonColumn(sim.Parameters)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Serialisieren der Parameter: %v", err)
 	}
 
+	// Neue Simulation einfügen oder vorhandene aktualisieren; created_at bleibt erhalten
+	query := `
+		INSERT INTO simulations (` + simulationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+		$20, $21, $22)
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description, status = EXCLUDED.status,
+			start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time,
+			infrastructure_id = EXCLUDED.infrastructure_id, scenario_id = EXCLUDED.scenario_id,
+			progress = EXCLUDED.progress, threats_detected = EXCLUDED.threats_detected,
+			results_json = EXCLUDED.results_json, parameters_json = EXCLUDED.parameters_json,
+			seed = EXCLUDED.seed, error = EXCLUDED.error, paused_at = EXCLUDED.paused_at,
+			paused_duration_ms = EXCLUDED.paused_duration_ms, archived_at = EXCLUDED.archived_at,
+			run_id = EXCLUDED.run_id, run_number = EXCLUDED.run_number,
+			cloned_from = EXCLUDED.cloned_from, updated_at = EXCLUDED.updated_at
+	`
+	_, err = r.exec(
+		query,
+		sim.ID, sim.Name, sim.Description, string(sim.Status), sim.StartTime, sim.EndTime,
+		sim.InfrastructureID, sim.ScenarioID, sim.Progress, sim.ThreatsDetected,
+		resultsJSON, parametersJSON, sim.Seed, sim.Error,
+		sim.PausedAt, sim.PausedDuration.Milliseconds(), sim.ArchivedAt, sim.RunID, sim.RunNumber,
+		sim.ClonedFrom, sim.CreatedAt, sim.UpdatedAt,
+	)
 	if err != nil {
 		return fmt.Errorf("Fehler beim Speichern der Simulation: %v", err)
 	}
@@ -79,35 +123,40 @@ func (r *Repository) SaveSimulation(sim *Simulation) error {
 	return nil
 }
 
-// GetSimulation lädt eine Simulation aus der Datenbank
-func (r *Repository) GetSimulation(id string) (*Simulation, error) {
-	query := `
-		SELECT id, name, description, status, start_time, end_time, infrastructure_id,
-			   scenario_id, progress, threats_detected, results_json, created_at, updated_at
-		FROM simulations
-		WHERE id = $1
-	`
-	
+// simulationColumns sind die Spalten, die scanSimulation erwartet
+const simulationColumns = `id, name, description, status, start_time, end_time, infrastructure_id,
+	scenario_id, progress, threats_detected, results_json, parameters_json, seed, error,
+	paused_at, paused_duration_ms, archived_at, run_id, run_number, cloned_from, created_at, updated_at`
+
+// rowScanner ist das gemeinsame Interface von *sql.Row und *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanSimulation liest eine Zeile mit den Spalten aus simulationColumns
+func scanSimulation(row rowScanner) (*Simulation, error) {
 	var sim Simulation
 	var status string
-	var resultsJSON []byte
-	var startTime, endTime sql.NullTime
-	
-	err := r.db.QueryRow(query, id).Scan(
+	var resultsJSON, parametersJSON []byte
+	var startTime, endTime, pausedAt, archivedAt sql.NullTime
+	var errorText sql.NullString
+	var pausedMillis int64
+
+	err := row.Scan(
 		&sim.ID, &sim.Name, &sim.Description, &status, &startTime, &endTime,
 		&sim.InfrastructureID, &sim.ScenarioID, &sim.Progress, &sim.ThreatsDetected,
-		&resultsJSON, &sim.CreatedAt, &sim.UpdatedAt,
+		&resultsJSON, &parametersJSON, &sim.Seed, &errorText,
+		&pausedAt, &pausedMillis, &archivedAt, &sim.RunID, &sim.RunNumber, &sim.ClonedFrom,
+		&sim.CreatedAt, &sim.UpdatedAt,
 	)
-	
-	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("Simulation nicht gefunden")
-	} else if err != nil {
-		return nil, fmt.Errorf("Fehler beim Laden der Simulation: %v", err)
+	if err != nil {
+		return nil, err
 	}
-	
+
 	// Status konvertieren
 	sim.Status = Status(status)
-	
+	sim.PausedDuration = time.Duration(pausedMillis) * time.Millisecond
+
 	// Nullable Felder handhaben
 	if startTime.Valid {
 		sim.StartTime = &startTime.Time
@@ -115,157 +164,530 @@ func (r *Repository) GetSimulation(id string) (*Simulation, error) {
 	if endTime.Valid {
 		sim.EndTime = &endTime.Time
 	}
-	
-	// Ergebnisse deserialisieren, falls vorhanden
+	if pausedAt.Valid {
+		sim.PausedAt = &pausedAt.Time
+	}
+	if archivedAt.Valid {
+		sim.ArchivedAt = &archivedAt.Time
+	}
+	if errorText.Valid {
+		sim.Error = errorText.String
+	}
+
+	// Ergebnisse und Parameter deserialisieren, falls vorhanden
 	if len(resultsJSON) > 0 {
-		var results map[string]interface{}
+		var results SimulationResults
 		if err := json.Unmarshal(resultsJSON, &results); err != nil {
 			logging.Logger.Warnf("Fehler beim Deserialisieren der Ergebnisse: %v", err)
 		} else {
-			sim.Results = results
+			sim.Results = &results
 		}
 	}
-	
+	if len(parametersJSON) > 0 {
+		if err := json.Unmarshal(parametersJSON, &sim.Parameters); err != nil {
+			logging.Logger.Warnf("Fehler beim Deserialisieren der Parameter: %v", err)
+		}
+	}
+
 	return &sim, nil
 }
 
+// GetSimulation lädt eine Simulation aus der Datenbank
+func (r *Repository) GetSimulation(id string) (*Simulation, error) {
+	query := "SELECT " + simulationColumns + " FROM simulations WHERE id = $1"
+
+	sim, err := scanSimulation(r.queryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, id)
+	} else if err != nil {
+		return nil, fmt.Errorf("Fehler beim Laden der Simulation: %v", err)
+	}
+
+	return sim, nil
+}
+
 // GetAllSimulations lädt alle Simulationen aus der Datenbank
 func (r *Repository) GetAllSimulations() ([]*Simulation, error) {
-	query := `
-		SELECT id, name, description, status, start_time, end_time, infrastructure_id,
-			   scenario_id, progress, threats_detected, results_json, created_at, updated_at
-		FROM simulations
-		ORDER BY created_at DESC
-	`
-	
-	rows, err := r.db.Query(query)
+	query := "SELECT " + simulationColumns + " FROM simulations ORDER BY created_at DESC"
+
+	rows, err := r.query(query)
 	if err != nil {
 		return nil, fmt.Errorf("Fehler beim Laden der Simulationen: %v", err)
 	}
 	defer rows.Close()
-	
+
 	var simulations []*Simulation
-	
+
+	for rows.Next() {
+		sim, err := scanSimulation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Fehler beim Scannen der Simulation: %v", err)
+		}
+		simulations = append(simulations, sim)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Fehler beim Iterieren über Simulationen: %v", err)
+	}
+
+	return simulations, nil
+}
+
+// QuerySimulations lädt eine Seite der passenden Simulationen und zählt alle passenden.
+// Filter, Sortierung, Cursor und Begrenzung werden in der Datenbank ausgewertet.
+func (r *Repository) QuerySimulations(query SimulationQuery) ([]*Simulation, int, error) {
+	field := query.sortField()
+	column, ok := sortColumns[field]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: Sortierung nach %q nicht möglich", ErrInvalidParameter, query.Sort)
+	}
+
+	var conditions []string
+	var args []interface{}
+	addCondition := func(format string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf(format, placeholders...))
+	}
+
+	if len(query.Statuses) > 0 {
+		placeholders := make([]string, len(query.Statuses))
+		for i, status := range query.Statuses {
+			args = append(args, string(status))
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf("status IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if query.ScenarioID != "" {
+		addCondition("scenario_id = %s", query.ScenarioID)
+	}
+	if query.InfrastructureID != "" {
+		addCondition("infrastructure_id = %s", query.InfrastructureID)
+	}
+	if query.CreatedAfter != nil {
+		addCondition("created_at >= %s", *query.CreatedAfter)
+	}
+	if query.CreatedBefore != nil {
+		addCondition("created_at < %s", *query.CreatedBefore)
+	}
+	if query.Archived != nil {
+		if *query.Archived {
+			conditions = append(conditions, "archived_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "archived_at IS NULL")
+		}
+	}
+	if query.Search != "" {
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(query.Search)) + "%"
+		addCondition(`LOWER(name) LIKE %s ESCAPE '\'`, pattern)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.queryRow("SELECT COUNT(*) FROM simulations"+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("Fehler beim Zählen der Simulationen: %v", err)
+	}
+
+	// Der Cursor setzt hinter der letzten Simulation der vorherigen Seite fort
+	if query.Cursor != "" {
+		cursor, err := decodeListCursor(query.Cursor, field)
+		if err != nil {
+			return nil, 0, err
+		}
+		var value interface{} = cursor.Value
+		if field == SortCreatedAt || field == SortUpdatedAt {
+			value, _ = time.Parse(time.RFC3339Nano, cursor.Value)
+		}
+		comparison := ">"
+		if query.Descending {
+			comparison = "<"
+		}
+		addCondition(fmt.Sprintf("(%[1]s %[2]s %%s OR (%[1]s = %%s AND id %[2]s %%s))", column, comparison), value, value, cursor.ID)
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	order := "ASC"
+	if query.Descending {
+		order = "DESC"
+	}
+	statement := fmt.Sprintf("SELECT %s FROM simulations%s ORDER BY %s %s, id %s", simulationColumns, where, column, order, order)
+	if query.Limit > 0 {
+		args = append(args, query.Limit)
+		statement += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if query.Offset > 0 {
+		// SQLite kennt OFFSET nur zusammen mit LIMIT
+		if query.Limit <= 0 && r.dialect == dialectSQLite {
+			statement += " LIMIT -1"
+		}
+		args = append(args, query.Offset)
+		statement += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := r.query(statement, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Fehler beim Laden der Simulationen: %v", err)
+	}
+	defer rows.Close()
+
+	simulations := []*Simulation{}
+	for rows.Next() {
+		sim, err := scanSimulation(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Fehler beim Scannen der Simulation: %v", err)
+		}
+		simulations = append(simulations, sim)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("Fehler beim Iterieren über Simulationen: %v", err)
+	}
+
+	return simulations, total, nil
+}
+
+// DeleteSimulation löscht eine Simulation; Events, Ressourcen und Checkpoint werden
+// über ihre Fremdschlüssel mitgelöscht
+func (r *Repository) DeleteSimulation(id string) error {
+	if _, err := r.exec("DELETE FROM simulations WHERE id = $1", id); err != nil {
+		return fmt.Errorf("Fehler beim Löschen der Simulation: %v", err)
+	}
+	return nil
+}
+
+// runEndedCondition wählt die Läufe aus, die vor dem Zeitpunkt in $1 beendet wurden
+var runEndedCondition = fmt.Sprintf("status IN ('%s', '%s', '%s') AND end_time < $1",
+	StatusCompleted, StatusStopped, StatusFailed)
+
+// ExpiredSimulations gibt die IDs der beendeten Simulationen zurück, deren Läufe alle
+// vor endedBefore endeten
+func (r *Repository) ExpiredSimulations(endedBefore time.Time) ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT id FROM simulations
+		WHERE status IN ('%s', '%s', '%s')
+		AND EXISTS (SELECT 1 FROM simulation_runs WHERE simulation_id = simulations.id)
+		AND NOT EXISTS (
+			SELECT 1 FROM simulation_runs
+			WHERE simulation_id = simulations.id AND NOT (`+runEndedCondition+` AND end_time IS NOT NULL)
+		)
+	`, StatusCompleted, StatusStopped, StatusFailed)
+
+	ids, err := r.queryIDs(query, endedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("Fehler beim Suchen abgelaufener Simulationen: %v", err)
+	}
+	return ids, nil
+}
+
+// PurgeEvents löscht die Events aller Läufe, die vor endedBefore beendet wurden, und gibt
+// die IDs der Läufe zurück, die Events hatten
+func (r *Repository) PurgeEvents(endedBefore time.Time) ([]string, error) {
+	ended := "SELECT id FROM simulation_runs WHERE " + runEndedCondition
+	ids, err := r.queryIDs("SELECT DISTINCT run_id FROM simulation_events WHERE run_id IN ("+ended+")", endedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("Fehler beim Suchen abgelaufener Events: %v", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if _, err := r.exec("DELETE FROM simulation_events WHERE run_id IN ("+ended+")", endedBefore); err != nil {
+		return nil, fmt.Errorf("Fehler beim Löschen abgelaufener Events: %v", err)
+	}
+	return ids, nil
+}
+
+// queryIDs liest eine Spalte mit IDs
+func (r *Repository) queryIDs(query string, args ...interface{}) ([]string, error) {
+	rows, err := r.query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// likeEscaper maskiert die Platzhalter von LIKE in einem Suchbegriff
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// SaveRun speichert einen Lauf und ersetzt einen vorhandenen; created_at bleibt erhalten
+func (r *Repository) SaveRun(run *SimulationRun) error {
+	resultsJSON, err := jsonColumn(run.Results)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Serialisieren der Ergebnisse: %v", err)
+	}
+	parametersJSON, err := jsonColumn(run.Parameters)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Serialisieren der Parameter: %v", err)
+	}
+
+	query := `
+		INSERT INTO simulation_runs
+		(id, simulation_id, number, status, seed, parameters_json, start_time, end_time,
+		progress, threats_detected, results_json, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (id) DO UPDATE
+		SET status = EXCLUDED.status, seed = EXCLUDED.seed, parameters_json = EXCLUDED.parameters_json,
+			start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time, progress = EXCLUDED.progress,
+			threats_detected = EXCLUDED.threats_detected, results_json = EXCLUDED.results_json,
+			error = EXCLUDED.error
+	`
+	_, err = r.exec(
+		query,
+		run.ID, run.SimulationID, run.Number, string(run.Status), run.Seed, parametersJSON,
+		run.StartTime, run.EndTime, run.Progress, run.ThreatsDetected, resultsJSON, run.Error, run.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Speichern des Laufs: %v", err)
+	}
+
+	return nil
+}
+
+// GetRuns lädt alle Läufe einer Simulation, den ersten zuerst
+func (r *Repository) GetRuns(simulationID string) ([]*SimulationRun, error) {
+	query := `
+		SELECT id, simulation_id, number, status, seed, parameters_json, start_time, end_time,
+			progress, threats_detected, results_json, error, created_at
+		FROM simulation_runs
+		WHERE simulation_id = $1
+		ORDER BY number
+	`
+
+	rows, err := r.query(query, simulationID)
+	if err != nil {
+		return nil, fmt.Errorf("Fehler beim Laden der Läufe: %v", err)
+	}
+	defer rows.Close()
+
+	runs := []*SimulationRun{}
 	for rows.Next() {
-		var sim Simulation
+		var run SimulationRun
 		var status string
-		var resultsJSON []byte
+		var resultsJSON, parametersJSON []byte
 		var startTime, endTime sql.NullTime
-		
+		var errorText sql.NullString
+
 		err := rows.Scan(
-			&sim.ID, &sim.Name, &sim.Description, &status, &startTime, &endTime,
-			&sim.InfrastructureID, &sim.ScenarioID, &sim.Progress, &sim.ThreatsDetected,
-			&resultsJSON, &sim.CreatedAt, &sim.UpdatedAt,
+			&run.ID, &run.SimulationID, &run.Number, &status, &run.Seed, &parametersJSON,
+			&startTime, &endTime, &run.Progress, &run.ThreatsDetected, &resultsJSON, &errorText, &run.CreatedAt,
 		)
-		
 		if err != nil {
-			return nil, fmt.Errorf("Fehler beim Scannen der Simulation: %v", err)
+			return nil, fmt.Errorf("Fehler beim Scannen des Laufs: %v", err)
 		}
-		
-		// Status konvertieren
-		sim.Status = Status(status)
-		
-		// Nullable Felder handhaben
+
+		run.Status = Status(status)
 		if startTime.Valid {
-			sim.StartTime = &startTime.Time
+			run.StartTime = &startTime.Time
 		}
 		if endTime.Valid {
-			sim.EndTime = &endTime.Time
+			run.EndTime = &endTime.Time
 		}
-		
-		// Ergebnisse deserialisieren, falls vorhanden
+		run.Error = errorText.String
 		if len(resultsJSON) > 0 {
-			var results map[string]interface{}
+			var results SimulationResults
 			if err := json.Unmarshal(resultsJSON, &results); err != nil {
 				logging.Logger.Warnf("Fehler beim Deserialisieren der Ergebnisse: %v", err)
 			} else {
-				sim.Results = results
+				run.Results = &results
 			}
 		}
-		
-		simulations = append(simulations, &sim)
+		if len(parametersJSON) > 0 {
+			if err := json.Unmarshal(parametersJSON, &run.Parameters); err != nil {
+				logging.Logger.Warnf("Fehler beim Deserialisieren der Parameter: %v", err)
+			}
+		}
+
+		runs = append(runs, &run)
 	}
-	
+
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("Fehler beim Iterieren über Simulationen: %v", err)
+		return nil, fmt.Errorf("Fehler beim Iterieren über Läufe: %v", err)
 	}
-	
-	return simulations, nil
+
+	return runs, nil
 }
 
 // SaveEvent speichert ein Event in der Datenbank
 func (r *Repository) SaveEvent(event SimulationEvent) error {
 	// Konvertiere Details zu JSON
-	var detailsJSON []byte
-	var err error
-	if event.Details != nil {
-		detailsJSON, err = json.Marshal(event.Details)
-		if err != nil {
-			return fmt.Errorf("Fehler beim Serialisieren der Details: %v", err)
-		}
+	detailsJSON, err := jsonColumn(event.Details)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Serialisieren der Details: %v", err)
 	}
-	
+
 	query := `
 		INSERT INTO simulation_events
-		(id, simulation_id, event_type, timestamp, resource_id, details_json, severity)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		(id, simulation_id, run_id, sequence, event_type, timestamp, description, resource_id, severity,
+		phase, outcome, detected, blocked, simulated_seconds, details_json)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		ON CONFLICT (id) DO NOTHING
 	`
-	
-	_, err = r.db.Exec(
+
+	_, err = r.exec(
 		query,
-		event.ID, event.SimulationID, string(event.Type), event.Timestamp,
-		event.ResourceID, detailsJSON, string(event.Severity),
+		event.ID, event.SimulationID, event.RunID, event.Sequence, string(event.Type), event.Timestamp, event.Description,
+		event.ResourceID, string(event.Severity), event.Phase, string(event.Outcome),
+		event.Detected, event.Blocked, event.SimulatedSeconds, detailsJSON,
 	)
-	
+
 	if err != nil {
 		return fmt.Errorf("Fehler beim Speichern des Events: %v", err)
 	}
-	
+
 	return nil
 }
 
-// GetEvents lädt alle Events für eine Simulation
-func (r *Repository) GetEvents(simulationID string) ([]SimulationEvent, error) {
-	query := `
-		SELECT id, simulation_id, event_type, timestamp, resource_id, details_json, severity
-		FROM simulation_events
-		WHERE simulation_id = $1
-		ORDER BY timestamp
-	`
-	
-	rows, err := r.db.Query(query, simulationID)
+// eventColumns sind die Spalten, die scanEvents erwartet
+const eventColumns = `id, simulation_id, run_id, sequence, event_type, timestamp, description, resource_id,
+	severity, phase, outcome, detected, blocked, simulated_seconds, details_json`
+
+// GetEvents lädt alle Events eines Laufs in der Reihenfolge, in der sie gespeichert wurden
+func (r *Repository) GetEvents(simulationID, runID string) ([]SimulationEvent, error) {
+	query := "SELECT " + eventColumns + " FROM simulation_events WHERE simulation_id = $1 AND run_id = $2 ORDER BY sequence"
+
+	rows, err := r.query(query, simulationID, runID)
 	if err != nil {
 		return nil, fmt.Errorf("Fehler beim Laden der Events: %v", err)
 	}
 	defer rows.Close()
-	
-	var events []SimulationEvent
-	
+
+	return scanEvents(rows)
+}
+
+// QueryEvents lädt nur die Events, die zur Abfrage passen. Filter, Ausschnitt und
+// Begrenzung werden in der Datenbank ausgewertet; bei Latest wird absteigend gelesen
+// und das Ergebnis umgedreht.
+func (r *Repository) QueryEvents(simulationID, runID string, query EventQuery) ([]SimulationEvent, error) {
+	conditions := []string{"simulation_id = $1", "run_id = $2"}
+	args := []interface{}{simulationID, runID}
+	addCondition := func(format string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf(format, placeholders...))
+	}
+	addIn := func(column string, values []string) {
+		placeholders := make([]string, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", ")))
+	}
+
+	if len(query.Types) > 0 {
+		types := make([]string, len(query.Types))
+		for i, eventType := range query.Types {
+			types[i] = string(eventType)
+		}
+		addIn("event_type", types)
+	}
+	if len(query.Severities) > 0 {
+		severities := make([]string, len(query.Severities))
+		for i, severity := range query.Severities {
+			severities[i] = string(severity)
+		}
+		addIn("severity", severities)
+	}
+	if query.MinSeverity != "" {
+		var severities []string
+		for _, severity := range severitiesFrom(query.MinSeverity) {
+			severities = append(severities, string(severity))
+		}
+		addIn("severity", severities)
+	}
+	if query.ResourceID != "" {
+		addCondition("resource_id = %s", query.ResourceID)
+	}
+	if query.Since != nil {
+		addCondition("timestamp >= %s", *query.Since)
+	}
+	if query.Until != nil {
+		addCondition("timestamp < %s", *query.Until)
+	}
+	if query.AfterSequence > 0 {
+		addCondition("sequence > %s", query.AfterSequence)
+	}
+	if query.BeforeSequence > 0 {
+		addCondition("sequence < %s", query.BeforeSequence)
+	}
+
+	order := "ASC"
+	if query.Latest {
+		order = "DESC"
+	}
+	statement := fmt.Sprintf("SELECT %s FROM simulation_events WHERE %s ORDER BY sequence %s",
+		eventColumns, strings.Join(conditions, " AND "), order)
+	if query.Limit > 0 {
+		args = append(args, query.Limit)
+		statement += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.query(statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Fehler beim Laden der Events: %v", err)
+	}
+	defer rows.Close()
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+	if query.Latest {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+	return events, nil
+}
+
+// scanEvents liest alle Zeilen mit den Spalten aus eventColumns
+func scanEvents(rows *sql.Rows) ([]SimulationEvent, error) {
+	events := []SimulationEvent{}
+
 	for rows.Next() {
 		var event SimulationEvent
 		var eventType, severity string
 		var detailsJSON []byte
-		var resourceID sql.NullString
-		
+		var description, resourceID, phase, outcome sql.NullString
+
 		err := rows.Scan(
-			&event.ID, &event.SimulationID, &eventType, &event.Timestamp,
-			&resourceID, &detailsJSON, &severity,
+			&event.ID, &event.SimulationID, &event.RunID, &event.Sequence, &eventType, &event.Timestamp, &description,
+			&resourceID, &severity, &phase, &outcome,
+			&event.Detected, &event.Blocked, &event.SimulatedSeconds, &detailsJSON,
 		)
-		
+
 		if err != nil {
 			return nil, fmt.Errorf("Fehler beim Scannen des Events: %v", err)
 		}
-		
+
 		// Typen konvertieren
 		event.Type = EventType(eventType)
 		event.Severity = Severity(severity)
-		
+
 		// Nullable Felder handhaben
-		if resourceID.Valid {
-			event.ResourceID = resourceID.String
-		}
-		
+		event.Description = description.String
+		event.ResourceID = resourceID.String
+		event.Phase = phase.String
+		event.Outcome = EventOutcome(outcome.String)
+
 		// Details deserialisieren, falls vorhanden
 		if len(detailsJSON) > 0 {
 			var details map[string]interface{}
@@ -275,24 +697,24 @@ func (r *Repository) GetEvents(simulationID string) ([]SimulationEvent, error) {
 				event.Details = details
 			}
 		}
-		
+
 		events = append(events, event)
 	}
-	
+
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("Fehler beim Iterieren über Events: %v", err)
 	}
-	
+
 	return events, nil
 }
 
 // SaveAffectedResource speichert eine betroffene Ressource in der Datenbank
 func (r *Repository) SaveAffectedResource(resource AffectedResource) error {
 	// Konvertiere Vulnerabilities zu JSON
-	var vulnerabilitiesJSON []byte
+	var vulnerabilitiesJSON interface{}
 	var err error
 	if len(resource.Vulnerabilities) > 0 {
-		vulnerabilitiesJSON, err = json.Marshal(resource.Vulnerabilities)
+		vulnerabilitiesJSON, err = jsonColumn(resource.Vulnerabilities)
 		if err != nil {
 			return fmt.Errorf("Fehler beim Serialisieren der Vulnerabilities: %v", err)
 		}
@@ -300,8 +722,8 @@ func (r *Repository) SaveAffectedResource(resource AffectedResource) error {
 	
 	// Prüfe, ob die Ressource bereits existiert
 	var exists bool
-	err = r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM affected_resources WHERE id = $1 AND simulation_id = $2)",
-		resource.ID, resource.SimulationID).Scan(&exists)
+	err = r.queryRow("SELECT EXISTS(SELECT 1 FROM affected_resources WHERE id = $1 AND simulation_id = $2 AND run_id = $3)",
+		resource.ID, resource.SimulationID, resource.RunID).Scan(&exists)
 	if err != nil {
 		return fmt.Errorf("Fehler beim Prüfen der Existenz der Ressource: %v", err)
 	}
@@ -311,23 +733,23 @@ func (r *Repository) SaveAffectedResource(resource AffectedResource) error {
 		query := `
 			UPDATE affected_resources
 			SET name = $1, type = $2, status = $3, threat_level = $4, attack_vector = $5, vulnerabilities = $6
-			WHERE id = $7 AND simulation_id = $8
+			WHERE id = $7 AND simulation_id = $8 AND run_id = $9
 		`
-		_, err = r.db.Exec(
+		_, err = r.exec(
 			query,
 			resource.Name, resource.Type, string(resource.Status), resource.ThreatLevel,
-			resource.AttackVector, vulnerabilitiesJSON, resource.ID, resource.SimulationID,
+			resource.AttackVector, vulnerabilitiesJSON, resource.ID, resource.SimulationID, resource.RunID,
 		)
 	} else {
 		// Neue Ressource einfügen
 		query := `
 			INSERT INTO affected_resources
-			(id, simulation_id, name, type, status, threat_level, attack_vector, vulnerabilities)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			(id, simulation_id, run_id, name, type, status, threat_level, attack_vector, vulnerabilities)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		`
-		_, err = r.db.Exec(
+		_, err = r.exec(
 			query,
-			resource.ID, resource.SimulationID, resource.Name, resource.Type,
+			resource.ID, resource.SimulationID, resource.RunID, resource.Name, resource.Type,
 			string(resource.Status), resource.ThreatLevel, resource.AttackVector, vulnerabilitiesJSON,
 		)
 	}
@@ -339,15 +761,15 @@ func (r *Repository) SaveAffectedResource(resource AffectedResource) error {
 	return nil
 }
 
-// GetAffectedResources lädt alle betroffenen Ressourcen für eine Simulation
-func (r *Repository) GetAffectedResources(simulationID string) ([]AffectedResource, error) {
+// GetAffectedResources lädt alle betroffenen Ressourcen eines Laufs
+func (r *Repository) GetAffectedResources(simulationID, runID string) ([]AffectedResource, error) {
 	query := `
-		SELECT id, simulation_id, name, type, status, threat_level, attack_vector, vulnerabilities
+		SELECT id, simulation_id, run_id, name, type, status, threat_level, attack_vector, vulnerabilities
 		FROM affected_resources
-		WHERE simulation_id = $1
+		WHERE simulation_id = $1 AND run_id = $2
 	`
 	
-	rows, err := r.db.Query(query, simulationID)
+	rows, err := r.query(query, simulationID, runID)
 	if err != nil {
 		return nil, fmt.Errorf("Fehler beim Laden der Ressourcen: %v", err)
 	}
@@ -362,7 +784,7 @@ func (r *Repository) GetAffectedResources(simulationID string) ([]AffectedResour
 		var attackVector sql.NullString
 		
 		err := rows.Scan(
-			&resource.ID, &resource.SimulationID, &resource.Name, &resource.Type,
+			&resource.ID, &resource.SimulationID, &resource.RunID, &resource.Name, &resource.Type,
 			&status, &resource.ThreatLevel, &attackVector, &vulnerabilitiesJSON,
 		)
 		
@@ -396,4 +818,283 @@ func (r *Repository) GetAffectedResources(simulationID string) ([]AffectedResour
 	}
 	
 	return resources, nil
-}
\ No newline at end of file
+}
+
+// jsonColumn serialisiert einen Wert für eine JSON-Spalte. Leere Werte werden als NULL
+// gespeichert; als String übergeben, damit der Treiber das JSON nicht als Binärdaten kodiert.
+func jsonColumn(value interface{}) (interface{}, error) {
+	if value == nil || reflect.ValueOf(value).IsZero() {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// SaveCheckpoint speichert den Checkpoint einer Simulation und ersetzt einen vorhandenen
+func (r *Repository) SaveCheckpoint(checkpoint Checkpoint) error {
+	query := `
+		INSERT INTO simulation_checkpoints (simulation_id, state_json, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (simulation_id) DO UPDATE
+		SET state_json = EXCLUDED.state_json, created_at = EXCLUDED.created_at
+	`
+
+	_, err := r.exec(query, checkpoint.SimulationID, string(checkpoint.State), checkpoint.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Speichern des Checkpoints: %v", err)
+	}
+
+	return nil
+}
+
+// GetCheckpoint lädt den Checkpoint einer Simulation
+func (r *Repository) GetCheckpoint(simulationID string) (*Checkpoint, error) {
+	query := `
+		SELECT simulation_id, state_json, created_at
+		FROM simulation_checkpoints
+		WHERE simulation_id = $1
+	`
+
+	var checkpoint Checkpoint
+	err := r.queryRow(query, simulationID).Scan(&checkpoint.SimulationID, &checkpoint.State, &checkpoint.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrCheckpointNotFound, simulationID)
+	} else if err != nil {
+		return nil, fmt.Errorf("Fehler beim Laden des Checkpoints: %v", err)
+	}
+
+	return &checkpoint, nil
+}
+
+// DeleteCheckpoint entfernt den Checkpoint einer Simulation, sofern vorhanden
+func (r *Repository) DeleteCheckpoint(simulationID string) error {
+	if _, err := r.exec("DELETE FROM simulation_checkpoints WHERE simulation_id = $1", simulationID); err != nil {
+		return fmt.Errorf("Fehler beim Löschen des Checkpoints: %v", err)
+	}
+	return nil
+}
+
+// scheduleColumns sind die Spalten, die scanSchedule erwartet
+const scheduleColumns = `id, name, description, cron, timezone, infrastructure_id, scenario_id,
+	parameters_json, overlap, enabled, pending, next_run_at, last_run_at, last_simulation_id,
+	last_outcome, last_error, created_at, updated_at`
+
+// SaveSchedule speichert einen Zeitplan und ersetzt einen vorhandenen; created_at bleibt erhalten
+func (r *Repository) SaveSchedule(schedule *Schedule) error {
+	parametersJSON, err := jsonColumn(schedule.Parameters)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Serialisieren der Parameter: %v", err)
+	}
+
+	query := `
+		INSERT INTO schedules (` + scheduleColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description, cron = EXCLUDED.cron,
+			timezone = EXCLUDED.timezone, infrastructure_id = EXCLUDED.infrastructure_id,
+			scenario_id = EXCLUDED.scenario_id, parameters_json = EXCLUDED.parameters_json,
+			overlap = EXCLUDED.overlap, enabled = EXCLUDED.enabled, pending = EXCLUDED.pending,
+			next_run_at = EXCLUDED.next_run_at, last_run_at = EXCLUDED.last_run_at,
+			last_simulation_id = EXCLUDED.last_simulation_id, last_outcome = EXCLUDED.last_outcome,
+			last_error = EXCLUDED.last_error, updated_at = EXCLUDED.updated_at
+	`
+	_, err = r.exec(
+		query,
+		schedule.ID, schedule.Name, schedule.Description, schedule.Cron, schedule.Timezone,
+		schedule.InfrastructureID, schedule.ScenarioID, parametersJSON, string(schedule.Overlap),
+		schedule.Enabled, schedule.Pending, schedule.NextRunAt, schedule.LastRunAt,
+		schedule.LastSimulationID, string(schedule.LastOutcome), schedule.LastError,
+		schedule.CreatedAt, schedule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Speichern des Zeitplans: %v", err)
+	}
+
+	return nil
+}
+
+// scanSchedule liest eine Zeile mit den Spalten aus scheduleColumns
+func scanSchedule(row rowScanner) (*Schedule, error) {
+	var schedule Schedule
+	var overlap, outcome string
+	var parametersJSON []byte
+	var nextRunAt, lastRunAt sql.NullTime
+	var lastError sql.NullString
+
+	err := row.Scan(
+		&schedule.ID, &schedule.Name, &schedule.Description, &schedule.Cron, &schedule.Timezone,
+		&schedule.InfrastructureID, &schedule.ScenarioID, &parametersJSON, &overlap,
+		&schedule.Enabled, &schedule.Pending, &nextRunAt, &lastRunAt, &schedule.LastSimulationID,
+		&outcome, &lastError, &schedule.CreatedAt, &schedule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.Overlap = OverlapPolicy(overlap)
+	schedule.LastOutcome = ScheduleOutcome(outcome)
+	schedule.LastError = lastError.String
+	if nextRunAt.Valid {
+		schedule.NextRunAt = &nextRunAt.Time
+	}
+	if lastRunAt.Valid {
+		schedule.LastRunAt = &lastRunAt.Time
+	}
+	if len(parametersJSON) > 0 {
+		if err := json.Unmarshal(parametersJSON, &schedule.Parameters); err != nil {
+			logging.Logger.Warnf("Fehler beim Deserialisieren der Parameter: %v", err)
+		}
+	}
+
+	return &schedule, nil
+}
+
+// GetSchedule lädt einen Zeitplan
+func (r *Repository) GetSchedule(id string) (*Schedule, error) {
+	query := "SELECT " + scheduleColumns + " FROM schedules WHERE id = $1"
+
+	schedule, err := scanSchedule(r.queryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrScheduleNotFound, id)
+	} else if err != nil {
+		return nil, fmt.Errorf("Fehler beim Laden des Zeitplans: %v", err)
+	}
+
+	return schedule, nil
+}
+
+// GetSchedules lädt alle Zeitpläne, die ältesten zuerst
+func (r *Repository) GetSchedules() ([]*Schedule, error) {
+	query := "SELECT " + scheduleColumns + " FROM schedules ORDER BY created_at, id"
+
+	rows, err := r.query(query)
+	if err != nil {
+		return nil, fmt.Errorf("Fehler beim Laden der Zeitpläne: %v", err)
+	}
+	defer rows.Close()
+
+	schedules := []*Schedule{}
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Fehler beim Scannen des Zeitplans: %v", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Fehler beim Iterieren über Zeitpläne: %v", err)
+	}
+
+	return schedules, nil
+}
+
+// DeleteSchedule entfernt einen Zeitplan, sofern vorhanden
+func (r *Repository) DeleteSchedule(id string) error {
+	if _, err := r.exec("DELETE FROM schedules WHERE id = $1", id); err != nil {
+		return fmt.Errorf("Fehler beim Löschen des Zeitplans: %v", err)
+	}
+	return nil
+}
+
+// scenarioColumns sind die Spalten, die scanScenario erwartet
+const scenarioColumns = `id, name, description, difficulty, duration, steps_json, created_at, updated_at`
+
+// SaveScenario speichert ein Szenario und ersetzt ein vorhandenes; created_at bleibt erhalten
+func (r *Repository) SaveScenario(scenario *Scenario) error {
+	stepsJSON, err := json.Marshal(scenario.Steps)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Serialisieren der Schritte: %v", err)
+	}
+
+	query := `
+		INSERT INTO scenarios (` + scenarioColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description, difficulty = EXCLUDED.difficulty,
+			duration = EXCLUDED.duration, steps_json = EXCLUDED.steps_json, updated_at = EXCLUDED.updated_at
+	`
+	_, err = r.exec(
+		query,
+		scenario.ID, scenario.Name, scenario.Description, string(scenario.Difficulty), scenario.Duration,
+		string(stepsJSON), scenario.CreatedAt, scenario.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("Fehler beim Speichern des Szenarios: %v", err)
+	}
+
+	return nil
+}
+
+// scanScenario liest eine Zeile mit den Spalten aus scenarioColumns
+func scanScenario(row rowScanner) (*Scenario, error) {
+	var scenario Scenario
+	var difficulty string
+	var stepsJSON []byte
+
+	err := row.Scan(
+		&scenario.ID, &scenario.Name, &scenario.Description, &difficulty, &scenario.Duration,
+		&stepsJSON, &scenario.CreatedAt, &scenario.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	scenario.Difficulty = Difficulty(difficulty)
+	if err := json.Unmarshal(stepsJSON, &scenario.Steps); err != nil {
+		return nil, fmt.Errorf("Fehler beim Deserialisieren der Schritte von Szenario %s: %v", scenario.ID, err)
+	}
+
+	return &scenario, nil
+}
+
+// GetScenario lädt ein Szenario
+func (r *Repository) GetScenario(id string) (*Scenario, error) {
+	query := "SELECT " + scenarioColumns + " FROM scenarios WHERE id = $1"
+
+	scenario, err := scanScenario(r.queryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrScenarioNotFound, id)
+	} else if err != nil {
+		return nil, fmt.Errorf("Fehler beim Laden des Szenarios: %v", err)
+	}
+
+	return scenario, nil
+}
+
+// GetScenarios lädt alle Szenarien, die ältesten zuerst
+func (r *Repository) GetScenarios() ([]*Scenario, error) {
+	query := "SELECT " + scenarioColumns + " FROM scenarios ORDER BY created_at, id"
+
+	rows, err := r.query(query)
+	if err != nil {
+		return nil, fmt.Errorf("Fehler beim Laden der Szenarien: %v", err)
+	}
+	defer rows.Close()
+
+	scenarios := []*Scenario{}
+	for rows.Next() {
+		scenario, err := scanScenario(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Fehler beim Scannen des Szenarios: %v", err)
+		}
+		scenarios = append(scenarios, scenario)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Fehler beim Iterieren über Szenarien: %v", err)
+	}
+
+	return scenarios, nil
+}
+
+// DeleteScenario entfernt ein Szenario, sofern vorhanden
+func (r *Repository) DeleteScenario(id string) error {
+	if _, err := r.exec("DELETE FROM scenarios WHERE id = $1", id); err != nil {
+		return fmt.Errorf("Fehler beim Löschen des Szenarios: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// backend/internal/simulation/results.go
+package simulation
+
+import (
+	"time"
+)
+
+// SimulationResults ist der Abschlussbericht eines Simulationslaufs. Er wird beim
+// Abschließen, Stoppen oder Fehlschlagen aus den tatsächlichen Events und
+// betroffenen Ressourcen berechnet.
+type SimulationResults struct {
+	Status                Status             `json:"status"`
+	Error                 string             `json:"error,omitempty"`
+	StartTime             *time.Time         `json:"startTime,omitempty"`
+	EndTime               *time.Time         `json:"endTime,omitempty"`
+	Runtime               string             `json:"runtime"`          // Laufzeit ohne Pausen
+	SimulatedSeconds      float64            `json:"simulatedSeconds"` // simulierte Dauer bis zum letzten Event
+	EventsCount           int                `json:"eventsCount"`
+	SeverityCounts        map[Severity]int   `json:"severityCounts"`
+	AttackSteps           int                `json:"attackSteps"`
+	SucceededSteps        int                `json:"succeededSteps"`
+	FailedSteps           int                `json:"failedSteps"`
+	BlockedSteps          int                `json:"blockedSteps"`
+	DetectedSteps         int                `json:"detectedSteps"`
+	UndetectedSteps       int                `json:"undetectedSteps"`
+	DetectionRate         float64            `json:"detectionRate"`
+	TimeToDetect          string             `json:"timeToDetect,omitempty"`
+	TimeToFirstCompromise string             `json:"timeToFirstCompromise,omitempty"` // simulierte Zeit ab Start
+	Phases                []PhaseResult      `json:"phases"`
+	CompromisedAssets     []CompromisedAsset `json:"compromisedAssets"`
+}
+
+// PhaseResult fasst die Angriffsaktionen eines Szenarioschritts zusammen
+type PhaseResult struct {
+	Name      string `json:"name"`
+	Attempts  int    `json:"attempts"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	Blocked   int    `json:"blocked"`
+	Detected  int    `json:"detected"`
+}
+
+// CompromisedAsset ist eine Ressource, die der Angreifer übernommen hat
+type CompromisedAsset struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Type         string  `json:"type"`
+	ThreatLevel  float64 `json:"threatLevel"`
+	AttackVector string  `json:"attackVector,omitempty"`
+}
+
+// buildResults berechnet den Abschlussbericht einer Simulation
+func buildResults(simulation *Simulation, events []SimulationEvent, resources []AffectedResource, now time.Time) *SimulationResults {
+	results := &SimulationResults{
+		Status:            simulation.Status,
+		Error:             simulation.Error,
+		StartTime:         simulation.StartTime,
+		EndTime:           simulation.EndTime,
+		Runtime:           formatRuntime(activeRuntime(simulation, now)),
+		EventsCount:       len(events),
+		SeverityCounts:    make(map[Severity]int),
+		Phases:            []PhaseResult{},
+		CompromisedAssets: []CompromisedAsset{},
+	}
+
+	var status SimulationStatus
+	applyDetectionStats(&status, events)
+	results.AttackSteps = status.AttackSteps
+	results.BlockedSteps = status.BlockedSteps
+	results.DetectedSteps = status.DetectedSteps
+	results.UndetectedSteps = status.AttackSteps - status.DetectedSteps
+	results.DetectionRate = status.DetectionRate
+	results.TimeToDetect = status.TimeToDetect
+
+	phaseIndex := make(map[string]int)
+	for _, event := range events {
+		results.SeverityCounts[event.Severity]++
+		if event.SimulatedSeconds > results.SimulatedSeconds {
+			results.SimulatedSeconds = event.SimulatedSeconds
+		}
+
+		// Schritte in der Reihenfolge ihres ersten Auftretens
+		if event.Phase == "" {
+			continue
+		}
+		index, exists := phaseIndex[event.Phase]
+		if !exists {
+			index = len(results.Phases)
+			phaseIndex[event.Phase] = index
+			results.Phases = append(results.Phases, PhaseResult{Name: event.Phase})
+		}
+		if event.Outcome == "" {
+			continue
+		}
+
+		phase := &results.Phases[index]
+		phase.Attempts++
+		switch event.Outcome {
+		case OutcomeSucceeded:
+			phase.Succeeded++
+			results.SucceededSteps++
+		case OutcomeBlocked:
+			phase.Blocked++
+		default:
+			phase.Failed++
+		}
+		if event.Detected {
+			phase.Detected++
+		}
+	}
+	results.FailedSteps = results.AttackSteps - results.SucceededSteps - results.BlockedSteps
+	if seconds, ok := firstCompromise(events); ok {
+		results.TimeToFirstCompromise = formatRuntime(time.Duration(seconds * float64(time.Second)))
+	}
+
+	for _, resource := range resources {
+		if resource.Status != ResourceStatusCompromised {
+			continue
+		}
+		results.CompromisedAssets = append(results.CompromisedAssets, CompromisedAsset{
+			ID:           resource.ID,
+			Name:         resource.Name,
+			Type:         resource.Type,
+			ThreatLevel:  resource.ThreatLevel,
+			AttackVector: resource.AttackVector,
+		})
+	}
+
+	return results
+}
+
+// firstCompromise gibt die simulierte Zeit zurück, zu der der Angreifer die erste
+// Ressource übernommen hat
+func firstCompromise(events []SimulationEvent) (float64, bool) {
+	for _, event := range events {
+		if event.Outcome == OutcomeSucceeded && eventImpact(event) == ResourceStatusCompromised {
+			return event.SimulatedSeconds, true
+		}
+	}
+	return 0, false
+}
+
+// eventImpact gibt den Status zurück, den eine erfolgreiche Aktion bei ihrem Ziel bewirkt hat
+func eventImpact(event SimulationEvent) ResourceStatus {
+	details, ok := event.Details.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	return ResourceStatus(toString(details["impact"]))
+}
@@ -0,0 +1,102 @@
+// backend/internal/simulation/results_test.go
+package simulation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResultsMatchEventsAndResources(t *testing.T) {
+	engine := NewEngine()
+
+	compromisedRuns := 0
+	for seed := 1; seed <= 5; seed++ {
+		sim := runInstant(t, engine, SimulationConfig{
+			Name:       "Bericht",
+			ScenarioID: "scenario-2",
+			Parameters: map[string]interface{}{ParameterSeed: float64(seed)},
+		})
+
+		finished, _ := engine.GetSimulation(sim.ID)
+		results := finished.Results
+		if results == nil {
+			t.Fatalf("Seed %d: Abgeschlossene Simulation hat keinen Bericht", seed)
+		}
+		if results.Status != StatusCompleted {
+			t.Fatalf("Seed %d: Erwarteter Status im Bericht: %s, Erhalten: %s", seed, StatusCompleted, results.Status)
+		}
+
+		events, _ := engine.GetEvents(sim.ID)
+		if results.EventsCount != len(events) {
+			t.Fatalf("Seed %d: Erwartete Events: %d, Im Bericht: %d", seed, len(events), results.EventsCount)
+		}
+		var severities int
+		for _, count := range results.SeverityCounts {
+			severities += count
+		}
+		if severities != len(events) {
+			t.Fatalf("Seed %d: Schweregrade summieren sich zu %d statt %d", seed, severities, len(events))
+		}
+
+		var attempts int
+		for _, phase := range results.Phases {
+			attempts += phase.Attempts
+			if phase.Succeeded+phase.Failed+phase.Blocked != phase.Attempts {
+				t.Fatalf("Seed %d: Ergebnisse der Phase %s sind inkonsistent: %+v", seed, phase.Name, phase)
+			}
+		}
+		if attempts != results.AttackSteps || results.DetectedSteps+results.UndetectedSteps != results.AttackSteps {
+			t.Fatalf("Seed %d: Angriffsschritte sind inkonsistent: %+v", seed, results)
+		}
+
+		resources, _ := engine.GetAffectedResources(sim.ID)
+		var compromised int
+		for _, resource := range resources {
+			if resource.Status == ResourceStatusCompromised {
+				compromised++
+			}
+		}
+		if len(results.CompromisedAssets) != compromised {
+			t.Fatalf("Seed %d: Erwartete kompromittierte Ressourcen: %d, Im Bericht: %d", seed, compromised, len(results.CompromisedAssets))
+		}
+		if (compromised > 0) != (results.TimeToFirstCompromise != "") {
+			t.Fatalf("Seed %d: Time-to-first-compromise %q passt nicht zu %d kompromittierten Ressourcen", seed, results.TimeToFirstCompromise, compromised)
+		}
+		if compromised > 0 {
+			compromisedRuns++
+		}
+	}
+
+	if compromisedRuns == 0 {
+		t.Fatal("Kein Lauf des Ransomware-Szenarios hat eine Ressource kompromittiert")
+	}
+}
+
+func TestStoppedSimulationHasResults(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock))
+
+	sim, err := engine.CreateSimulation(SimulationConfig{Name: "Gestoppt"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	engine.StartSimulation(sim.ID)
+	clock.Advance(time.Minute)
+	engine.StopSimulation(sim.ID)
+
+	stopped, _ := engine.GetSimulation(sim.ID)
+	if stopped.Results == nil || stopped.Results.Status != StatusStopped {
+		t.Fatalf("Gestoppte Simulation sollte einen Bericht mit Status %s haben: %+v", StatusStopped, stopped.Results)
+	}
+	if stopped.Results.Runtime != "00:01:00" {
+		t.Fatalf("Erwartete Laufzeit: 00:01:00, Erhalten: %s", stopped.Results.Runtime)
+	}
+
+	// Ein Neustart verwirft den alten Bericht
+	engine.StartSimulation(sim.ID)
+	restarted, _ := engine.GetSimulation(sim.ID)
+	if restarted.Results != nil {
+		t.Fatal("Neu gestartete Simulation sollte keinen Bericht haben")
+	}
+	engine.StopSimulation(sim.ID)
+}
@@ -0,0 +1,263 @@
+// backend/internal/simulation/retention.go
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/google/uuid"
+)
+
+// ErrSimulationActive wird zurückgegeben, wenn eine Simulation für die Aktion noch läuft,
+// pausiert ist oder auf einen Worker wartet
+var ErrSimulationActive = errors.New("Simulation ist noch aktiv")
+
+// finishedStatuses sind die Status beendeter Simulationen
+var finishedStatuses = []Status{StatusCompleted, StatusStopped, StatusFailed}
+
+// isActive gibt an, ob eine Simulation einen Worker belegt oder auf einen wartet
+func isActive(simulation *Simulation) bool {
+	return activeStatus(simulation.Status)
+}
+
+// activeStatus gibt an, ob eine Simulation mit dem Status einen Worker belegt oder auf
+// einen wartet
+func activeStatus(status Status) bool {
+	switch status {
+	case StatusRunning, StatusPaused, StatusQueued:
+		return true
+	}
+	return false
+}
+
+// unloadAfter ist die Zeit ohne Änderung, nach der der Janitor eine beendete Simulation
+// aus dem Speicher nimmt; sie bleibt im Store und wird bei Bedarf neu geladen
+const unloadAfter = 30 * time.Minute
+
+// RetentionPolicy legt fest, wie lange beendete Läufe und Simulationen aufbewahrt werden,
+// gerechnet ab dem Ende jedes Laufs. 0 bewahrt unbegrenzt auf.
+type RetentionPolicy struct {
+	EventsAfter      time.Duration // danach werden die Events gelöscht; Bericht und Ressourcen bleiben
+	SimulationsAfter time.Duration // nach dem Ende ihres letzten Laufs wird die Simulation vollständig gelöscht
+}
+
+// WithJanitor startet einen Hintergrundprozess, der im angegebenen Abstand die
+// Aufbewahrungsfristen durchsetzt und lange unveränderte beendete Simulationen aus dem
+// Speicher nimmt. Shutdown beendet ihn.
+func WithJanitor(interval time.Duration, policy RetentionPolicy) EngineOption {
+	return func(e *Engine) {
+		e.janitorInterval = interval
+		e.retention = policy
+	}
+}
+
+// startJanitor startet den Janitor, sofern er konfiguriert ist
+func (e *Engine) startJanitor() {
+	if e.janitorInterval <= 0 {
+		return
+	}
+	e.janitorStop = make(chan struct{})
+	go e.janitor(e.clock.NewTicker(e.janitorInterval), e.janitorStop)
+}
+
+// janitor räumt in jedem Intervall auf, bis stop geschlossen wird
+func (e *Engine) janitor(ticker Ticker, stop <-chan struct{}) {
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C():
+			e.cleanup()
+		}
+	}
+}
+
+// cleanup setzt die Aufbewahrungsfristen durch und entlädt ungenutzte Simulationen
+func (e *Engine) cleanup() {
+	now := e.clock.Now()
+
+	if e.retention.SimulationsAfter > 0 {
+		endedBefore := now.Add(-e.retention.SimulationsAfter)
+		ids, err := e.store.ExpiredSimulations(endedBefore)
+		if err != nil {
+			logging.Logger.Errorf("Abgelaufene Simulationen konnten nicht gesucht werden: %v", err)
+		}
+		e.mutex.Lock()
+		deleted := 0
+		for _, id := range ids {
+			// Der Store kennt noch nicht jede Änderung, etwa einen eben gestarteten Lauf;
+			// maßgeblich ist der Stand im Speicher
+			if simulation, loaded := e.simulations[id]; loaded && !expiredBefore(simulation, endedBefore) {
+				continue
+			}
+			id := id
+			e.queueWriteLocked(func(store Store) {
+				if err := store.DeleteSimulation(id); err != nil {
+					logging.Logger.Errorf("Abgelaufene Simulation %s konnte nicht gelöscht werden: %v", id, err)
+				}
+			})
+			e.forgetLocked(id)
+			deleted++
+		}
+		e.unlock()
+		if deleted > 0 {
+			logging.Logger.Infof("Aufbewahrungsfrist: %d Simulation(en) gelöscht", deleted)
+		}
+	}
+
+	if e.retention.EventsAfter > 0 {
+		runIDs, err := e.store.PurgeEvents(now.Add(-e.retention.EventsAfter))
+		if err != nil {
+			logging.Logger.Errorf("Abgelaufene Events konnten nicht gelöscht werden: %v", err)
+		}
+		purged := make(map[string]bool, len(runIDs))
+		for _, runID := range runIDs {
+			purged[runID] = true
+		}
+		e.mutex.Lock()
+		for id, simulation := range e.simulations {
+			if purged[simulation.RunID] {
+				e.events[id] = []SimulationEvent{}
+			}
+		}
+		e.unlock()
+		if len(runIDs) > 0 {
+			logging.Logger.Infof("Aufbewahrungsfrist: Events von %d abgelaufenen Läufen gelöscht", len(runIDs))
+		}
+	}
+
+	// Beendete Simulationen ohne Abonnenten entladen; ensureLoaded holt sie zurück
+	e.mutex.Lock()
+	unloaded := 0
+	for id, simulation := range e.simulations {
+		if isActive(simulation) || len(e.subscribers[id]) > 0 || now.Sub(simulation.UpdatedAt) < unloadAfter {
+			continue
+		}
+		delete(e.simulations, id)
+		delete(e.events, id)
+		delete(e.affectedResources, id)
+		unloaded++
+	}
+	e.unlock()
+	if unloaded > 0 {
+		logging.Logger.Debugf("%d beendete Simulation(en) aus dem Speicher entladen", unloaded)
+	}
+}
+
+// expiredBefore gibt an, ob eine Simulation beendet ist und ihr aktueller, also letzter
+// Lauf vor endedBefore endete
+func expiredBefore(simulation *Simulation, endedBefore time.Time) bool {
+	return containsStatus(finishedStatuses, simulation.Status) && simulation.EndTime != nil && simulation.EndTime.Before(endedBefore)
+}
+
+// forgetLocked entfernt eine Simulation aus dem Speicher und beendet ihre Abonnements.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) forgetLocked(id string) {
+	delete(e.simulations, id)
+	delete(e.events, id)
+	delete(e.affectedResources, id)
+	for sub := range e.subscribers[id] {
+		e.unsubscribeLocked(id, sub)
+	}
+}
+
+// DeleteSimulation löscht eine Simulation samt Events, Ressourcen und Checkpoint.
+// Eine aktive Simulation wird nur mit force gelöscht und dann zuvor angehalten.
+func (e *Engine) DeleteSimulation(id string, force bool) error {
+	e.ensureLoaded(id)
+
+	e.mutex.Lock()
+
+	simulation, exists := e.simulations[id]
+	if !exists {
+		e.unlock()
+		return fmt.Errorf("%w: %s", ErrSimulationNotFound, id)
+	}
+	if isActive(simulation) {
+		if !force {
+			e.unlock()
+			return fmt.Errorf("%w: Status %s", ErrSimulationActive, simulation.Status)
+		}
+		if simulation.Status == StatusQueued {
+			e.scheduler.remove(id)
+			delete(e.queuedRuns, id)
+			e.updateQueuePositionsLocked()
+		}
+		if stopChan, exists := e.stopChannels[id]; exists {
+			close(stopChan)
+			delete(e.stopChannels, id)
+		}
+		delete(e.resumeChannels, id)
+	}
+
+	// Das Löschen reiht sich hinter die noch vorgemerkten Schreibvorgänge der Simulation
+	// ein, sonst legte einer davon sie im Store wieder an
+	var deleteErr error
+	e.queueWriteLocked(func(store Store) {
+		deleteErr = store.DeleteSimulation(id)
+	})
+	e.forgetLocked(id)
+	e.unlock()
+
+	// unlock kehrt erst zurück, wenn auch das Löschen ausgeführt ist
+	if deleteErr != nil {
+		return fmt.Errorf("Simulation konnte nicht gelöscht werden: %v", deleteErr)
+	}
+
+	logging.Logger.Infof("Simulation '%s' (ID: %s) gelöscht", simulation.Name, id)
+	return nil
+}
+
+// ArchiveSimulation blendet eine beendete Simulation aus der Standardliste aus
+func (e *Engine) ArchiveSimulation(id string) (*Simulation, error) {
+	return e.setArchived(id, true)
+}
+
+// UnarchiveSimulation nimmt eine Simulation wieder in die Standardliste auf
+func (e *Engine) UnarchiveSimulation(id string) (*Simulation, error) {
+	return e.setArchived(id, false)
+}
+
+func (e *Engine) setArchived(id string, archived bool) (*Simulation, error) {
+	e.ensureLoaded(id)
+
+	e.mutex.Lock()
+	defer e.unlock()
+
+	simulation, exists := e.simulations[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, id)
+	}
+	if isActive(simulation) {
+		return nil, fmt.Errorf("%w: Status %s", ErrSimulationActive, simulation.Status)
+	}
+	if (simulation.ArchivedAt != nil) == archived {
+		return simulation, nil
+	}
+
+	now := e.clock.Now()
+	description := "Simulation archiviert"
+	if archived {
+		simulation.ArchivedAt = &now
+	} else {
+		simulation.ArchivedAt = nil
+		description = "Simulation aus dem Archiv geholt"
+	}
+	simulation.UpdatedAt = now
+	e.appendEventLocked(id, SimulationEvent{
+		ID:           uuid.New().String(),
+		SimulationID: id,
+		Timestamp:    now,
+		Type:         EventTypeSystem,
+		Description:  description,
+		Severity:     SeverityInfo,
+	})
+	e.saveSimulationLocked(simulation)
+
+	logging.Logger.Infof("%s: '%s' (ID: %s)", description, simulation.Name, id)
+	return simulation, nil
+}
@@ -0,0 +1,225 @@
+// backend/internal/simulation/retention_test.go
+package simulation
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// assertDeleteAndArchive prüft Löschen und Archivieren von Simulationen auf einem Store
+func assertDeleteAndArchive(t *testing.T, store Store) {
+	t.Helper()
+
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock), WithStore(store))
+
+	finished := runInstant(t, engine, SimulationConfig{Name: "Beendet"})
+	running, err := engine.CreateSimulation(SimulationConfig{Name: "Laufend"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	if _, err := engine.StartSimulation(running.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+
+	// Aktive Simulationen werden weder archiviert noch ohne force gelöscht
+	if _, err := engine.ArchiveSimulation(running.ID); !errors.Is(err, ErrSimulationActive) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrSimulationActive, err)
+	}
+	if err := engine.DeleteSimulation(running.ID, false); !errors.Is(err, ErrSimulationActive) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrSimulationActive, err)
+	}
+
+	// Archivierte Simulationen fehlen in der Standardliste
+	if _, err := engine.ArchiveSimulation(finished.ID); err != nil {
+		t.Fatalf("Fehler beim Archivieren: %v", err)
+	}
+	if stored, _ := store.GetSimulation(finished.ID); stored == nil || stored.ArchivedAt == nil {
+		t.Fatal("Der Archivierungszeitpunkt sollte gespeichert sein")
+	}
+	notArchived, archived := false, true
+	for _, c := range []struct {
+		archived *bool
+		want     []string
+	}{
+		{&notArchived, []string{"Laufend"}},
+		{&archived, []string{"Beendet"}},
+		{nil, []string{"Beendet", "Laufend"}},
+	} {
+		page, err := engine.QuerySimulations(SimulationQuery{Sort: SortName, SimulationFilter: SimulationFilter{Archived: c.archived}})
+		if err != nil {
+			t.Fatalf("Fehler beim Abfragen der Simulationen: %v", err)
+		}
+		if names := simulationNames(page.Simulations); !reflect.DeepEqual(names, c.want) {
+			t.Fatalf("Erwartet: %v, Erhalten: %v", c.want, names)
+		}
+	}
+	if sim, err := engine.UnarchiveSimulation(finished.ID); err != nil || sim.ArchivedAt != nil {
+		t.Fatalf("Fehler beim Zurückholen aus dem Archiv: %v", err)
+	}
+
+	// Mit force wird auch eine laufende Simulation angehalten und gelöscht
+	for _, sim := range []*Simulation{finished, running} {
+		if err := engine.DeleteSimulation(sim.ID, true); err != nil {
+			t.Fatalf("Fehler beim Löschen der Simulation: %v", err)
+		}
+		if _, err := engine.GetSimulation(sim.ID); err == nil {
+			t.Fatal("Die gelöschte Simulation sollte nicht mehr gefunden werden")
+		}
+		if events, _ := store.GetEvents(sim.ID, sim.RunID); len(events) > 0 {
+			t.Fatalf("Es sind noch %d Events gespeichert", len(events))
+		}
+	}
+	if err := engine.DeleteSimulation(running.ID, true); !errors.Is(err, ErrSimulationNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrSimulationNotFound, err)
+	}
+}
+
+func TestDeleteAndArchiveInMemory(t *testing.T) {
+	assertDeleteAndArchive(t, NewMemoryStore())
+}
+
+func TestDeleteAndArchiveInSQLite(t *testing.T) {
+	assertDeleteAndArchive(t, openSQLiteRepository(t, filepath.Join(t.TempDir(), "aegis.db")))
+}
+
+// assertRetention prüft, dass der Janitor Events und Simulationen nach Ablauf der Fristen löscht
+func assertRetention(t *testing.T, store Store) {
+	t.Helper()
+
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock), WithStore(store), WithJanitor(time.Hour, RetentionPolicy{
+		EventsAfter:      24 * time.Hour,
+		SimulationsAfter: 72 * time.Hour,
+	}))
+
+	old := runInstant(t, engine, SimulationConfig{Name: "Alt"})
+	created, err := engine.CreateSimulation(SimulationConfig{Name: "Nie gestartet"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+
+	// Nach einem Tag sind nur die Events abgelaufen
+	clock.Advance(25 * time.Hour)
+	waitFor(t, "Löschen der Events", func() bool {
+		events, _ := store.GetEvents(old.ID, old.RunID)
+		return len(events) == 0
+	})
+	if _, err := engine.GetSimulation(old.ID); err != nil {
+		t.Fatalf("Die Simulation sollte erhalten bleiben: %v", err)
+	}
+	if events, _ := engine.GetEvents(old.ID); len(events) != 0 {
+		t.Fatalf("Die Engine liefert noch %d Events", len(events))
+	}
+
+	recent := runInstant(t, engine, SimulationConfig{Name: "Neu"})
+
+	// Nach drei Tagen ist die erste Simulation abgelaufen, die zweite erst ihre Events
+	clock.Advance(48 * time.Hour)
+	waitFor(t, "Löschen der Simulation", func() bool {
+		_, err := store.GetSimulation(old.ID)
+		events, _ := store.GetEvents(recent.ID, recent.RunID)
+		return err != nil && len(events) == 0
+	})
+	if _, err := engine.GetSimulation(old.ID); err == nil {
+		t.Fatal("Die abgelaufene Simulation sollte gelöscht sein")
+	}
+	for _, id := range []string{recent.ID, created.ID} {
+		if _, err := engine.GetSimulation(id); err != nil {
+			t.Fatalf("Die Simulation %s sollte erhalten bleiben: %v", id, err)
+		}
+	}
+}
+
+// assertRunRetention prüft, dass die Fristen für jeden Lauf ab seinem eigenen Ende gelten
+func assertRunRetention(t *testing.T, store Store) {
+	t.Helper()
+
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock), WithStore(store), WithJanitor(time.Hour, RetentionPolicy{
+		EventsAfter:      24 * time.Hour,
+		SimulationsAfter: 72 * time.Hour,
+	}))
+
+	sim := runInstant(t, engine, SimulationConfig{Name: "Zwei Läufe"})
+	firstRun := sim.RunID
+	clock.Advance(20 * time.Hour)
+	rerun(t, engine, sim.ID)
+	secondRun := sim.RunID
+
+	// Nach 25 Stunden ist nur der erste Lauf abgelaufen
+	clock.Advance(5 * time.Hour)
+	waitFor(t, "Löschen der Events des ersten Laufs", func() bool {
+		events, _ := store.GetEvents(sim.ID, firstRun)
+		return len(events) == 0
+	})
+	if events, _ := store.GetEvents(sim.ID, secondRun); len(events) == 0 {
+		t.Fatal("Die Events des zweiten Laufs sollten erhalten bleiben")
+	}
+	if events, _ := engine.GetEvents(sim.ID); len(events) == 0 {
+		t.Fatal("Die Engine sollte die Events des aktuellen Laufs behalten")
+	}
+
+	// Die Simulation bleibt, solange ihr letzter Lauf nicht abgelaufen ist
+	clock.Advance(50 * time.Hour)
+	waitFor(t, "Löschen der Events des zweiten Laufs", func() bool {
+		events, _ := store.GetEvents(sim.ID, secondRun)
+		return len(events) == 0
+	})
+	if _, err := store.GetSimulation(sim.ID); err != nil {
+		t.Fatalf("Die Simulation sollte erhalten bleiben: %v", err)
+	}
+	clock.Advance(20 * time.Hour)
+	waitFor(t, "Löschen der Simulation", func() bool {
+		_, err := store.GetSimulation(sim.ID)
+		return err != nil
+	})
+}
+
+// staleStore meldet Simulationen als abgelaufen, ohne ihren aktuellen Stand zu prüfen,
+// wie ein Store, der eine Änderung der Engine noch nicht gesehen hat
+type staleStore struct {
+	*MemoryStore
+	expired []string
+}
+
+func (s *staleStore) ExpiredSimulations(endedBefore time.Time) ([]string, error) {
+	return s.expired, nil
+}
+
+func TestJanitorKeepsSimulationsActiveInMemory(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	store := &staleStore{MemoryStore: NewMemoryStore()}
+	engine := NewEngine(WithClock(clock), WithStore(store), WithJanitor(0, RetentionPolicy{SimulationsAfter: time.Hour}))
+
+	running, err := engine.CreateSimulation(SimulationConfig{Name: "Laufend"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	if _, err := engine.StartSimulation(running.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+	defer engine.StopSimulation(running.ID)
+	store.expired = []string{running.ID}
+
+	engine.cleanup()
+	if _, err := store.GetSimulation(running.ID); err != nil {
+		t.Fatalf("Die laufende Simulation sollte erhalten bleiben: %v", err)
+	}
+	if status, err := engine.GetSimulationStatus(running.ID); err != nil || status.Status != StatusRunning {
+		t.Fatalf("Erwartet: laufende Simulation, Erhalten: %+v (%v)", status, err)
+	}
+}
+
+func TestRetentionInMemory(t *testing.T) {
+	assertRetention(t, NewMemoryStore())
+	assertRunRetention(t, NewMemoryStore())
+}
+
+func TestRetentionInSQLite(t *testing.T) {
+	assertRetention(t, openSQLiteRepository(t, filepath.Join(t.TempDir(), "aegis.db")))
+	assertRunRetention(t, openSQLiteRepository(t, filepath.Join(t.TempDir(), "runs.db")))
+}
@@ -0,0 +1,207 @@
+// backend/internal/simulation/runs.go
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/google/uuid"
+)
+
+// ErrRunNotFound wird zurückgegeben, wenn eine Simulation keinen Lauf mit der ID hat
+var ErrRunNotFound = errors.New("Lauf nicht gefunden")
+
+// SimulationRun ist ein Lauf einer Simulation. Jeder Start legt einen neuen Lauf mit
+// eigenen Events, Ressourcen, Ergebnissen und Seed an; die Simulation selbst zeigt
+// immer den Stand ihres aktuellen Laufs.
+type SimulationRun struct {
+	ID              string                 `json:"id"`
+	SimulationID    string                 `json:"simulationId"`
+	Number          int                    `json:"number"`
+	Status          Status                 `json:"status"`
+	Seed            int64                  `json:"seed"`
+	Parameters      map[string]interface{} `json:"parameters,omitempty"`
+	StartTime       *time.Time             `json:"startTime,omitempty"`
+	EndTime         *time.Time             `json:"endTime,omitempty"`
+	Progress        float64                `json:"progress"`
+	ThreatsDetected int                    `json:"threatsDetected"`
+	Results         *SimulationResults     `json:"results,omitempty"`
+	Error           string                 `json:"error,omitempty"`
+	CreatedAt       time.Time              `json:"createdAt"`
+}
+
+// currentRun gibt den aktuellen Lauf einer Simulation mit ihrem Stand zurück
+func currentRun(simulation *Simulation, createdAt time.Time) *SimulationRun {
+	return &SimulationRun{
+		ID:              simulation.RunID,
+		SimulationID:    simulation.ID,
+		Number:          simulation.RunNumber,
+		Status:          simulation.Status,
+		Seed:            simulation.Seed,
+		Parameters:      simulation.Parameters,
+		StartTime:       simulation.StartTime,
+		EndTime:         simulation.EndTime,
+		Progress:        simulation.Progress,
+		ThreatsDetected: simulation.ThreatsDetected,
+		Results:         simulation.Results,
+		Error:           simulation.Error,
+		CreatedAt:       createdAt,
+	}
+}
+
+// beginRunLocked legt einen neuen Lauf mit dem angegebenen Seed an und setzt den Stand
+// der Simulation zurück; Events und Ressourcen früherer Läufe bleiben im Store.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) beginRunLocked(simulation *Simulation, seed int64) {
+	id := simulation.ID
+
+	simulation.RunID = uuid.New().String()
+	simulation.RunNumber++
+	simulation.Seed = seed
+	simulation.StartTime = nil
+	simulation.EndTime = nil
+	simulation.Progress = 0
+	simulation.ThreatsDetected = 0
+	simulation.Results = nil
+	simulation.Error = ""
+	simulation.PausedAt = nil
+	simulation.PausedDuration = 0
+
+	e.events[id] = []SimulationEvent{}
+	e.affectedResources[id] = []AffectedResource{}
+	e.saveRunLocked(simulation)
+}
+
+// saveRunLocked schreibt den aktuellen Lauf einer Simulation in den Store. Der Store
+// behält den Zeitpunkt, zu dem der Lauf zuerst gespeichert wurde. Der Aufrufer muss
+// e.mutex halten.
+func (e *Engine) saveRunLocked(simulation *Simulation) {
+	if simulation.RunID == "" {
+		return
+	}
+	run := currentRun(simulation, e.clock.Now())
+	e.queueWriteLocked(func(store Store) {
+		if err := store.SaveRun(run); err != nil {
+			logging.Logger.Errorf("Lauf %d der Simulation %s konnte nicht gespeichert werden: %v", run.Number, run.SimulationID, err)
+		}
+	})
+}
+
+// runIDLocked gibt den aktuellen Lauf einer Simulation zurück, an den neue Events und
+// Ressourcen gehängt werden. Der Aufrufer muss e.mutex halten.
+func (e *Engine) runIDLocked(simulationID string) string {
+	if simulation, exists := e.simulations[simulationID]; exists {
+		return simulation.RunID
+	}
+	return ""
+}
+
+// GetRuns gibt alle Läufe einer Simulation zurück, den ersten zuerst. Der aktuelle Lauf
+// zeigt den Stand der Simulation.
+func (e *Engine) GetRuns(simulationID string) ([]*SimulationRun, error) {
+	e.ensureLoaded(simulationID)
+
+	runs, err := e.store.GetRuns(simulationID)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	simulation, exists := e.simulations[simulationID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, simulationID)
+	}
+	for i, run := range runs {
+		if run.ID == simulation.RunID {
+			runs[i] = currentRun(simulation, run.CreatedAt)
+		}
+	}
+	return runs, nil
+}
+
+// GetRun gibt einen Lauf einer Simulation zurück
+func (e *Engine) GetRun(simulationID, runID string) (*SimulationRun, error) {
+	runs, err := e.GetRuns(simulationID)
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs {
+		if run.ID == runID {
+			return run, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+}
+
+// GetRunEvents gibt die Events eines Laufs zurück
+func (e *Engine) GetRunEvents(simulationID, runID string) ([]SimulationEvent, error) {
+	if _, err := e.GetRun(simulationID, runID); err != nil {
+		return nil, err
+	}
+	return e.store.GetEvents(simulationID, runID)
+}
+
+// GetRunResources gibt die betroffenen Ressourcen eines Laufs zurück
+func (e *Engine) GetRunResources(simulationID, runID string) ([]AffectedResource, error) {
+	if _, err := e.GetRun(simulationID, runID); err != nil {
+		return nil, err
+	}
+	return e.store.GetAffectedResources(simulationID, runID)
+}
+
+// CloneSimulation legt eine neue Simulation mit der Konfiguration einer bestehenden an.
+// Gesetzte Felder in overrides ersetzen die kopierten Werte; Parameter werden einzeln
+// überschrieben, ein Parameter mit dem Wert null wird entfernt. Ohne eigenen Seed
+// übernimmt die Kopie den Seed des aktuellen Laufs, damit ein Vorher-Nachher-Vergleich
+// dieselben Zufallsentscheidungen trifft.
+func (e *Engine) CloneSimulation(id string, overrides SimulationConfig) (*Simulation, error) {
+	source, err := e.GetSimulation(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, id)
+	}
+
+	e.mutex.RLock()
+	config := SimulationConfig{
+		Name:             source.Name + " (Kopie)",
+		Description:      source.Description,
+		InfrastructureID: source.InfrastructureID,
+		ScenarioID:       source.ScenarioID,
+		Parameters:       map[string]interface{}{ParameterSeed: float64(source.Seed)},
+	}
+	for name, value := range source.Parameters {
+		config.Parameters[name] = value
+	}
+	e.mutex.RUnlock()
+
+	if overrides.Name != "" {
+		config.Name = overrides.Name
+	}
+	if overrides.Description != "" {
+		config.Description = overrides.Description
+	}
+	if overrides.InfrastructureID != "" {
+		config.InfrastructureID = overrides.InfrastructureID
+	}
+	if overrides.ScenarioID != "" {
+		config.ScenarioID = overrides.ScenarioID
+	}
+	for name, value := range overrides.Parameters {
+		if value == nil {
+			delete(config.Parameters, name)
+		} else {
+			config.Parameters[name] = value
+		}
+	}
+
+	clone, err := e.createSimulation(config, id)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Logger.Infof("Simulation '%s' (ID: %s) aus %s kopiert", clone.Name, clone.ID, id)
+	return clone, nil
+}
@@ -0,0 +1,176 @@
+// backend/internal/simulation/runs_test.go
+package simulation
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// rerun startet einen weiteren Lauf einer Simulation und wartet auf seinen Abschluss
+func rerun(t *testing.T, engine *Engine, id string) {
+	t.Helper()
+
+	if _, err := engine.StartSimulation(id); err != nil {
+		t.Fatalf("Fehler beim erneuten Starten der Simulation: %v", err)
+	}
+	waitFor(t, "Abschluss des Laufs", func() bool {
+		status, _ := engine.GetSimulationStatus(id)
+		return status.Status == StatusCompleted
+	})
+}
+
+// assertSeparateRuns prüft, dass jeder Start einen eigenen Lauf mit eigenen Events,
+// Ressourcen und Ergebnissen anlegt
+func assertSeparateRuns(t *testing.T, store Store) {
+	t.Helper()
+
+	engine := NewEngine(WithStore(store))
+	sim := runInstant(t, engine, SimulationConfig{Name: "Läufe", ScenarioID: "scenario-2"})
+	firstRun, firstSeed := sim.RunID, sim.Seed
+	firstEvents, _ := engine.GetEvents(sim.ID)
+	firstResources, _ := engine.GetAffectedResources(sim.ID)
+
+	rerun(t, engine, sim.ID)
+	if sim.RunNumber != 2 || sim.RunID == firstRun {
+		t.Fatalf("Erwartet: zweiter Lauf mit neuer ID, Erhalten: Lauf %d (%s)", sim.RunNumber, sim.RunID)
+	}
+	if sim.Seed == firstSeed {
+		t.Fatal("Ohne Parameter seed sollte jeder Lauf einen neuen Seed erhalten")
+	}
+
+	// Die Simulation zeigt nur den aktuellen Lauf
+	events, _ := engine.GetEvents(sim.ID)
+	if events[0].Sequence != 1 || events[0].Description != "Simulation gestartet" {
+		t.Fatalf("Der zweite Lauf sollte mit eigenen Events beginnen, erstes Event: %d %q", events[0].Sequence, events[0].Description)
+	}
+	for _, event := range events {
+		if event.RunID != sim.RunID {
+			t.Fatalf("Event %s gehört zu Lauf %s", event.ID, event.RunID)
+		}
+	}
+
+	runs, err := engine.GetRuns(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen der Läufe: %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != firstRun || runs[1].ID != sim.RunID {
+		t.Fatalf("Erwartet: 2 Läufe in Startreihenfolge, Erhalten: %d", len(runs))
+	}
+	for _, run := range runs {
+		if run.Status != StatusCompleted || run.Results == nil {
+			t.Fatalf("Lauf %d sollte mit Bericht abgeschlossen sein, Status: %s", run.Number, run.Status)
+		}
+	}
+	if runs[0].Seed != firstSeed {
+		t.Fatalf("Erwarteter Seed des ersten Laufs: %d, Erhalten: %d", firstSeed, runs[0].Seed)
+	}
+
+	// Events und Ressourcen des ersten Laufs bleiben abrufbar
+	stored, err := engine.GetRunEvents(sim.ID, firstRun)
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen der Events des ersten Laufs: %v", err)
+	}
+	if !reflect.DeepEqual(eventIDs(stored), eventIDs(firstEvents)) {
+		t.Fatalf("Erwartet: %d Events des ersten Laufs, Erhalten: %d", len(firstEvents), len(stored))
+	}
+	if runs[0].Results.EventsCount != len(firstEvents) {
+		t.Fatalf("Der Bericht des ersten Laufs zählt %d statt %d Events", runs[0].Results.EventsCount, len(firstEvents))
+	}
+	if resources, _ := engine.GetRunResources(sim.ID, firstRun); len(resources) != len(firstResources) {
+		t.Fatalf("Erwartet: %d Ressourcen des ersten Laufs, Erhalten: %d", len(firstResources), len(resources))
+	}
+	if _, err := engine.GetRunEvents(sim.ID, "kein-lauf"); !errors.Is(err, ErrRunNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrRunNotFound, err)
+	}
+
+	// Nach einem Neustart lädt die Engine nur den aktuellen Lauf
+	reloaded := NewEngine(WithStore(store))
+	if events, _ := reloaded.GetEvents(sim.ID); !reflect.DeepEqual(eventIDs(events), eventIDs(engine.events[sim.ID])) {
+		t.Fatalf("Erwartet: %d Events des aktuellen Laufs, Erhalten: %d", len(engine.events[sim.ID]), len(events))
+	}
+}
+
+func TestSeparateRunsInMemory(t *testing.T) {
+	assertSeparateRuns(t, NewMemoryStore())
+}
+
+func TestSeparateRunsInSQLite(t *testing.T) {
+	assertSeparateRuns(t, openSQLiteRepository(t, filepath.Join(t.TempDir(), "aegis.db")))
+}
+
+func TestCloneSimulation(t *testing.T) {
+	engine := NewEngine()
+	source := runInstant(t, engine, SimulationConfig{
+		Name:       "Vorher",
+		ScenarioID: "scenario-2",
+		Parameters: map[string]interface{}{"note": "kopieren"},
+	})
+
+	clone, err := engine.CloneSimulation(source.ID, SimulationConfig{
+		Name:       "Nachher",
+		Parameters: map[string]interface{}{"note": nil},
+	})
+	if err != nil {
+		t.Fatalf("Fehler beim Kopieren der Simulation: %v", err)
+	}
+	if clone.Name != "Nachher" || clone.ScenarioID != source.ScenarioID || clone.ClonedFrom != source.ID {
+		t.Fatalf("Unerwartete Kopie: %+v", clone)
+	}
+	if _, exists := clone.Parameters["note"]; exists {
+		t.Fatal("Der Parameter note sollte entfernt sein")
+	}
+
+	// Mit demselben Seed trifft die Kopie dieselben Zufallsentscheidungen
+	if clone.Seed != source.Seed {
+		t.Fatalf("Erwarteter Seed: %d, Erhalten: %d", source.Seed, clone.Seed)
+	}
+	rerun(t, engine, clone.ID)
+	if !reflect.DeepEqual(attackTrace(t, engine, clone.ID), attackTrace(t, engine, source.ID)) {
+		t.Fatal("Die Kopie sollte denselben Ablauf wie das Original haben")
+	}
+
+	if _, err := engine.CloneSimulation(source.ID, SimulationConfig{Parameters: map[string]interface{}{ParameterSpeed: "sehr schnell"}}); !errors.Is(err, ErrInvalidParameter) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrInvalidParameter, err)
+	}
+	if _, err := engine.CloneSimulation("unbekannt", SimulationConfig{}); !errors.Is(err, ErrSimulationNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrSimulationNotFound, err)
+	}
+}
+
+func TestRestartWithFullQueueKeepsPreviousRun(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock), WithWorkerPool(1, 0))
+	finished := runInstant(t, engine, SimulationConfig{Name: "Abgeschlossen"})
+	before, _ := engine.GetEvents(finished.ID)
+
+	// Eine zweite Simulation belegt den einzigen Worker, ohne dass die Zeit fortschreitet
+	busy, err := engine.CreateSimulation(SimulationConfig{Name: "Belegt"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	waitFor(t, "freier Worker", func() bool {
+		_, err := engine.StartSimulation(busy.ID)
+		return err == nil
+	})
+	defer engine.StopSimulation(busy.ID)
+
+	if _, err := engine.StartSimulation(finished.ID); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrQueueFull, err)
+	}
+
+	// Der abgelehnte Start lässt den abgeschlossenen Lauf unverändert
+	sim, _ := engine.GetSimulation(finished.ID)
+	if sim.Status != StatusCompleted || sim.RunNumber != 1 || sim.Results == nil {
+		t.Fatalf("Unerwarteter Stand nach dem abgelehnten Start: %+v", sim)
+	}
+	if after, _ := engine.GetEvents(finished.ID); len(after) != len(before) {
+		t.Fatalf("Erwartete Events: %d, Erhalten: %d", len(before), len(after))
+	}
+	runs, err := engine.GetRuns(finished.ID)
+	if err != nil || len(runs) != 1 || runs[0].Results == nil {
+		t.Fatalf("Erwartet: 1 Lauf mit Ergebnissen, Erhalten: %+v (%v)", runs, err)
+	}
+}
@@ -0,0 +1,458 @@
+// backend/internal/simulation/scenario_file.go
+package simulation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// scenarioFileVersion ist die aktuelle Version des Dateiformats
+const scenarioFileVersion = 1
+
+// scenarioIDPattern beschreibt IDs, die ein Szenario in einer Datei tragen kann.
+// Sie werden Teil von URLs und Dateinamen.
+var scenarioIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// maxScenarioIDLength ist die Länge der Spalte scenarios.id in Postgres
+const maxScenarioIDLength = 36
+
+// scenarioIDProblem gibt an, was gegen eine ID aus einer Datei spricht, oder "" für eine gültige ID
+func scenarioIDProblem(id string) string {
+	switch {
+	case !scenarioIDPattern.MatchString(id):
+		return fmt.Sprintf("ungültige ID %q", id)
+	case len(id) > maxScenarioIDLength:
+		return fmt.Sprintf("die ID %q ist länger als %d Zeichen", id, maxScenarioIDLength)
+	}
+	return ""
+}
+
+// yamlLinePattern trennt die Zeilennummer von den Fehlermeldungen des YAML-Parsers
+var yamlLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// yamlUnknownFieldPattern erkennt Felder, die das Dateiformat nicht kennt
+var yamlUnknownFieldPattern = regexp.MustCompile(`^field (\S+) not found in type`)
+
+// scenarioFile ist ein Szenario im YAML-Dateiformat:
+//
+//	version: 1
+//	metadata:
+//	  id: phishing-kampagne
+//	  name: Phishing-Kampagne
+//	  difficulty: medium
+//	steps:
+//	  - name: Erstzugriff
+//	    duration: 10m
+//	    techniques: [T1566.001]
+//	    targets:
+//	      nodeTypes: [workstation]
+//	    eventTypes: [exploitation]
+//	    successProbability: 0.6
+//	  - name: Ausbreitung
+//	    duration: 1h
+//	    preconditions: [Erstzugriff]
+//	    targets:
+//	      tags: [finance]
+//	    eventTypes: [lateral_movement]
+//	    successProbability: 0.4
+//
+// Dauern sind Go-Dauern wie 90s, 10m oder 1h30m oder ganze Sekunden.
+type scenarioFile struct {
+	Version  int                  `yaml:"version"`
+	Metadata scenarioFileMetadata `yaml:"metadata"`
+	Steps    []scenarioFileStep   `yaml:"steps"`
+}
+
+// scenarioFileMetadata enthält die Angaben zum Szenario selbst
+type scenarioFileMetadata struct {
+	ID          string     `yaml:"id,omitempty"`
+	Name        string     `yaml:"name"`
+	Description string     `yaml:"description,omitempty"`
+	Difficulty  Difficulty `yaml:"difficulty,omitempty"`
+}
+
+// scenarioFileStep ist ein Schritt im Dateiformat
+type scenarioFileStep struct {
+	Name               string          `yaml:"name"`
+	Description        string          `yaml:"description,omitempty"`
+	Duration           string          `yaml:"duration"`
+	Techniques         []string        `yaml:"techniques,omitempty"`
+	Preconditions      []string        `yaml:"preconditions,omitempty"`
+	Targets            *TargetSelector `yaml:"targets,omitempty"`
+	EventTypes         []EventType     `yaml:"eventTypes,omitempty"`
+	Severities         []Severity      `yaml:"severities,omitempty"`
+	SuccessProbability *float64        `yaml:"successProbability"`
+	Impact             ResourceStatus  `yaml:"impact,omitempty"`
+	Actions            []string        `yaml:"actions,omitempty"`
+}
+
+// ScenarioFileError ist ein Fehler in einer Szenariodatei. Field ist der Pfad des
+// betroffenen Felds, etwa steps[1].duration, sofern er sich bestimmen lässt.
+type ScenarioFileError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ScenarioFileErrors sind alle Fehler einer Szenariodatei, nach Zeilen sortiert
+type ScenarioFileErrors []ScenarioFileError
+
+func (e ScenarioFileErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, problem := range e {
+		messages[i] = fmt.Sprintf("Zeile %d: %s", problem.Line, problem.Message)
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidParameter, strings.Join(messages, "; "))
+}
+
+// Unwrap ordnet Fehler in Szenariodateien den ungültigen Parametern zu
+func (e ScenarioFileErrors) Unwrap() error {
+	return ErrInvalidParameter
+}
+
+// parseScenarioFile liest ein Szenario aus einer YAML-Datei und prüft es vollständig.
+// Alle gefundenen Fehler werden mit ihrer Zeile als ScenarioFileErrors gemeldet.
+func parseScenarioFile(data []byte) (*Scenario, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, yamlErrors(err)
+	}
+	if len(root.Content) == 0 {
+		return nil, ScenarioFileErrors{{Line: 1, Message: "die Datei enthält kein Szenario"}}
+	}
+
+	var file scenarioFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && err != io.EOF {
+		return nil, yamlErrors(err)
+	}
+
+	// Felder, die schon beim Einlesen fehlschlagen, werden nicht ein zweites Mal gemeldet
+	scenario, problems := file.toScenario()
+	reported := make(map[string]bool)
+	for _, problem := range problems {
+		reported[problem.Path] = true
+	}
+	for _, problem := range scenario.problems() {
+		if !reported[problem.Path] {
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) == 0 {
+		return scenario, nil
+	}
+
+	errs := make(ScenarioFileErrors, 0, len(problems))
+	for _, problem := range problems {
+		path := scenarioFilePath(problem.Path)
+		errs = append(errs, ScenarioFileError{Line: lineOf(&root, path), Field: path, Message: problem.Message})
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+	return nil, errs
+}
+
+// toScenario übernimmt die Datei in ein Szenario. Zurückgegeben werden zusätzlich die
+// Fehler, die nur im Dateiformat auftreten können.
+func (f *scenarioFile) toScenario() (*Scenario, []scenarioProblem) {
+	var problems []scenarioProblem
+	if f.Version != 0 && f.Version != scenarioFileVersion {
+		problems = append(problems, scenarioProblem{Path: "version", Message: fmt.Sprintf("unbekannte Version %d des Dateiformats", f.Version)})
+	}
+	if f.Metadata.ID != "" {
+		if problem := scenarioIDProblem(f.Metadata.ID); problem != "" {
+			problems = append(problems, scenarioProblem{Path: "id", Message: problem})
+		}
+	}
+
+	scenario := &Scenario{
+		ID:          f.Metadata.ID,
+		Name:        f.Metadata.Name,
+		Description: f.Metadata.Description,
+		Difficulty:  f.Metadata.Difficulty,
+	}
+	for i, raw := range f.Steps {
+		step := ScenarioStep{
+			Name:          raw.Name,
+			Description:   raw.Description,
+			Techniques:    raw.Techniques,
+			Preconditions: raw.Preconditions,
+			Targets:       raw.Targets,
+			EventTypes:    raw.EventTypes,
+			Severities:    raw.Severities,
+			Impact:        raw.Impact,
+			Actions:       raw.Actions,
+		}
+		if raw.Duration != "" {
+			seconds, err := parseStepDuration(raw.Duration)
+			if err != nil {
+				problems = append(problems, scenarioProblem{Path: fmt.Sprintf("steps[%d].duration", i), Message: err.Error()})
+			}
+			step.Duration = seconds
+		}
+		if raw.SuccessProbability == nil {
+			problems = append(problems, scenarioProblem{Path: fmt.Sprintf("steps[%d]", i), Message: fmt.Sprintf("Schritt %d braucht eine Erfolgswahrscheinlichkeit", i+1)})
+		} else {
+			step.SuccessProbability = *raw.SuccessProbability
+		}
+		scenario.Steps = append(scenario.Steps, step)
+	}
+	return scenario, problems
+}
+
+// parseStepDuration liest die Dauer eines Schritts als Go-Dauer oder in ganzen Sekunden
+func parseStepDuration(text string) (int, error) {
+	if seconds, err := strconv.Atoi(text); err == nil {
+		return seconds, nil
+	}
+	duration, err := time.ParseDuration(text)
+	if err != nil {
+		return 0, fmt.Errorf("ungültige Dauer %q, erwartet z. B. 90s, 10m oder 1h", text)
+	}
+	if duration%time.Second != 0 {
+		return 0, fmt.Errorf("die Dauer %q muss aus ganzen Sekunden bestehen", text)
+	}
+	return int(duration / time.Second), nil
+}
+
+// formatStepDuration schreibt die Dauer eines Schritts ohne überflüssige Nullen, etwa 1h30m
+func formatStepDuration(seconds int) string {
+	text := (time.Duration(seconds) * time.Second).String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+// scenarioFilePath übersetzt den Pfad eines Felds im Szenario in den Pfad in der Datei
+func scenarioFilePath(path string) string {
+	switch path {
+	case "id", "name", "description", "difficulty":
+		return "metadata." + path
+	}
+	return path
+}
+
+// lineOf gibt die Zeile des Felds mit dem Pfad zurück. Fehlt das Feld, wird die Zeile
+// des nächsten vorhandenen übergeordneten Felds verwendet.
+func lineOf(root *yaml.Node, path string) int {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line := node.Line
+
+	for _, segment := range strings.Split(path, ".") {
+		key, index := segment, -1
+		if open := strings.Index(segment, "["); open >= 0 {
+			key = segment[:open]
+			index, _ = strconv.Atoi(strings.TrimSuffix(segment[open+1:], "]"))
+		}
+
+		child := mappingValue(node, key)
+		if child == nil {
+			return line
+		}
+		node, line = child, child.Line
+		if index >= 0 {
+			if child.Kind != yaml.SequenceNode || index >= len(child.Content) {
+				return line
+			}
+			node = child.Content[index]
+			line = node.Line
+		}
+	}
+	return line
+}
+
+// mappingValue gibt den Wert zu einem Schlüssel eines YAML-Mappings zurück
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlErrors übersetzt Fehler des YAML-Parsers in Fehler mit Zeilennummern
+func yamlErrors(err error) ScenarioFileErrors {
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+
+	errs := make(ScenarioFileErrors, 0, len(messages))
+	for _, message := range messages {
+		problem := ScenarioFileError{Line: 1, Message: strings.TrimPrefix(message, "yaml: ")}
+		if match := yamlLinePattern.FindStringSubmatch(message); match != nil {
+			problem.Line, _ = strconv.Atoi(match[1])
+			problem.Message = match[2]
+		}
+		if match := yamlUnknownFieldPattern.FindStringSubmatch(problem.Message); match != nil {
+			problem.Message = fmt.Sprintf("unbekanntes Feld %s", match[1])
+		}
+		errs = append(errs, problem)
+	}
+	return errs
+}
+
+// marshalScenarioFile schreibt ein Szenario im YAML-Dateiformat
+func marshalScenarioFile(scenario *Scenario) ([]byte, error) {
+	file := scenarioFile{
+		Version: scenarioFileVersion,
+		Metadata: scenarioFileMetadata{
+			ID:          scenario.ID,
+			Name:        scenario.Name,
+			Description: scenario.Description,
+			Difficulty:  scenario.Difficulty,
+		},
+	}
+	for _, step := range scenario.Steps {
+		probability := step.SuccessProbability
+		file.Steps = append(file.Steps, scenarioFileStep{
+			Name:               step.Name,
+			Description:        step.Description,
+			Duration:           formatStepDuration(step.Duration),
+			Techniques:         step.Techniques,
+			Preconditions:      step.Preconditions,
+			Targets:            step.Targets,
+			EventTypes:         step.EventTypes,
+			Severities:         step.Severities,
+			SuccessProbability: &probability,
+			Impact:             step.Impact,
+			Actions:            step.Actions,
+		})
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(file); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// ImportScenario legt ein Szenario aus einer YAML-Datei an. Trägt die Datei eine ID,
+// wird ein vorhandenes eigenes Szenario mit dieser ID ersetzt, sodass dieselbe Datei
+// wiederholt importiert werden kann.
+func (e *Engine) ImportScenario(data []byte) (*Scenario, error) {
+	scenario, err := parseScenarioFile(data)
+	if err != nil {
+		return nil, err
+	}
+	return e.importScenario(scenario)
+}
+
+// importScenario speichert ein geprüftes Szenario aus einer Datei
+func (e *Engine) importScenario(scenario *Scenario) (*Scenario, error) {
+	if scenario.ID == "" {
+		return e.CreateScenario(*scenario)
+	}
+	if builtInScenario(scenario.ID) != nil {
+		return nil, fmt.Errorf("%w: %s", ErrScenarioReadOnly, scenario.ID)
+	}
+
+	existing, err := e.store.GetScenario(scenario.ID)
+	switch {
+	case err == nil:
+		if unchangedScenario(existing, *scenario) {
+			return existing, nil
+		}
+		return e.UpdateScenario(scenario.ID, *scenario)
+	case errors.Is(err, ErrScenarioNotFound):
+		return e.createScenario(scenario.ID, *scenario)
+	default:
+		return nil, err
+	}
+}
+
+// unchangedScenario gibt an, ob ein Szenario aus einer Datei dem gespeicherten gleicht.
+// Beim Laden des Szenarioverzeichnisses bleibt ein unverändertes Szenario so unberührt.
+func unchangedScenario(existing *Scenario, scenario Scenario) bool {
+	scenario.ID = existing.ID
+	scenario.BuiltIn = existing.BuiltIn
+	scenario.CreatedAt = existing.CreatedAt
+	scenario.UpdatedAt = existing.UpdatedAt
+	scenario.normalize()
+	return reflect.DeepEqual(&scenario, existing)
+}
+
+// ExportScenario gibt ein Szenario im YAML-Dateiformat zurück
+func (e *Engine) ExportScenario(id string) ([]byte, error) {
+	scenario, err := e.GetScenario(id)
+	if err != nil {
+		return nil, err
+	}
+	return marshalScenarioFile(scenario)
+}
+
+// LoadScenarioDirectory importiert alle Szenariodateien (*.yaml, *.yml) eines
+// Verzeichnisses in alphabetischer Reihenfolge. Dateien ohne ID erhalten den
+// Dateinamen ohne Endung als ID. Fehlerhafte Dateien werden protokolliert und
+// übersprungen; zurückgegeben wird die Anzahl der geladenen Szenarien.
+func (e *Engine) LoadScenarioDirectory(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("Szenarioverzeichnis %s konnte nicht gelesen werden: %v", dir, err)
+	}
+
+	loaded := 0
+	for _, entry := range entries {
+		extension := filepath.Ext(entry.Name())
+		if entry.IsDir() || extension != ".yaml" && extension != ".yml" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if err := e.loadScenarioFile(path, strings.TrimSuffix(entry.Name(), extension)); err != nil {
+			logging.Logger.Errorf("Szenariodatei %s wurde nicht geladen: %v", path, err)
+			continue
+		}
+		loaded++
+	}
+	return loaded, nil
+}
+
+// loadScenarioFile importiert eine Szenariodatei; defaultID gilt für Dateien ohne ID
+func (e *Engine) loadScenarioFile(path, defaultID string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	scenario, err := parseScenarioFile(data)
+	if err != nil {
+		return err
+	}
+	if scenario.ID == "" {
+		if problem := scenarioIDProblem(defaultID); problem != "" {
+			return fmt.Errorf("%w: der Dateiname ergibt keine gültige ID: %s", ErrInvalidParameter, problem)
+		}
+		scenario.ID = defaultID
+	}
+	_, err = e.importScenario(scenario)
+	return err
+}
@@ -0,0 +1,209 @@
+// backend/internal/simulation/scenario_file_test.go
+package simulation
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScenarioFileRoundTrip(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "scenarios", "phishing-kampagne.yaml"))
+	if err != nil {
+		t.Fatalf("Fehler beim Lesen der Beispieldatei: %v", err)
+	}
+	scenario, err := parseScenarioFile(data)
+	if err != nil {
+		t.Fatalf("Die Beispieldatei ist ungültig: %v", err)
+	}
+	if scenario.ID != "phishing-kampagne" || len(scenario.Steps) != 3 || scenario.Steps[1].Duration != 1200 {
+		t.Fatalf("Unerwartetes Szenario: %+v", scenario)
+	}
+	if step := scenario.Steps[2]; step.Preconditions[0] != "Erstzugriff" || step.Targets.NodeTypes[0] != "server" {
+		t.Fatalf("Unerwarteter Schritt: %+v", step)
+	}
+
+	// Ein exportiertes Szenario lässt sich unverändert wieder einlesen
+	scenario.Steps[0].Duration = 5400
+	exported, err := marshalScenarioFile(scenario)
+	if err != nil {
+		t.Fatalf("Fehler beim Exportieren: %v", err)
+	}
+	reimported, err := parseScenarioFile(exported)
+	if err != nil {
+		t.Fatalf("Das exportierte Szenario ist ungültig: %v\n%s", err, exported)
+	}
+	if !reflect.DeepEqual(reimported.Steps, scenario.Steps) || reimported.Name != scenario.Name {
+		t.Fatalf("Erwartet: %+v, Erhalten: %+v", scenario.Steps, reimported.Steps)
+	}
+	if formatStepDuration(5400) != "1h30m" || formatStepDuration(3600) != "1h" || formatStepDuration(90) != "1m30s" {
+		t.Fatalf("Unerwartete Dauern: %s, %s, %s", formatStepDuration(5400), formatStepDuration(3600), formatStepDuration(90))
+	}
+}
+
+func TestScenarioFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		expected []ScenarioFileError
+	}{
+		{
+			name:     "Syntaxfehler",
+			file:     "metadata:\n  name: Kaputt\nsteps:\n\t- name: Tabulator\n",
+			expected: []ScenarioFileError{{Line: 4}},
+		},
+		{
+			name:     "unbekanntes Feld",
+			file:     "metadata:\n  name: Tippfehler\nsteps:\n  - name: Eins\n    duration: 1m\n    sucessProbability: 0.5\n",
+			expected: []ScenarioFileError{{Line: 6, Message: "unbekanntes Feld sucessProbability"}},
+		},
+		{
+			name: "ungültige Werte",
+			file: `version: 1
+metadata:
+  id: mit leerzeichen
+  name: Fehlerhaft
+  difficulty: extrem
+steps:
+  - name: Eins
+    duration: zehn Minuten
+    successProbability: 0.5
+  - name: Zwei
+    duration: 5m
+    preconditions: [Drei]
+    eventTypes: [discovery, defense]
+    successProbability: 1.5
+`,
+			expected: []ScenarioFileError{
+				{Line: 3, Field: "metadata.id"},
+				{Line: 5, Field: "metadata.difficulty"},
+				{Line: 8, Field: "steps[0].duration"},
+				{Line: 12, Field: "steps[1].preconditions[0]"},
+				{Line: 13, Field: "steps[1].eventTypes[1]"},
+				{Line: 14, Field: "steps[1].successProbability"},
+			},
+		},
+		{
+			name:     "zu lange ID",
+			file:     "metadata:\n  name: Lang\n  id: eine-szenario-id-mit-mehr-als-36-zeichen\nsteps:\n  - name: Eins\n    duration: 1m\n    successProbability: 0.5\n",
+			expected: []ScenarioFileError{{Line: 3, Field: "metadata.id"}},
+		},
+		{
+			name: "fehlende Angaben",
+			file: "metadata:\n  description: ohne Namen\nsteps:\n  - name: Eins\n    duration: 1m\n",
+			expected: []ScenarioFileError{
+				{Line: 2, Field: "metadata.name"},
+				{Line: 4, Field: "steps[0]"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		_, err := parseScenarioFile([]byte(test.file))
+		var errs ScenarioFileErrors
+		if !errors.As(err, &errs) || !errors.Is(err, ErrInvalidParameter) {
+			t.Fatalf("%s: Erwartet: Fehler in der Datei, Erhalten: %v", test.name, err)
+		}
+		if len(errs) != len(test.expected) {
+			t.Fatalf("%s: Erwartete Fehler: %d, Erhaltene Fehler: %+v", test.name, len(test.expected), errs)
+		}
+		for i, expected := range test.expected {
+			got := errs[i]
+			if got.Line != expected.Line || expected.Field != "" && got.Field != expected.Field ||
+				expected.Message != "" && got.Message != expected.Message {
+				t.Fatalf("%s: Erwarteter Fehler %d: %+v, Erhalten: %+v", test.name, i+1, expected, got)
+			}
+		}
+	}
+}
+
+func TestLoadScenarioDirectory(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("Fehler beim Schreiben von %s: %v", name, err)
+		}
+	}
+	write("ohne-id.yaml", "metadata:\n  name: Ohne ID\nsteps:\n  - name: Eins\n    duration: 1m\n    successProbability: 0.5\n")
+	write("kaputt.yml", "metadata:\n  name: Kaputt\nsteps: []\n")
+	write("ein-dateiname-mit-mehr-als-36-zeichen.yaml", "metadata:\n  name: Zu lang\nsteps:\n  - name: Eins\n    duration: 1m\n    successProbability: 0.5\n")
+	write("notizen.txt", "kein Szenario")
+
+	engine := NewEngine()
+	loaded, err := engine.LoadScenarioDirectory(dir)
+	if err != nil || loaded != 1 {
+		t.Fatalf("Erwartet: 1 geladenes Szenario, Erhalten: %d (%v)", loaded, err)
+	}
+	scenario, err := engine.GetScenario("ohne-id")
+	if err != nil {
+		t.Fatalf("Das Szenario sollte den Dateinamen als ID erhalten: %v", err)
+	}
+
+	// Ein erneuter Import mit derselben ID ersetzt das Szenario
+	reimported, err := engine.ImportScenario([]byte("metadata:\n  id: ohne-id\n  name: Geändert\nsteps:\n  - name: Eins\n    duration: 2m\n    successProbability: 1\n"))
+	if err != nil {
+		t.Fatalf("Fehler beim erneuten Import: %v", err)
+	}
+	if reimported.Name != "Geändert" || reimported.Duration != 120 || !reimported.CreatedAt.Equal(scenario.CreatedAt) {
+		t.Fatalf("Unerwartetes Szenario nach dem erneuten Import: %+v", reimported)
+	}
+	if scenarios, _ := engine.GetScenarios(); len(scenarios) != len(builtInScenarios())+1 {
+		t.Fatalf("Der erneute Import sollte kein weiteres Szenario anlegen, Erhalten: %d", len(scenarios))
+	}
+
+	// Mitgelieferte Szenarien lassen sich exportieren, aber nicht überschreiben
+	exported, err := engine.ExportScenario("scenario-1")
+	if err != nil {
+		t.Fatalf("Fehler beim Exportieren: %v", err)
+	}
+	if _, err := engine.ImportScenario(exported); !errors.Is(err, ErrScenarioReadOnly) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrScenarioReadOnly, err)
+	}
+	if _, err := engine.ExportScenario("unbekannt"); !errors.Is(err, ErrScenarioNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrScenarioNotFound, err)
+	}
+	if _, err := engine.LoadScenarioDirectory(filepath.Join(dir, "fehlt")); err == nil {
+		t.Fatal("Ein fehlendes Verzeichnis sollte einen Fehler liefern")
+	}
+}
+
+// assertUnchangedScenarioFiles prüft, dass ein erneutes Laden unveränderter Dateien die
+// gespeicherten Szenarien nicht anfasst
+func assertUnchangedScenarioFiles(t *testing.T, store Store) {
+	t.Helper()
+
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock), WithStore(store))
+	dir := filepath.Join("..", "..", "scenarios")
+	if _, err := engine.LoadScenarioDirectory(dir); err != nil {
+		t.Fatalf("Fehler beim Laden des Szenarioverzeichnisses: %v", err)
+	}
+	loaded, err := engine.GetScenario("phishing-kampagne")
+	if err != nil {
+		t.Fatalf("Fehler beim Laden des Szenarios: %v", err)
+	}
+
+	// Ein Neustart lädt dieselben Dateien erneut
+	clock.Advance(time.Hour)
+	if _, err := engine.LoadScenarioDirectory(dir); err != nil {
+		t.Fatalf("Fehler beim erneuten Laden des Szenarioverzeichnisses: %v", err)
+	}
+	reloaded, err := engine.GetScenario("phishing-kampagne")
+	if err != nil {
+		t.Fatalf("Fehler beim Laden des Szenarios: %v", err)
+	}
+	if !reloaded.UpdatedAt.Equal(loaded.UpdatedAt) {
+		t.Fatalf("Das unveränderte Szenario wurde neu gespeichert: %s statt %s", reloaded.UpdatedAt, loaded.UpdatedAt)
+	}
+}
+
+func TestUnchangedScenarioFilesInMemory(t *testing.T) {
+	assertUnchangedScenarioFiles(t, NewMemoryStore())
+}
+
+func TestUnchangedScenarioFilesInSQLite(t *testing.T) {
+	assertUnchangedScenarioFiles(t, openSQLiteRepository(t, filepath.Join(t.TempDir(), "aegis.db")))
+}
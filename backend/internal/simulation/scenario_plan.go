@@ -0,0 +1,183 @@
+// backend/internal/simulation/scenario_plan.go
+package simulation
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultScenarioID ist das Szenario, das Simulationen ohne Szenario ausführen
+const defaultScenarioID = "scenario-1"
+
+// phasePlan beschreibt einen Schritt eines Szenarios so, wie ihn die Engine ausführt
+type phasePlan struct {
+	Name               string
+	Description        string
+	Duration           time.Duration // simulierte Dauer des Schritts
+	Techniques         []string
+	Preconditions      []string        // Schritte, die zuvor erfolgreich gewesen sein müssen
+	Targets            *TargetSelector // zulässige Ziele von Netzwerkaktionen
+	EventTypes         []EventType
+	Severities         []Severity
+	SuccessProbability float64
+	Impact             ResourceStatus // Status einer Ressource nach erfolgreicher Aktion
+	Actions            []string       // Beschreibungen der einzelnen Aktionen
+}
+
+// scenarioPlan ist die ausführbare Form eines Szenarios
+type scenarioPlan struct {
+	ID    string
+	Name  string
+	Steps []phasePlan
+}
+
+// defaultEventDescriptions enthält Aktionsbeschreibungen für Schritte ohne eigene Aktionen
+var defaultEventDescriptions = map[EventType][]string{
+	EventTypeDiscovery: {
+		"Port-Scan durchgeführt",
+		"DNS-Informationen abgerufen",
+		"Webserver-Header analysiert",
+		"Offene Dienste identifiziert",
+		"Betriebssystem-Fingerprinting durchgeführt",
+	},
+	EventTypeExploitation: {
+		"Versuch einer SQL-Injection",
+		"Ausnutzung einer bekannten Schwachstelle",
+		"Brute-Force-Angriff auf Login-Formular",
+		"Phishing-E-Mail gesendet",
+		"Fehlkonfiguration ausgenutzt",
+	},
+	EventTypeEscalation: {
+		"Privilege Escalation über unsichere Berechtigung",
+		"Ausnutzung einer Kernel-Schwachstelle",
+		"Passwort in Klartext gefunden",
+		"Zugangsdaten gestohlen",
+		"Erstellung eines neuen Administratorkontos",
+	},
+	EventTypeLateralMovement: {
+		"Bewegung zum nächsten Netzwerksegment",
+		"Verwendung gestohlener Anmeldeinformationen",
+		"Remote-Codeausführung",
+		"Nutzung des Pass-the-Hash-Angriffs",
+	},
+	EventTypeDataExfiltration: {
+		"Datenexfiltration über verschlüsselten Tunnel",
+		"Kopieren sensibler Dateien",
+		"E-Mail-Extraktion über SMTP",
+		"Datenbank-Dump erstellt",
+	},
+	EventTypeSystem: {
+		"Systemprüfung durchgeführt",
+	},
+}
+
+// defaultImpacts legt fest, welchen Status eine Ressource nach einer erfolgreichen Aktion erhält
+var defaultImpacts = map[EventType]ResourceStatus{
+	EventTypeExploitation:     ResourceStatusAttacked,
+	EventTypeEscalation:       ResourceStatusCompromised,
+	EventTypeLateralMovement:  ResourceStatusCompromised,
+	EventTypeDataExfiltration: ResourceStatusCompromised,
+}
+
+// totalDuration gibt die simulierte Gesamtdauer des Szenarios zurück
+func (p *scenarioPlan) totalDuration() time.Duration {
+	var total time.Duration
+	for _, step := range p.Steps {
+		total += step.Duration
+	}
+	return total
+}
+
+// stepAt gibt den Index des Schritts zurück, der nach der simulierten Zeit elapsed aktiv ist
+func (p *scenarioPlan) stepAt(elapsed time.Duration) int {
+	var end time.Duration
+	for i, step := range p.Steps {
+		end += step.Duration
+		if elapsed <= end {
+			return i
+		}
+	}
+	return len(p.Steps) - 1
+}
+
+// impactFor gibt den Status zurück, den eine erfolgreiche Aktion des Typs bewirkt.
+// Ein leerer Status bedeutet, dass die Ressource unverändert bleibt.
+func (step *phasePlan) impactFor(eventType EventType) ResourceStatus {
+	if step.Impact != "" {
+		return step.Impact
+	}
+	return defaultImpacts[eventType]
+}
+
+// actionsFor gibt die möglichen Aktionsbeschreibungen für einen Eventtyp zurück
+func (step *phasePlan) actionsFor(eventType EventType) []string {
+	if len(step.Actions) > 0 {
+		return step.Actions
+	}
+	return defaultEventDescriptions[eventType]
+}
+
+// missingPrecondition gibt die erste Voraussetzung des Schritts zurück, die im bisherigen
+// Lauf nicht erfüllt wurde, oder einen leeren String
+func (step *phasePlan) missingPrecondition(succeeded map[string]bool) string {
+	for _, precondition := range step.Preconditions {
+		if !succeeded[precondition] {
+			return precondition
+		}
+	}
+	return ""
+}
+
+// loadScenarioPlan lädt das Szenario mit der angegebenen ID oder dem angegebenen Namen.
+// Simulationen ohne Szenario führen das Standardszenario aus.
+func (e *Engine) loadScenarioPlan(scenarioID string) (*scenarioPlan, error) {
+	if scenarioID == "" {
+		scenarioID = defaultScenarioID
+	}
+	scenario, err := e.findScenario(scenarioID)
+	if err != nil {
+		return nil, err
+	}
+	return scenarioPlanFrom(scenario)
+}
+
+// scenarioPlanFrom wandelt ein Szenario in einen ausführbaren Plan um
+func scenarioPlanFrom(scenario *Scenario) (*scenarioPlan, error) {
+	plan := &scenarioPlan{
+		ID:   scenario.ID,
+		Name: scenario.Name,
+	}
+	if len(scenario.Steps) == 0 {
+		return nil, fmt.Errorf("Szenario %s enthält keine Schritte", plan.ID)
+	}
+
+	for _, raw := range scenario.Steps {
+		step := phasePlan{
+			Name:               raw.Name,
+			Description:        raw.Description,
+			Duration:           time.Duration(raw.Duration) * time.Second,
+			Techniques:         raw.Techniques,
+			Preconditions:      raw.Preconditions,
+			Targets:            raw.Targets,
+			EventTypes:         raw.EventTypes,
+			Severities:         raw.Severities,
+			SuccessProbability: raw.SuccessProbability,
+			Impact:             raw.Impact,
+			Actions:            raw.Actions,
+		}
+
+		if step.Duration <= 0 {
+			return nil, fmt.Errorf("Schritt %s in Szenario %s hat keine Dauer", step.Name, plan.ID)
+		}
+		if len(step.EventTypes) == 0 {
+			step.EventTypes = []EventType{EventTypeSystem}
+		}
+		if len(step.Severities) == 0 {
+			step.Severities = []Severity{SeverityInfo}
+		}
+
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	return plan, nil
+}
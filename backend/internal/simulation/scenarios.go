@@ -0,0 +1,319 @@
+// backend/internal/simulation/scenarios.go
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/google/uuid"
+)
+
+// ErrScenarioNotFound wird zurückgegeben, wenn es kein Szenario mit der ID gibt
+var ErrScenarioNotFound = errors.New("Szenario nicht gefunden")
+
+// ErrScenarioReadOnly wird zurückgegeben, wenn ein mitgeliefertes Szenario geändert
+// oder gelöscht werden soll
+var ErrScenarioReadOnly = errors.New("mitgelieferte Szenarien können nicht geändert werden")
+
+// Difficulty ist der Schwierigkeitsgrad eines Szenarios
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+)
+
+// scenarioEventTypes sind die Eventtypen, die ein Schritt eines Szenarios erzeugen kann
+var scenarioEventTypes = map[EventType]bool{
+	EventTypeDiscovery:        true,
+	EventTypeEscalation:       true,
+	EventTypeExploitation:     true,
+	EventTypeLateralMovement:  true,
+	EventTypeDataExfiltration: true,
+	EventTypeSystem:           true,
+}
+
+// TargetSelector schränkt die Ziele eines Schritts auf Knoten eines der Typen oder mit
+// einem der Tags ein. Ein leerer Selektor lässt alle Knoten zu. Passt kein erreichbarer
+// Knoten, handelt der Angreifer in dem Schritt nicht.
+type TargetSelector struct {
+	NodeTypes []string `json:"nodeTypes,omitempty" yaml:"nodeTypes,omitempty"`
+	Tags      []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// empty gibt an, ob der Selektor alle Knoten zulässt
+func (t *TargetSelector) empty() bool {
+	return t == nil || len(t.NodeTypes) == 0 && len(t.Tags) == 0
+}
+
+// ScenarioStep ist ein Schritt eines Szenarios. Ohne Eventtypen erzeugt er Systemevents,
+// ohne Schweregrade Events mit info; ohne Impact bestimmt der Eventtyp, was eine
+// erfolgreiche Aktion bewirkt, ohne Actions stammen die Beschreibungen vom Eventtyp.
+// Preconditions nennt frühere Schritte, in denen mindestens eine Aktion erfolgreich
+// gewesen sein muss, damit der Schritt ausgeführt wird.
+type ScenarioStep struct {
+	ID                 int             `json:"id"`
+	Name               string          `json:"name"`
+	Description        string          `json:"description"`
+	Duration           int             `json:"duration"`             // simulierte Dauer in Sekunden
+	Techniques         []string        `json:"techniques,omitempty"` // z. B. MITRE ATT&CK T1566
+	Preconditions      []string        `json:"preconditions,omitempty"`
+	Targets            *TargetSelector `json:"targets,omitempty"`
+	EventTypes         []EventType     `json:"eventTypes"`
+	Severities         []Severity      `json:"severities"`
+	SuccessProbability float64         `json:"successProbability"`
+	Impact             ResourceStatus  `json:"impact,omitempty"`
+	Actions            []string        `json:"actions,omitempty"`
+}
+
+// Scenario ist ein Angriffsszenario, dessen Schritte eine Simulation nacheinander
+// ausführt. Mitgelieferte Szenarien (BuiltIn) stehen immer zur Verfügung und lassen
+// sich nicht ändern; eigene Szenarien liegen im Store.
+type Scenario struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Difficulty  Difficulty     `json:"difficulty,omitempty"`
+	Duration    int            `json:"duration"` // Summe der Schritte in Sekunden
+	Steps       []ScenarioStep `json:"steps"`
+	BuiltIn     bool           `json:"builtIn"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// normalize nummeriert die Schritte und berechnet die Gesamtdauer
+func (s *Scenario) normalize() {
+	s.Duration = 0
+	for i := range s.Steps {
+		s.Steps[i].ID = i + 1
+		s.Duration += s.Steps[i].Duration
+	}
+}
+
+// scenarioProblem ist ein Fehler an einer Stelle eines Szenarios. Path verwendet die
+// JSON-Feldnamen, etwa steps[1].duration.
+type scenarioProblem struct {
+	Path    string
+	Message string
+}
+
+// problems gibt alle Fehler zurück, die eine Ausführung des Szenarios verhindern
+func (s *Scenario) problems() []scenarioProblem {
+	var problems []scenarioProblem
+	add := func(path, format string, args ...interface{}) {
+		problems = append(problems, scenarioProblem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Name == "" {
+		add("name", "das Szenario braucht einen Namen")
+	}
+	switch s.Difficulty {
+	case "", DifficultyEasy, DifficultyMedium, DifficultyHard:
+	default:
+		add("difficulty", "unbekannter Schwierigkeitsgrad %q", s.Difficulty)
+	}
+	if len(s.Steps) == 0 {
+		add("steps", "das Szenario braucht mindestens einen Schritt")
+	}
+
+	earlier := make(map[string]bool)
+	for i, step := range s.Steps {
+		path := fmt.Sprintf("steps[%d]", i)
+		label := step.Name
+		if step.Name == "" {
+			label = fmt.Sprintf("%d", i+1)
+			add(path+".name", "Schritt %d braucht einen Namen", i+1)
+		} else if earlier[step.Name] {
+			add(path+".name", "der Schrittname %s ist doppelt vergeben", step.Name)
+		}
+		if step.Duration <= 0 {
+			add(path+".duration", "Schritt %s braucht eine Dauer", label)
+		}
+		if step.SuccessProbability < 0 || step.SuccessProbability > 1 {
+			add(path+".successProbability", "die Erfolgswahrscheinlichkeit von Schritt %s muss zwischen 0 und 1 liegen", label)
+		}
+		for j, technique := range step.Techniques {
+			if technique == "" {
+				add(fmt.Sprintf("%s.techniques[%d]", path, j), "leere Technik in Schritt %s", label)
+			}
+		}
+		for j, precondition := range step.Preconditions {
+			if !earlier[precondition] {
+				add(fmt.Sprintf("%s.preconditions[%d]", path, j), "die Voraussetzung %q von Schritt %s ist kein früherer Schritt", precondition, label)
+			}
+		}
+		if step.Targets != nil {
+			for j, nodeType := range step.Targets.NodeTypes {
+				if nodeType == "" {
+					add(fmt.Sprintf("%s.targets.nodeTypes[%d]", path, j), "leerer Knotentyp in Schritt %s", label)
+				}
+			}
+			for j, tag := range step.Targets.Tags {
+				if tag == "" {
+					add(fmt.Sprintf("%s.targets.tags[%d]", path, j), "leeres Tag in Schritt %s", label)
+				}
+			}
+		}
+		for j, eventType := range step.EventTypes {
+			if !scenarioEventTypes[eventType] {
+				add(fmt.Sprintf("%s.eventTypes[%d]", path, j), "unbekannter Eventtyp %q in Schritt %s", eventType, label)
+			}
+		}
+		for j, severity := range step.Severities {
+			if !ValidSeverity(severity) {
+				add(fmt.Sprintf("%s.severities[%d]", path, j), "unbekannter Schweregrad %q in Schritt %s", severity, label)
+			}
+		}
+		switch step.Impact {
+		case "", ResourceStatusVulnerable, ResourceStatusAttacked, ResourceStatusCompromised:
+		default:
+			add(path+".impact", "unbekannte Auswirkung %q in Schritt %s", step.Impact, label)
+		}
+		earlier[step.Name] = true
+	}
+	return problems
+}
+
+// Validate prüft, ob die Engine das Szenario ausführen kann, und meldet den ersten Fehler
+func (s *Scenario) Validate() error {
+	if problems := s.problems(); len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidParameter, problems[0].Message)
+	}
+	return nil
+}
+
+// builtInScenario gibt das mitgelieferte Szenario mit der ID zurück
+func builtInScenario(id string) *Scenario {
+	for _, scenario := range builtInScenarios() {
+		if scenario.ID == id {
+			return scenario
+		}
+	}
+	return nil
+}
+
+// GetScenarios gibt alle Szenarien zurück, die mitgelieferten zuerst, danach die
+// eigenen in der Reihenfolge ihrer Erstellung
+func (e *Engine) GetScenarios() ([]*Scenario, error) {
+	stored, err := e.store.GetScenarios()
+	if err != nil {
+		return nil, err
+	}
+	return append(builtInScenarios(), stored...), nil
+}
+
+// GetScenario gibt das Szenario mit der ID zurück
+func (e *Engine) GetScenario(id string) (*Scenario, error) {
+	if scenario := builtInScenario(id); scenario != nil {
+		return scenario, nil
+	}
+	return e.store.GetScenario(id)
+}
+
+// findScenario sucht ein Szenario über seine ID oder, für ältere Simulationen, über
+// seinen Namen
+func (e *Engine) findScenario(idOrName string) (*Scenario, error) {
+	scenario, err := e.GetScenario(idOrName)
+	if !errors.Is(err, ErrScenarioNotFound) {
+		return scenario, err
+	}
+
+	scenarios, err := e.GetScenarios()
+	if err != nil {
+		return nil, err
+	}
+	for _, scenario := range scenarios {
+		if scenario.Name == idOrName {
+			return scenario, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrScenarioNotFound, idOrName)
+}
+
+// checkScenario prüft, ob das Szenario einer neuen Simulation oder eines Zeitplans
+// existiert. Ohne Szenario wird das Standardszenario ausgeführt.
+func (e *Engine) checkScenario(scenarioID string) error {
+	if scenarioID == "" {
+		return nil
+	}
+	_, err := e.findScenario(scenarioID)
+	if errors.Is(err, ErrScenarioNotFound) {
+		return fmt.Errorf("%w: Szenario %s nicht gefunden", ErrInvalidParameter, scenarioID)
+	}
+	return err
+}
+
+// CreateScenario legt ein eigenes Szenario an. ID, BuiltIn und Zeitstempel werden
+// vergeben.
+func (e *Engine) CreateScenario(scenario Scenario) (*Scenario, error) {
+	return e.createScenario(uuid.New().String(), scenario)
+}
+
+// createScenario legt ein eigenes Szenario mit der angegebenen ID an
+func (e *Engine) createScenario(id string, scenario Scenario) (*Scenario, error) {
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := e.clock.Now()
+	scenario.ID = id
+	scenario.BuiltIn = false
+	scenario.CreatedAt = now
+	scenario.UpdatedAt = now
+	scenario.normalize()
+
+	if err := e.store.SaveScenario(&scenario); err != nil {
+		return nil, fmt.Errorf("Szenario konnte nicht gespeichert werden: %v", err)
+	}
+
+	logging.Logger.Infof("Szenario '%s' (ID: %s) erstellt", scenario.Name, scenario.ID)
+	return &scenario, nil
+}
+
+// UpdateScenario ersetzt ein eigenes Szenario. Laufende Simulationen behalten die
+// Schritte, mit denen sie gestartet wurden.
+func (e *Engine) UpdateScenario(id string, scenario Scenario) (*Scenario, error) {
+	if builtInScenario(id) != nil {
+		return nil, fmt.Errorf("%w: %s", ErrScenarioReadOnly, id)
+	}
+	existing, err := e.store.GetScenario(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+
+	scenario.ID = id
+	scenario.BuiltIn = false
+	scenario.CreatedAt = existing.CreatedAt
+	scenario.UpdatedAt = e.clock.Now()
+	scenario.normalize()
+
+	if err := e.store.SaveScenario(&scenario); err != nil {
+		return nil, fmt.Errorf("Szenario konnte nicht gespeichert werden: %v", err)
+	}
+
+	logging.Logger.Infof("Szenario '%s' (ID: %s) geändert", scenario.Name, scenario.ID)
+	return &scenario, nil
+}
+
+// DeleteScenario löscht ein eigenes Szenario. Simulationen, die es verwenden, bleiben
+// erhalten, lassen sich aber nicht erneut starten.
+func (e *Engine) DeleteScenario(id string) error {
+	if builtInScenario(id) != nil {
+		return fmt.Errorf("%w: %s", ErrScenarioReadOnly, id)
+	}
+	if _, err := e.store.GetScenario(id); err != nil {
+		return err
+	}
+	if err := e.store.DeleteScenario(id); err != nil {
+		return err
+	}
+
+	logging.Logger.Infof("Szenario %s gelöscht", id)
+	return nil
+}
@@ -0,0 +1,258 @@
+// backend/internal/simulation/scenarios_test.go
+package simulation
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// customScenario erzeugt ein eigenes Szenario mit zwei Schritten
+func customScenario(name string) Scenario {
+	return Scenario{
+		Name:        name,
+		Description: "Eigenes Szenario für Tests",
+		Difficulty:  DifficultyMedium,
+		Steps: []ScenarioStep{
+			{
+				Name:               "Aufklärung",
+				Duration:           60,
+				EventTypes:         []EventType{EventTypeDiscovery},
+				Severities:         []Severity{SeverityInfo},
+				SuccessProbability: 1,
+			},
+			{
+				Name:               "Einbruch",
+				Duration:           120,
+				EventTypes:         []EventType{EventTypeExploitation},
+				Severities:         []Severity{SeverityHigh},
+				SuccessProbability: 0.5,
+				Impact:             ResourceStatusCompromised,
+			},
+		},
+	}
+}
+
+func TestScenarioValidation(t *testing.T) {
+	invalid := map[string]func(*Scenario){
+		"ohne Name":                 func(s *Scenario) { s.Name = "" },
+		"unbekannte Schwierigkeit":  func(s *Scenario) { s.Difficulty = "extrem" },
+		"ohne Schritte":             func(s *Scenario) { s.Steps = nil },
+		"Schritt ohne Dauer":        func(s *Scenario) { s.Steps[0].Duration = 0 },
+		"Wahrscheinlichkeit über 1": func(s *Scenario) { s.Steps[1].SuccessProbability = 1.5 },
+		"unbekannter Eventtyp":      func(s *Scenario) { s.Steps[0].EventTypes = []EventType{EventTypeDefense} },
+		"unbekannter Schweregrad":   func(s *Scenario) { s.Steps[0].Severities = []Severity{"fatal"} },
+		"unbekannte Auswirkung":     func(s *Scenario) { s.Steps[1].Impact = ResourceStatusNormal },
+	}
+	for name, mutate := range invalid {
+		scenario := customScenario("Ungültig")
+		mutate(&scenario)
+		if err := scenario.Validate(); !errors.Is(err, ErrInvalidParameter) {
+			t.Fatalf("%s: Erwarteter Fehler: %v, Erhaltener Fehler: %v", name, ErrInvalidParameter, err)
+		}
+	}
+
+	for _, scenario := range builtInScenarios() {
+		if err := scenario.Validate(); err != nil {
+			t.Fatalf("Das mitgelieferte Szenario %s ist ungültig: %v", scenario.ID, err)
+		}
+	}
+}
+
+// assertScenarios prüft Anlegen, Ändern und Löschen eigener Szenarien auf einem Store
+func assertScenarios(t *testing.T, store Store) {
+	t.Helper()
+
+	engine := NewEngine(WithStore(store))
+	service := &Service{engine: engine}
+
+	created, err := service.CreateScenario(customScenario("Eigenes"))
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen des Szenarios: %v", err)
+	}
+	if created.ID == "" || created.BuiltIn || created.Duration != 180 || created.Steps[1].ID != 2 {
+		t.Fatalf("Unerwartetes Szenario: %+v", created)
+	}
+
+	loaded, err := service.GetScenario(created.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Laden des Szenarios: %v", err)
+	}
+	if loaded.Name != "Eigenes" || len(loaded.Steps) != 2 || loaded.Steps[1].Impact != ResourceStatusCompromised {
+		t.Fatalf("Unerwartetes gespeichertes Szenario: %+v", loaded)
+	}
+
+	scenarios, err := service.GetScenarios()
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen der Szenarien: %v", err)
+	}
+	builtIn := len(builtInScenarios())
+	if len(scenarios) != builtIn+1 || !scenarios[0].BuiltIn || scenarios[builtIn].ID != created.ID {
+		t.Fatalf("Erwartet: %d mitgelieferte und das eigene Szenario, Erhalten: %d", builtIn, len(scenarios))
+	}
+
+	changed := customScenario("Geändert")
+	changed.Steps = changed.Steps[:1]
+	updated, err := service.UpdateScenario(created.ID, changed)
+	if err != nil {
+		t.Fatalf("Fehler beim Ändern des Szenarios: %v", err)
+	}
+	if updated.Name != "Geändert" || updated.Duration != 60 || !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Fatalf("Unerwartetes geändertes Szenario: %+v", updated)
+	}
+
+	// Mitgelieferte Szenarien sind schreibgeschützt
+	if _, err := service.UpdateScenario("scenario-1", changed); !errors.Is(err, ErrScenarioReadOnly) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrScenarioReadOnly, err)
+	}
+	if err := service.DeleteScenario("scenario-1"); !errors.Is(err, ErrScenarioReadOnly) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrScenarioReadOnly, err)
+	}
+
+	if err := service.DeleteScenario(created.ID); err != nil {
+		t.Fatalf("Fehler beim Löschen des Szenarios: %v", err)
+	}
+	if _, err := service.GetScenario(created.ID); !errors.Is(err, ErrScenarioNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrScenarioNotFound, err)
+	}
+	if err := service.DeleteScenario(created.ID); !errors.Is(err, ErrScenarioNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrScenarioNotFound, err)
+	}
+	if _, err := service.UpdateScenario(created.ID, changed); !errors.Is(err, ErrScenarioNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrScenarioNotFound, err)
+	}
+}
+
+func TestScenariosInMemory(t *testing.T) {
+	assertScenarios(t, NewMemoryStore())
+}
+
+func TestScenariosInSQLite(t *testing.T) {
+	assertScenarios(t, openSQLiteRepository(t, filepath.Join(t.TempDir(), "aegis.db")))
+}
+
+func TestCustomScenarioDrivesTheRun(t *testing.T) {
+	engine := NewEngine()
+
+	// Simulationen und Zeitpläne mit unbekanntem Szenario werden abgelehnt
+	if _, err := engine.CreateSimulation(SimulationConfig{Name: "Unbekannt", ScenarioID: "kein-szenario"}); !errors.Is(err, ErrInvalidParameter) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrInvalidParameter, err)
+	}
+	if _, err := engine.CreateSchedule(ScheduleConfig{Name: "Unbekannt", Cron: "@daily", ScenarioID: "kein-szenario"}); !errors.Is(err, ErrInvalidParameter) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrInvalidParameter, err)
+	}
+
+	scenario, err := engine.CreateScenario(customScenario("Eigenes"))
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen des Szenarios: %v", err)
+	}
+	sim := runInstant(t, engine, SimulationConfig{Name: "Eigenes Szenario", ScenarioID: scenario.ID})
+	events, _ := engine.GetEvents(sim.ID)
+
+	var phases []string
+	for _, event := range events {
+		if event.Description == "Phase gestartet: "+event.Phase {
+			phases = append(phases, event.Phase)
+		}
+	}
+	if len(phases) != 2 || phases[0] != "Aufklärung" || phases[1] != "Einbruch" {
+		t.Fatalf("Erwartete Phasen: [Aufklärung Einbruch], Erhaltene Phasen: %v", phases)
+	}
+}
+
+func TestScenarioPreconditionsAndTargets(t *testing.T) {
+	engine := NewEngine()
+	nodeTypes := make(map[string]string)
+	for _, node := range toMapList(MockInfrastructureFor("infra-1")["nodes"]) {
+		nodeTypes[toString(node["id"])] = toString(node["type"])
+	}
+
+	access := func(probability float64, targets *TargetSelector) ScenarioStep {
+		return ScenarioStep{
+			Name:               "Zugriff",
+			Duration:           600,
+			Targets:            targets,
+			EventTypes:         []EventType{EventTypeExploitation},
+			SuccessProbability: probability,
+			Impact:             ResourceStatusCompromised,
+		}
+	}
+	spread := ScenarioStep{
+		Name:               "Ausbreitung",
+		Duration:           600,
+		Techniques:         []string{"T1021"},
+		Preconditions:      []string{"Zugriff"},
+		Targets:            &TargetSelector{NodeTypes: []string{"server"}},
+		EventTypes:         []EventType{EventTypeLateralMovement},
+		SuccessProbability: 1,
+	}
+	attack := func(steps ...ScenarioStep) (*Simulation, []SimulationEvent) {
+		scenario, err := engine.CreateScenario(Scenario{Name: "Zielgerichtet", Steps: steps})
+		if err != nil {
+			t.Fatalf("Fehler beim Erstellen des Szenarios: %v", err)
+		}
+		sim := runInstant(t, engine, SimulationConfig{Name: "Zielgerichtet", InfrastructureID: "infra-1", ScenarioID: scenario.ID})
+		events, _ := engine.GetEvents(sim.ID)
+		return sim, events
+	}
+
+	// Schon der Einstiegspunkt passt zum Selektor, danach greift der Angreifer nur Server an
+	_, events := attack(access(1, &TargetSelector{NodeTypes: []string{"router"}}), spread)
+	servers := 0
+	for _, event := range events {
+		if event.Outcome == "" {
+			continue
+		}
+		if event.Phase == "Zugriff" {
+			if nodeTypes[event.ResourceID] != "router" {
+				t.Fatalf("Unzulässiges Ziel %s (%s) in Schritt Zugriff", event.ResourceID, nodeTypes[event.ResourceID])
+			}
+			continue
+		}
+		if nodeTypes[event.ResourceID] != "server" {
+			t.Fatalf("Unzulässiges Ziel %s (%s) in Schritt Ausbreitung", event.ResourceID, nodeTypes[event.ResourceID])
+		}
+		servers++
+		if details, _ := event.Details.(map[string]interface{}); details["techniques"] == nil {
+			t.Fatalf("Die Techniken des Schritts fehlen im Event: %+v", event.Details)
+		}
+	}
+	if servers == 0 {
+		t.Fatal("Es wurde kein Server angegriffen")
+	}
+
+	// Ohne erfolgreichen Zugriff wird die Ausbreitung übersprungen
+	skipped := 0
+	_, events = attack(access(0, nil), spread)
+	for _, event := range events {
+		if event.Phase != "Ausbreitung" {
+			continue
+		}
+		if event.Outcome != "" || !strings.HasPrefix(event.Description, "Phase übersprungen: Ausbreitung") {
+			t.Fatalf("Im übersprungenen Schritt sollte nichts geschehen: %+v", event)
+		}
+		skipped++
+	}
+	if skipped != 1 {
+		t.Fatalf("Erwartet: 1 Event zum übersprungenen Schritt, Erhalten: %d", skipped)
+	}
+
+	// Lässt der Selektor keinen Knoten zu, wird das einmal vermerkt und nichts angegriffen
+	sim, events := attack(access(1, &TargetSelector{NodeTypes: []string{"mainframe"}}))
+	untargeted := 0
+	for _, event := range events {
+		if event.Outcome != "" {
+			t.Fatalf("Ohne zulässiges Ziel sollte keine Aktion stattfinden: %+v", event)
+		}
+		if event.Description == "Kein zulässiges Ziel im Schritt Zugriff" {
+			untargeted++
+		}
+	}
+	if untargeted != 1 {
+		t.Fatalf("Erwartet: 1 Event zum fehlenden Ziel, Erhalten: %d", untargeted)
+	}
+	if resources, _ := engine.GetAffectedResources(sim.ID); len(resources) != 0 {
+		t.Fatalf("Erwartet: keine betroffenen Ressourcen, Erhalten: %d", len(resources))
+	}
+}
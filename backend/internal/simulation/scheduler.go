@@ -0,0 +1,90 @@
+// backend/internal/simulation/scheduler.go
+package simulation
+
+import (
+	"errors"
+)
+
+// ErrQueueFull wird zurückgegeben, wenn alle Worker belegt sind und die Warteschlange voll ist
+var ErrQueueFull = errors.New("Warteschlange für Simulationen ist voll")
+
+// scheduler begrenzt die Anzahl gleichzeitig laufender Simulationen. Simulationen,
+// für die kein Worker frei ist, warten in einer begrenzten Warteschlange.
+// Der scheduler ist nicht selbst synchronisiert; die Engine ruft ihn nur unter
+// ihrem Mutex auf.
+type scheduler struct {
+	workerCount int // 0 bedeutet unbegrenzt
+	bufferSize  int
+	active      int
+	queue       []string
+}
+
+// newScheduler erstellt einen scheduler; workerCount <= 0 hebt die Begrenzung auf
+func newScheduler(workerCount, bufferSize int) *scheduler {
+	if workerCount < 0 {
+		workerCount = 0
+	}
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	return &scheduler{
+		workerCount: workerCount,
+		bufferSize:  bufferSize,
+	}
+}
+
+// tryAcquire belegt einen Worker, sofern einer frei ist
+func (s *scheduler) tryAcquire() bool {
+	if s.workerCount > 0 && s.active >= s.workerCount {
+		return false
+	}
+	s.active++
+	return true
+}
+
+// release gibt einen Worker wieder frei
+func (s *scheduler) release() {
+	if s.active > 0 {
+		s.active--
+	}
+}
+
+// enqueue reiht eine Simulation ein und gibt ihre Position (ab 1) zurück
+func (s *scheduler) enqueue(id string) (int, error) {
+	if len(s.queue) >= s.bufferSize {
+		return 0, ErrQueueFull
+	}
+	s.queue = append(s.queue, id)
+	return len(s.queue), nil
+}
+
+// dequeue entnimmt die am längsten wartende Simulation
+func (s *scheduler) dequeue() (string, bool) {
+	if len(s.queue) == 0 {
+		return "", false
+	}
+	id := s.queue[0]
+	s.queue = s.queue[1:]
+	return id, true
+}
+
+// remove entfernt eine wartende Simulation aus der Warteschlange
+func (s *scheduler) remove(id string) bool {
+	for i, queued := range s.queue {
+		if queued == id {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// position gibt die Position einer wartenden Simulation zurück, 0 wenn sie nicht wartet
+func (s *scheduler) position(id string) int {
+	for i, queued := range s.queue {
+		if queued == id {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+// backend/internal/simulation/scheduler_test.go
+package simulation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func simulationStatus(engine *Engine, id string) (Status, int) {
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+	simulation := engine.simulations[id]
+	return simulation.Status, simulation.QueuePosition
+}
+
+func TestWorkerPoolQueuesAndRejects(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock), WithWorkerPool(1, 2))
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		sim, err := engine.CreateSimulation(SimulationConfig{Name: "Worker Pool"})
+		if err != nil {
+			t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+		}
+		ids = append(ids, sim.ID)
+	}
+
+	// Die erste Simulation belegt den einzigen Worker, die nächsten beiden warten
+	for i, id := range ids[:3] {
+		if _, err := engine.StartSimulation(id); err != nil {
+			t.Fatalf("Fehler beim Starten der Simulation %d: %v", i, err)
+		}
+	}
+	if status, _ := simulationStatus(engine, ids[0]); status != StatusRunning {
+		t.Fatalf("Erwarteter Status: %s, Erhaltener Status: %s", StatusRunning, status)
+	}
+	for i, id := range ids[1:3] {
+		status, position := simulationStatus(engine, id)
+		if status != StatusQueued || position != i+1 {
+			t.Fatalf("Erwartet: %s an Position %d, Erhalten: %s an Position %d", StatusQueued, i+1, status, position)
+		}
+	}
+
+	// Die Warteschlange ist voll
+	if _, err := engine.StartSimulation(ids[3]); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrQueueFull, err)
+	}
+	if status, _ := simulationStatus(engine, ids[3]); status != StatusNotStarted {
+		t.Fatalf("Abgelehnte Simulation sollte %s bleiben, ist aber %s", StatusNotStarted, status)
+	}
+
+	// Eine wartende Simulation verlässt die Warteschlange beim Stoppen
+	if _, err := engine.StopSimulation(ids[1]); err != nil {
+		t.Fatalf("Fehler beim Stoppen der Simulation: %v", err)
+	}
+	if status, position := simulationStatus(engine, ids[2]); status != StatusQueued || position != 1 {
+		t.Fatalf("Erwartet: %s an Position 1, Erhalten: %s an Position %d", StatusQueued, status, position)
+	}
+
+	// Sobald der Worker frei wird, startet die nächste wartende Simulation
+	if _, err := engine.StopSimulation(ids[0]); err != nil {
+		t.Fatalf("Fehler beim Stoppen der Simulation: %v", err)
+	}
+	waitFor(t, "Start aus der Warteschlange", func() bool {
+		status, _ := simulationStatus(engine, ids[2])
+		return status == StatusRunning
+	})
+	if status, _ := simulationStatus(engine, ids[1]); status != StatusStopped {
+		t.Fatalf("Erwarteter Status: %s, Erhaltener Status: %s", StatusStopped, status)
+	}
+
+	engine.StopSimulation(ids[2])
+}
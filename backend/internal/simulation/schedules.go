@@ -0,0 +1,366 @@
+// backend/internal/simulation/schedules.go
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+)
+
+// ErrScheduleNotFound wird zurückgegeben, wenn es keinen Zeitplan mit der ID gibt
+var ErrScheduleNotFound = errors.New("Zeitplan nicht gefunden")
+
+// OverlapPolicy legt fest, was geschieht, wenn ein Zeitplan fällig wird, während die
+// zuletzt von ihm gestartete Simulation noch aktiv ist
+type OverlapPolicy string
+
+const (
+	OverlapSkip  OverlapPolicy = "skip"  // die Ausführung entfällt
+	OverlapQueue OverlapPolicy = "queue" // die Ausführung startet, sobald die vorige Simulation beendet ist
+)
+
+// ScheduleOutcome ist das Ergebnis der letzten fälligen Ausführung eines Zeitplans
+type ScheduleOutcome string
+
+const (
+	ScheduleStarted ScheduleOutcome = "started"
+	ScheduleSkipped ScheduleOutcome = "skipped"
+	ScheduleQueued  ScheduleOutcome = "queued"
+	ScheduleFailed  ScheduleOutcome = "failed"
+)
+
+// Schedule ist ein Zeitplan, nach dem wiederkehrend eine Simulation mit derselben
+// Konfiguration erstellt und gestartet wird. Cron ist ein Ausdruck mit fünf Feldern
+// oder eine Kurzform wie @daily, ausgewertet in der Zeitzone Timezone.
+type Schedule struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description,omitempty"`
+	Cron             string                 `json:"cron"`
+	Timezone         string                 `json:"timezone"`
+	InfrastructureID string                 `json:"infrastructureId"`
+	ScenarioID       string                 `json:"scenarioId"`
+	Parameters       map[string]interface{} `json:"parameters,omitempty"`
+	Overlap          OverlapPolicy          `json:"overlap"`
+	Enabled          bool                   `json:"enabled"`
+	Pending          bool                   `json:"pending"` // eine zurückgestellte Ausführung wartet auf das Ende der vorigen Simulation
+	NextRunAt        *time.Time             `json:"nextRunAt,omitempty"`
+	LastRunAt        *time.Time             `json:"lastRunAt,omitempty"` // letzter Start einer Simulation
+	LastSimulationID string                 `json:"lastSimulationId,omitempty"`
+	LastOutcome      ScheduleOutcome        `json:"lastOutcome,omitempty"`
+	LastError        string                 `json:"lastError,omitempty"`
+	CreatedAt        time.Time              `json:"createdAt"`
+	UpdatedAt        time.Time              `json:"updatedAt"`
+}
+
+// ScheduleConfig enthält die Konfiguration eines Zeitplans. Ohne Timezone gilt UTC,
+// ohne Overlap skip; Enabled fehlt, um einen neuen Zeitplan einzuschalten bzw. den
+// Zustand eines bestehenden beizubehalten.
+type ScheduleConfig struct {
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	Cron             string                 `json:"cron"`
+	Timezone         string                 `json:"timezone"`
+	InfrastructureID string                 `json:"infrastructureId"`
+	ScenarioID       string                 `json:"scenarioId"`
+	Parameters       map[string]interface{} `json:"parameters,omitempty"`
+	Overlap          OverlapPolicy          `json:"overlap"`
+	Enabled          *bool                  `json:"enabled,omitempty"`
+}
+
+// nextScheduleRun berechnet den nächsten Ausführungszeitpunkt eines Zeitplans nach after
+func nextScheduleRun(schedule *Schedule, after time.Time) (*time.Time, error) {
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unbekannte Zeitzone %q", ErrInvalidParameter, schedule.Timezone)
+	}
+	spec, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ungültiger Cron-Ausdruck %q: %v", ErrInvalidParameter, schedule.Cron, err)
+	}
+	next := spec.Next(after.In(location))
+	if next.IsZero() {
+		return nil, fmt.Errorf("%w: der Cron-Ausdruck %q wird nie fällig", ErrInvalidParameter, schedule.Cron)
+	}
+	next = next.UTC()
+	return &next, nil
+}
+
+// applyScheduleConfig prüft eine Konfiguration, übernimmt sie in den Zeitplan und
+// berechnet den nächsten Ausführungszeitpunkt neu
+func applyScheduleConfig(schedule *Schedule, config ScheduleConfig, now time.Time) error {
+	if config.Name == "" {
+		return fmt.Errorf("%w: der Zeitplan braucht einen Namen", ErrInvalidParameter)
+	}
+	if config.Timezone == "" {
+		config.Timezone = "UTC"
+	}
+	switch config.Overlap {
+	case "":
+		config.Overlap = OverlapSkip
+	case OverlapSkip, OverlapQueue:
+	default:
+		return fmt.Errorf("%w: unbekanntes Verhalten bei Überschneidung %q", ErrInvalidParameter, config.Overlap)
+	}
+
+	// Die Parameter werden wie beim Erstellen einer Simulation geprüft
+	if _, err := parseSpeed(config.Parameters); err != nil {
+		return err
+	}
+	if _, err := parseTimeout(config.Parameters); err != nil {
+		return err
+	}
+	if _, err := parseSeed(config.Parameters); err != nil {
+		return err
+	}
+
+	schedule.Name = config.Name
+	schedule.Description = config.Description
+	schedule.Cron = config.Cron
+	schedule.Timezone = config.Timezone
+	schedule.InfrastructureID = config.InfrastructureID
+	schedule.ScenarioID = config.ScenarioID
+	schedule.Parameters = config.Parameters
+	schedule.Overlap = config.Overlap
+	if config.Enabled != nil {
+		schedule.Enabled = *config.Enabled
+	}
+
+	// Auch ausgeschaltete Zeitpläne brauchen einen gültigen Ausdruck
+	next, err := nextScheduleRun(schedule, now)
+	if err != nil {
+		return err
+	}
+	if schedule.Enabled {
+		schedule.NextRunAt = next
+	} else {
+		schedule.NextRunAt = nil
+		schedule.Pending = false
+	}
+	schedule.UpdatedAt = now
+	return nil
+}
+
+// CreateSchedule legt einen Zeitplan an
+func (e *Engine) CreateSchedule(config ScheduleConfig) (*Schedule, error) {
+	now := e.clock.Now()
+	schedule := &Schedule{
+		ID:        uuid.New().String(),
+		Enabled:   true,
+		CreatedAt: now,
+	}
+	if err := applyScheduleConfig(schedule, config, now); err != nil {
+		return nil, err
+	}
+	if err := e.checkScenario(config.ScenarioID); err != nil {
+		return nil, err
+	}
+
+	e.scheduleMutex.Lock()
+	defer e.scheduleMutex.Unlock()
+
+	if err := e.store.SaveSchedule(schedule); err != nil {
+		return nil, fmt.Errorf("Zeitplan konnte nicht gespeichert werden: %v", err)
+	}
+
+	logging.Logger.Infof("Zeitplan '%s' (ID: %s, %s) erstellt", schedule.Name, schedule.ID, schedule.Cron)
+	return schedule, nil
+}
+
+// GetSchedules gibt alle Zeitpläne zurück, die ältesten zuerst
+func (e *Engine) GetSchedules() ([]*Schedule, error) {
+	return e.store.GetSchedules()
+}
+
+// GetSchedule gibt einen Zeitplan zurück
+func (e *Engine) GetSchedule(id string) (*Schedule, error) {
+	return e.store.GetSchedule(id)
+}
+
+// UpdateSchedule ersetzt die Konfiguration eines Zeitplans. Der nächste
+// Ausführungszeitpunkt wird ab jetzt neu berechnet; Angaben zur letzten Ausführung
+// bleiben erhalten.
+func (e *Engine) UpdateSchedule(id string, config ScheduleConfig) (*Schedule, error) {
+	e.scheduleMutex.Lock()
+	defer e.scheduleMutex.Unlock()
+
+	schedule, err := e.store.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyScheduleConfig(schedule, config, e.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := e.checkScenario(config.ScenarioID); err != nil {
+		return nil, err
+	}
+	if err := e.store.SaveSchedule(schedule); err != nil {
+		return nil, fmt.Errorf("Zeitplan konnte nicht gespeichert werden: %v", err)
+	}
+
+	logging.Logger.Infof("Zeitplan '%s' (ID: %s) geändert", schedule.Name, schedule.ID)
+	return schedule, nil
+}
+
+// DeleteSchedule löscht einen Zeitplan. Bereits gestartete Simulationen bleiben erhalten.
+func (e *Engine) DeleteSchedule(id string) error {
+	e.scheduleMutex.Lock()
+	defer e.scheduleMutex.Unlock()
+
+	if _, err := e.store.GetSchedule(id); err != nil {
+		return err
+	}
+	if err := e.store.DeleteSchedule(id); err != nil {
+		return err
+	}
+
+	logging.Logger.Infof("Zeitplan %s gelöscht", id)
+	return nil
+}
+
+// StartSchedules prüft im angegebenen Abstand, welche Zeitpläne fällig sind, und
+// startet ihre Simulationen über den Service. Fälligkeiten, die verpasst wurden, während
+// der Server gestoppt war, werden sofort zu einer Ausführung zusammengefasst nachgeholt.
+// Shutdown beendet die Prüfung.
+func (s *Service) StartSchedules(interval time.Duration) {
+	if interval <= 0 || s.schedulesStop != nil {
+		return
+	}
+	s.schedulesStop = make(chan struct{})
+	s.schedulesDone = make(chan struct{})
+
+	ticker := s.engine.clock.NewTicker(interval)
+	go func() {
+		defer close(s.schedulesDone)
+		defer ticker.Stop()
+
+		s.runDueSchedules()
+		for {
+			select {
+			case <-s.schedulesStop:
+				return
+			case <-ticker.C():
+				s.runDueSchedules()
+			}
+		}
+	}()
+}
+
+// stopSchedules beendet die Prüfung der Zeitpläne und wartet auf eine laufende Ausführung
+func (s *Service) stopSchedules() {
+	if s.schedulesStop == nil {
+		return
+	}
+	close(s.schedulesStop)
+	<-s.schedulesDone
+	s.schedulesStop = nil
+}
+
+// runDueSchedules führt alle fälligen und alle zurückgestellten Zeitpläne aus
+func (s *Service) runDueSchedules() {
+	e := s.engine
+	e.scheduleMutex.Lock()
+	defer e.scheduleMutex.Unlock()
+
+	schedules, err := e.store.GetSchedules()
+	if err != nil {
+		logging.Logger.Errorf("Zeitpläne konnten nicht geladen werden: %v", err)
+		return
+	}
+
+	now := e.clock.Now()
+	for _, schedule := range schedules {
+		if !schedule.Enabled {
+			continue
+		}
+		due := schedule.NextRunAt != nil && !schedule.NextRunAt.After(now)
+		if due || schedule.Pending {
+			s.runSchedule(schedule, due, now)
+		}
+	}
+}
+
+// runSchedule startet die Simulation eines Zeitplans oder überspringt bzw. stellt sie
+// zurück, solange die vorige noch aktiv ist. Der Aufrufer muss e.scheduleMutex halten.
+func (s *Service) runSchedule(schedule *Schedule, due bool, now time.Time) {
+	if due {
+		// Mehrere verpasste Fälligkeiten ergeben nur eine Ausführung
+		next, err := nextScheduleRun(schedule, now)
+		if err != nil {
+			logging.Logger.Errorf("Nächste Ausführung des Zeitplans %s nicht berechenbar: %v", schedule.ID, err)
+		}
+		schedule.NextRunAt = next
+	}
+
+	if s.previousRunActive(schedule) {
+		if !due {
+			// Die zurückgestellte Ausführung wartet weiter
+			return
+		}
+		action := "übersprungen"
+		if schedule.Overlap == OverlapQueue {
+			schedule.Pending = true
+			schedule.LastOutcome = ScheduleQueued
+			action = "zurückgestellt"
+		} else {
+			schedule.LastOutcome = ScheduleSkipped
+		}
+		logging.Logger.Infof("Zeitplan '%s': Simulation %s läuft noch, Ausführung %s", schedule.Name, schedule.LastSimulationID, action)
+	} else {
+		schedule.Pending = false
+		s.launchSchedule(schedule, now)
+	}
+
+	schedule.UpdatedAt = now
+	if err := s.engine.store.SaveSchedule(schedule); err != nil {
+		logging.Logger.Errorf("Zeitplan %s konnte nicht gespeichert werden: %v", schedule.ID, err)
+	}
+}
+
+// previousRunActive gibt an, ob die zuletzt vom Zeitplan gestartete Simulation noch aktiv ist
+func (s *Service) previousRunActive(schedule *Schedule) bool {
+	if schedule.LastSimulationID == "" {
+		return false
+	}
+	status, err := s.engine.GetSimulationStatus(schedule.LastSimulationID)
+	if err != nil {
+		// Die Simulation wurde inzwischen gelöscht
+		return false
+	}
+	return activeStatus(status.Status)
+}
+
+// launchSchedule erstellt und startet die Simulation eines Zeitplans
+func (s *Service) launchSchedule(schedule *Schedule, now time.Time) {
+	config := SimulationConfig{
+		Name:             fmt.Sprintf("%s (%s)", schedule.Name, now.UTC().Format("2006-01-02 15:04")),
+		Description:      schedule.Description,
+		InfrastructureID: schedule.InfrastructureID,
+		ScenarioID:       schedule.ScenarioID,
+		Parameters:       make(map[string]interface{}, len(schedule.Parameters)),
+	}
+	for name, value := range schedule.Parameters {
+		config.Parameters[name] = value
+	}
+
+	schedule.LastRunAt = &now
+	simulation, err := s.CreateSimulation(config)
+	if err == nil {
+		schedule.LastSimulationID = simulation.ID
+		_, err = s.StartSimulation(simulation.ID)
+	}
+	if err != nil {
+		schedule.LastOutcome = ScheduleFailed
+		schedule.LastError = err.Error()
+		logging.Logger.Errorf("Zeitplan '%s' konnte keine Simulation starten: %v", schedule.Name, err)
+		return
+	}
+
+	schedule.LastOutcome = ScheduleStarted
+	schedule.LastError = ""
+	logging.Logger.Infof("Zeitplan '%s' hat Simulation %s gestartet", schedule.Name, simulation.ID)
+}
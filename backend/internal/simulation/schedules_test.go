@@ -0,0 +1,188 @@
+// backend/internal/simulation/schedules_test.go
+package simulation
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scheduledSimulations gibt die Anzahl der Simulationen zurück, die ein Zeitplan gestartet hat
+func scheduledSimulations(engine *Engine, schedule *Schedule) int {
+	count := 0
+	for _, sim := range engine.GetSimulations() {
+		if strings.HasPrefix(sim.Name, schedule.Name+" (") {
+			count++
+		}
+	}
+	return count
+}
+
+// waitForSchedule wartet, bis der gespeicherte Zeitplan die Bedingung erfüllt, und gibt ihn zurück
+func waitForSchedule(t *testing.T, store Store, id, what string, condition func(*Schedule) bool) *Schedule {
+	t.Helper()
+
+	var schedule *Schedule
+	waitFor(t, what, func() bool {
+		schedule, _ = store.GetSchedule(id)
+		return schedule != nil && condition(schedule)
+	})
+	return schedule
+}
+
+// assertSchedules prüft Anlegen, Ausführen und Nachholen von Zeitplänen auf einem Store
+func assertSchedules(t *testing.T, store Store) {
+	t.Helper()
+
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock), WithStore(store))
+	service := &Service{engine: engine}
+
+	for _, config := range []ScheduleConfig{
+		{Name: "Ungültig", Cron: "jede Nacht"},
+		{Name: "Ungültig", Cron: "0 2 * * *", Timezone: "Mars/Olympus"},
+		{Name: "Ungültig", Cron: "0 2 * * *", Overlap: "parallel"},
+		{Cron: "0 2 * * *"},
+	} {
+		if _, err := service.CreateSchedule(config); !errors.Is(err, ErrInvalidParameter) {
+			t.Fatalf("Erwarteter Fehler für %+v: %v, Erhaltener Fehler: %v", config, ErrInvalidParameter, err)
+		}
+	}
+
+	// Jede Nacht um 2 Uhr Berliner Zeit, im Winter 1 Uhr UTC
+	schedule, err := service.CreateSchedule(ScheduleConfig{
+		Name:             "Nächtlich",
+		Cron:             "0 2 * * *",
+		Timezone:         "Europe/Berlin",
+		InfrastructureID: "infra-1",
+		ScenarioID:       "scenario-2",
+		Parameters:       map[string]interface{}{ParameterSpeed: SpeedInstant},
+	})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen des Zeitplans: %v", err)
+	}
+	if want := time.Date(2025, 1, 2, 1, 0, 0, 0, time.UTC); !schedule.Enabled || schedule.Overlap != OverlapSkip || !schedule.NextRunAt.Equal(want) {
+		t.Fatalf("Erwartet: eingeschaltet, skip, nächste Ausführung %v, Erhalten: %+v", want, schedule)
+	}
+
+	service.StartSchedules(time.Minute)
+	clock.Advance(13 * time.Hour)
+	started := waitForSchedule(t, store, schedule.ID, "Start der geplanten Simulation", func(s *Schedule) bool {
+		return s.LastOutcome == ScheduleStarted
+	})
+	if want := time.Date(2025, 1, 3, 1, 0, 0, 0, time.UTC); !started.NextRunAt.Equal(want) || started.LastRunAt == nil {
+		t.Fatalf("Erwartet: nächste Ausführung %v, Erhalten: %v (zuletzt %v)", want, started.NextRunAt, started.LastRunAt)
+	}
+	sim, err := engine.GetSimulation(started.LastSimulationID)
+	if err != nil {
+		t.Fatalf("Die geplante Simulation fehlt: %v", err)
+	}
+	if sim.InfrastructureID != "infra-1" || sim.ScenarioID != "scenario-2" {
+		t.Fatalf("Unerwartete Konfiguration der geplanten Simulation: %+v", sim)
+	}
+	service.stopSchedules()
+
+	// Nach einem Neustart werden verpasste Ausführungen einmal nachgeholt
+	clock.Advance(73 * time.Hour)
+	restarted := NewEngine(WithClock(clock), WithStore(store))
+	restartedService := &Service{engine: restarted}
+	restartedService.StartSchedules(time.Minute)
+	defer restartedService.stopSchedules()
+
+	caughtUp := waitForSchedule(t, store, schedule.ID, "Nachholen der verpassten Ausführung", func(s *Schedule) bool {
+		return s.LastSimulationID != started.LastSimulationID
+	})
+	if want := time.Date(2025, 1, 6, 1, 0, 0, 0, time.UTC); !caughtUp.NextRunAt.Equal(want) {
+		t.Fatalf("Erwartete nächste Ausführung: %v, Erhalten: %v", want, caughtUp.NextRunAt)
+	}
+	if count := scheduledSimulations(restarted, schedule); count != 2 {
+		t.Fatalf("Erwartet: 2 geplante Simulationen, Erhalten: %d", count)
+	}
+
+	// Ausgeschaltete Zeitpläne haben keine nächste Ausführung
+	disabled := false
+	updated, err := restartedService.UpdateSchedule(schedule.ID, ScheduleConfig{Name: "Nächtlich", Cron: "0 3 * * *", Enabled: &disabled})
+	if err != nil {
+		t.Fatalf("Fehler beim Ändern des Zeitplans: %v", err)
+	}
+	if updated.Enabled || updated.NextRunAt != nil || updated.LastSimulationID != caughtUp.LastSimulationID {
+		t.Fatalf("Unerwarteter Zeitplan nach dem Ausschalten: %+v", updated)
+	}
+
+	if err := restartedService.DeleteSchedule(schedule.ID); err != nil {
+		t.Fatalf("Fehler beim Löschen des Zeitplans: %v", err)
+	}
+	if _, err := restartedService.GetSchedule(schedule.ID); !errors.Is(err, ErrScheduleNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrScheduleNotFound, err)
+	}
+	if err := restartedService.DeleteSchedule(schedule.ID); !errors.Is(err, ErrScheduleNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrScheduleNotFound, err)
+	}
+}
+
+func TestSchedulesInMemory(t *testing.T) {
+	assertSchedules(t, NewMemoryStore())
+}
+
+func TestSchedulesInSQLite(t *testing.T) {
+	assertSchedules(t, openSQLiteRepository(t, filepath.Join(t.TempDir(), "aegis.db")))
+}
+
+func TestScheduleOverlap(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	store := NewMemoryStore()
+	engine := NewEngine(WithClock(clock), WithStore(store))
+	service := &Service{engine: engine}
+
+	config := ScheduleConfig{Name: "Stündlich", Cron: "@hourly", ScenarioID: "scenario-2"}
+	schedule, err := service.CreateSchedule(config)
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen des Zeitplans: %v", err)
+	}
+	service.StartSchedules(time.Minute)
+	defer service.stopSchedules()
+
+	clock.Advance(time.Hour)
+	first := waitForSchedule(t, store, schedule.ID, "erste Ausführung", func(s *Schedule) bool {
+		return s.LastOutcome == ScheduleStarted
+	})
+	// Die pausierte Simulation bleibt aktiv, bis sie gestoppt wird
+	if _, err := engine.PauseSimulation(first.LastSimulationID); err != nil {
+		t.Fatalf("Fehler beim Pausieren der Simulation: %v", err)
+	}
+
+	// skip: die Ausführung entfällt
+	clock.Advance(time.Hour)
+	skipped := waitForSchedule(t, store, schedule.ID, "übersprungene Ausführung", func(s *Schedule) bool {
+		return s.LastOutcome == ScheduleSkipped
+	})
+	if skipped.LastSimulationID != first.LastSimulationID || skipped.Pending {
+		t.Fatalf("Es sollte keine Simulation gestartet oder zurückgestellt werden: %+v", skipped)
+	}
+
+	// queue: die Ausführung wartet auf das Ende der vorigen Simulation
+	config.Overlap = OverlapQueue
+	if _, err := service.UpdateSchedule(schedule.ID, config); err != nil {
+		t.Fatalf("Fehler beim Ändern des Zeitplans: %v", err)
+	}
+	clock.Advance(time.Hour)
+	waitForSchedule(t, store, schedule.ID, "zurückgestellte Ausführung", func(s *Schedule) bool {
+		return s.LastOutcome == ScheduleQueued && s.Pending
+	})
+	if count := scheduledSimulations(engine, schedule); count != 1 {
+		t.Fatalf("Erwartet: 1 geplante Simulation, Erhalten: %d", count)
+	}
+
+	if _, err := engine.StopSimulation(first.LastSimulationID); err != nil {
+		t.Fatalf("Fehler beim Stoppen der Simulation: %v", err)
+	}
+	clock.Advance(time.Minute)
+	queued := waitForSchedule(t, store, schedule.ID, "Start der zurückgestellten Ausführung", func(s *Schedule) bool {
+		return s.LastOutcome == ScheduleStarted && !s.Pending
+	})
+	if queued.LastSimulationID == first.LastSimulationID {
+		t.Fatal("Die zurückgestellte Ausführung sollte eine neue Simulation starten")
+	}
+}
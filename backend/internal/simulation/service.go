@@ -2,6 +2,7 @@
 package simulation
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -12,23 +13,54 @@ import (
 type Service struct {
 	engine *Engine
 	once   sync.Once
+
+	schedulesStop chan struct{} // gesetzt, solange StartSchedules die Zeitpläne prüft
+	schedulesDone chan struct{}
 }
 
 // Singleton-Instanz
 var instance *Service
 var once sync.Once
 
-// GetService gibt die Singleton-Instanz des Services zurück
-func GetService() *Service {
+// InitService initialisiert die Singleton-Instanz mit einer konfigurierten Engine.
+// Muss vor dem ersten Aufruf von GetService erfolgen, sonst bleibt die bestehende
+// Instanz erhalten.
+func InitService(opts ...EngineOption) *Service {
 	once.Do(func() {
 		instance = &Service{
-			engine: NewEngine(),
+			engine: NewEngine(opts...),
 		}
 		logging.Logger.Info("Simulations-Service initialisiert")
 	})
 	return instance
 }
 
+// GetService gibt die Singleton-Instanz des Services zurück
+func GetService() *Service {
+	return InitService()
+}
+
+// ResumeInterrupted setzt Simulationen fort, die beim letzten Beenden des Servers
+// noch nicht abgeschlossen waren
+func (s *Service) ResumeInterrupted() int {
+	resumed := s.engine.ResumeInterrupted()
+	if resumed > 0 {
+		logging.Logger.Infof("%d unterbrochene Simulation(en) fortgesetzt", resumed)
+	}
+	return resumed
+}
+
+// Shutdown beendet die Zeitpläne, sichert alle laufenden Simulationen und hält ihre
+// Worker an
+func (s *Service) Shutdown(ctx context.Context) error {
+	s.stopSchedules()
+	if err := s.engine.Shutdown(ctx); err != nil {
+		logging.Logger.Errorf("Fehler beim Herunterfahren der Simulations-Engine: %v", err)
+		return err
+	}
+	return nil
+}
+
 // CreateSimulation erstellt eine neue Simulation
 func (s *Service) CreateSimulation(config SimulationConfig) (*Simulation, error) {
 	simulation, err := s.engine.CreateSimulation(config)
@@ -59,6 +91,224 @@ func (s *Service) StopSimulation(id string) (*Simulation, error) {
 	return simulation, nil
 }
 
+// DeleteSimulation löscht eine Simulation (siehe Engine.DeleteSimulation)
+func (s *Service) DeleteSimulation(id string, force bool) error {
+	if err := s.engine.DeleteSimulation(id, force); err != nil {
+		logging.Logger.Errorf("Fehler beim Löschen der Simulation %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// ArchiveSimulation archiviert eine beendete Simulation
+func (s *Service) ArchiveSimulation(id string) (*Simulation, error) {
+	simulation, err := s.engine.ArchiveSimulation(id)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Archivieren der Simulation %s: %v", id, err)
+		return nil, err
+	}
+	return simulation, nil
+}
+
+// UnarchiveSimulation holt eine Simulation aus dem Archiv
+func (s *Service) UnarchiveSimulation(id string) (*Simulation, error) {
+	simulation, err := s.engine.UnarchiveSimulation(id)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Zurückholen der Simulation %s aus dem Archiv: %v", id, err)
+		return nil, err
+	}
+	return simulation, nil
+}
+
+// CloneSimulation kopiert die Konfiguration einer Simulation in eine neue (siehe Engine.CloneSimulation)
+func (s *Service) CloneSimulation(id string, overrides SimulationConfig) (*Simulation, error) {
+	simulation, err := s.engine.CloneSimulation(id, overrides)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Kopieren der Simulation %s: %v", id, err)
+		return nil, err
+	}
+	return simulation, nil
+}
+
+// GetRuns gibt alle Läufe einer Simulation zurück
+func (s *Service) GetRuns(simulationID string) ([]*SimulationRun, error) {
+	runs, err := s.engine.GetRuns(simulationID)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abrufen der Läufe der Simulation %s: %v", simulationID, err)
+		return nil, err
+	}
+	return runs, nil
+}
+
+// GetRun gibt einen Lauf einer Simulation zurück
+func (s *Service) GetRun(simulationID, runID string) (*SimulationRun, error) {
+	run, err := s.engine.GetRun(simulationID, runID)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abrufen des Laufs %s der Simulation %s: %v", runID, simulationID, err)
+		return nil, err
+	}
+	return run, nil
+}
+
+// GetRunEvents gibt die Events eines Laufs zurück
+func (s *Service) GetRunEvents(simulationID, runID string) ([]SimulationEvent, error) {
+	events, err := s.engine.GetRunEvents(simulationID, runID)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abrufen der Events des Laufs %s: %v", runID, err)
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetRunResources gibt die betroffenen Ressourcen eines Laufs zurück
+func (s *Service) GetRunResources(simulationID, runID string) ([]AffectedResource, error) {
+	resources, err := s.engine.GetRunResources(simulationID, runID)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abrufen der Ressourcen des Laufs %s: %v", runID, err)
+		return nil, err
+	}
+	return resources, nil
+}
+
+// CompareRuns vergleicht zwei beendete Läufe auf derselben Infrastruktur
+func (s *Service) CompareRuns(a, b RunSelector) (*RunComparison, error) {
+	comparison, err := s.engine.CompareRuns(a, b)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Vergleichen der Simulationen %s und %s: %v", a.SimulationID, b.SimulationID, err)
+		return nil, err
+	}
+	return comparison, nil
+}
+
+// GetScenarios gibt alle Szenarien zurück
+func (s *Service) GetScenarios() ([]*Scenario, error) {
+	scenarios, err := s.engine.GetScenarios()
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abrufen der Szenarien: %v", err)
+		return nil, err
+	}
+	return scenarios, nil
+}
+
+// GetScenario gibt ein Szenario zurück
+func (s *Service) GetScenario(id string) (*Scenario, error) {
+	scenario, err := s.engine.GetScenario(id)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abrufen des Szenarios %s: %v", id, err)
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// CreateScenario legt ein eigenes Szenario an
+func (s *Service) CreateScenario(scenario Scenario) (*Scenario, error) {
+	created, err := s.engine.CreateScenario(scenario)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Erstellen des Szenarios: %v", err)
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateScenario ersetzt ein eigenes Szenario
+func (s *Service) UpdateScenario(id string, scenario Scenario) (*Scenario, error) {
+	updated, err := s.engine.UpdateScenario(id, scenario)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Ändern des Szenarios %s: %v", id, err)
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteScenario löscht ein eigenes Szenario
+func (s *Service) DeleteScenario(id string) error {
+	if err := s.engine.DeleteScenario(id); err != nil {
+		logging.Logger.Errorf("Fehler beim Löschen des Szenarios %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// ImportScenario legt ein Szenario aus einer YAML-Datei an oder ersetzt es
+func (s *Service) ImportScenario(data []byte) (*Scenario, error) {
+	scenario, err := s.engine.ImportScenario(data)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Importieren des Szenarios: %v", err)
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// ExportScenario gibt ein Szenario als YAML-Datei zurück
+func (s *Service) ExportScenario(id string) ([]byte, error) {
+	data, err := s.engine.ExportScenario(id)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Exportieren des Szenarios %s: %v", id, err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// LoadScenarioDirectory lädt die Szenariodateien eines Verzeichnisses
+func (s *Service) LoadScenarioDirectory(dir string) (int, error) {
+	loaded, err := s.engine.LoadScenarioDirectory(dir)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Laden der Szenariodateien: %v", err)
+		return 0, err
+	}
+	logging.Logger.Infof("%d Szenarien aus %s geladen", loaded, dir)
+	return loaded, nil
+}
+
+// CreateSchedule legt einen Zeitplan an
+func (s *Service) CreateSchedule(config ScheduleConfig) (*Schedule, error) {
+	schedule, err := s.engine.CreateSchedule(config)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Erstellen des Zeitplans: %v", err)
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// GetSchedules gibt alle Zeitpläne zurück
+func (s *Service) GetSchedules() ([]*Schedule, error) {
+	schedules, err := s.engine.GetSchedules()
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abrufen der Zeitpläne: %v", err)
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// GetSchedule gibt einen Zeitplan zurück
+func (s *Service) GetSchedule(id string) (*Schedule, error) {
+	schedule, err := s.engine.GetSchedule(id)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abrufen des Zeitplans %s: %v", id, err)
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// UpdateSchedule ersetzt die Konfiguration eines Zeitplans
+func (s *Service) UpdateSchedule(id string, config ScheduleConfig) (*Schedule, error) {
+	schedule, err := s.engine.UpdateSchedule(id, config)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Ändern des Zeitplans %s: %v", id, err)
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// DeleteSchedule löscht einen Zeitplan
+func (s *Service) DeleteSchedule(id string) error {
+	if err := s.engine.DeleteSchedule(id); err != nil {
+		logging.Logger.Errorf("Fehler beim Löschen des Zeitplans %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
 // PauseSimulation pausiert eine Simulation
 func (s *Service) PauseSimulation(id string) (*Simulation, error) {
 	simulation, err := s.engine.PauseSimulation(id)
@@ -69,6 +319,26 @@ func (s *Service) PauseSimulation(id string) (*Simulation, error) {
 	return simulation, nil
 }
 
+// ResumeSimulation setzt eine pausierte Simulation fort
+func (s *Service) ResumeSimulation(id string) (*Simulation, error) {
+	simulation, err := s.engine.ResumeSimulation(id)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Fortsetzen der Simulation %s: %v", id, err)
+		return nil, err
+	}
+	return simulation, nil
+}
+
+// InjectDefensiveAction wendet eine Abwehraktion auf eine laufende Simulation an
+func (s *Service) InjectDefensiveAction(id string, action DefensiveAction) (*SimulationEvent, error) {
+	event, err := s.engine.InjectDefensiveAction(id, action)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Anwenden der Abwehraktion auf Simulation %s: %v", id, err)
+		return nil, err
+	}
+	return event, nil
+}
+
 // GetSimulation gibt eine Simulation zurück
 func (s *Service) GetSimulation(id string) (*Simulation, error) {
 	simulation, err := s.engine.GetSimulation(id)
@@ -84,6 +354,16 @@ func (s *Service) GetSimulations() []*Simulation {
 	return s.engine.GetSimulations()
 }
 
+// QuerySimulations gibt eine sortierte und gefilterte Seite der Simulationen zurück (siehe Engine.QuerySimulations)
+func (s *Service) QuerySimulations(query SimulationQuery) (*SimulationPage, error) {
+	page, err := s.engine.QuerySimulations(query)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abfragen der Simulationen: %v", err)
+		return nil, err
+	}
+	return page, nil
+}
+
 // GetSimulationStatus gibt den Status einer Simulation zurück
 func (s *Service) GetSimulationStatus(id string) (*SimulationStatus, error) {
 	status, err := s.engine.GetSimulationStatus(id)
@@ -104,6 +384,26 @@ func (s *Service) GetEvents(simulationID string) ([]SimulationEvent, error) {
 	return events, nil
 }
 
+// QueryEvents gibt eine gefilterte Seite der Events einer Simulation zurück (siehe Engine.QueryEvents)
+func (s *Service) QueryEvents(simulationID string, filter EventFilter, cursor string, limit int) (*EventPage, error) {
+	page, err := s.engine.QueryEvents(simulationID, filter, cursor, limit)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abfragen der Events der Simulation %s: %v", simulationID, err)
+		return nil, err
+	}
+	return page, nil
+}
+
+// Subscribe abonniert die Updates einer Simulation (siehe Engine.Subscribe)
+func (s *Service) Subscribe(simulationID string) (<-chan SimulationUpdate, func(), error) {
+	updates, cancel, err := s.engine.Subscribe(simulationID)
+	if err != nil {
+		logging.Logger.Errorf("Fehler beim Abonnieren der Simulation %s: %v", simulationID, err)
+		return nil, nil, err
+	}
+	return updates, cancel, nil
+}
+
 // GetAffectedResources gibt die betroffenen Ressourcen einer Simulation zurück
 func (s *Service) GetAffectedResources(simulationID string) ([]AffectedResource, error) {
 	resources, err := s.engine.GetAffectedResources(simulationID)
@@ -121,7 +421,7 @@ func (s *Service) AddMockData() {
         Name:            "Demo-Simulation",
         Description:     "Automatisch generierte Beispielsimulation für Testzwecke",
         InfrastructureID: "infrastructure-demo",
-        ScenarioID:      "scenario-basic-pentest",
+        ScenarioID:      "scenario-1",
     }
     
     sim, err := s.CreateSimulation(config)
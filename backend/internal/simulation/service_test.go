@@ -15,7 +15,7 @@ func TestSimulationService(t *testing.T) {
         Name:            "Test Simulation",
         Description:     "Eine Testsimulation",
         InfrastructureID: "infrastructure-test",
-        ScenarioID:      "scenario-test",
+        ScenarioID:      "scenario-1",
     }
     
     // Simulation erstellen
@@ -82,4 +82,74 @@ func TestSimulationService(t *testing.T) {
     if stoppedSim.EndTime == nil {
         t.Fatal("EndTime ist nil nach dem Stoppen der Simulation")
     }
-}
\ No newline at end of file
+}
+func TestPauseResumeSimulation(t *testing.T) {
+	service := GetService()
+
+	sim, err := service.CreateSimulation(SimulationConfig{
+		Name:             "Pause Simulation",
+		InfrastructureID: "infrastructure-test",
+		ScenarioID:       "scenario-1",
+	})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	if _, err := service.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+
+	// Simulation pausieren
+	pausedSim, err := service.PauseSimulation(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Pausieren der Simulation: %v", err)
+	}
+	if pausedSim.Status != StatusPaused {
+		t.Fatalf("Erwarteter Status: %s, Erhaltener Status: %s", StatusPaused, pausedSim.Status)
+	}
+	if pausedSim.PausedAt == nil {
+		t.Fatal("PausedAt ist nil nach dem Pausieren der Simulation")
+	}
+
+	// Während der Pause darf die Laufzeit nicht weiterlaufen
+	before, _ := service.GetSimulationStatus(sim.ID)
+	time.Sleep(1100 * time.Millisecond)
+	after, _ := service.GetSimulationStatus(sim.ID)
+	if before.Runtime != after.Runtime {
+		t.Fatalf("Laufzeit hat sich während der Pause verändert: %s -> %s", before.Runtime, after.Runtime)
+	}
+
+	// Simulation fortsetzen
+	resumedSim, err := service.ResumeSimulation(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Fortsetzen der Simulation: %v", err)
+	}
+	if resumedSim.Status != StatusRunning {
+		t.Fatalf("Erwarteter Status: %s, Erhaltener Status: %s", StatusRunning, resumedSim.Status)
+	}
+	if resumedSim.PausedAt != nil || resumedSim.PausedDuration <= 0 {
+		t.Fatal("Pausenzeit wurde beim Fortsetzen nicht verbucht")
+	}
+
+	// Pause und Fortsetzen erzeugen jeweils ein System-Event
+	events, err := service.GetEvents(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen der Events: %v", err)
+	}
+	var systemEvents []string
+	for _, event := range events {
+		if event.Type == EventTypeSystem {
+			systemEvents = append(systemEvents, event.Description)
+		}
+	}
+	expected := []string{"Simulation gestartet", "Simulation pausiert", "Simulation fortgesetzt"}
+	if len(systemEvents) != len(expected) {
+		t.Fatalf("Erwartete System-Events: %v, Erhaltene: %v", expected, systemEvents)
+	}
+	for i := range expected {
+		if systemEvents[i] != expected[i] {
+			t.Fatalf("Erwartete System-Events: %v, Erhaltene: %v", expected, systemEvents)
+		}
+	}
+
+	service.StopSimulation(sim.ID)
+}
@@ -0,0 +1,70 @@
+// backend/internal/simulation/shutdown.go
+package simulation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+)
+
+// ErrShuttingDown wird zurückgegeben, wenn die Engine heruntergefahren wird und keine
+// Simulationen mehr startet
+var ErrShuttingDown = errors.New("Simulations-Engine wird heruntergefahren")
+
+// Shutdown hält alle Worker an, ohne die Simulationen zu beenden. Laufende und
+// pausierte Simulationen behalten ihren Status und werden mit ihrem aktuellen Stand
+// gesichert, wartende bleiben in der Warteschlange; nach einem Neustart setzt
+// ResumeInterrupted sie fort. Shutdown wartet, bis alle Worker ihre letzten
+// Schreibzugriffe auf den Store abgeschlossen haben, höchstens bis ctx abläuft.
+func (e *Engine) Shutdown(ctx context.Context) error {
+	e.mutex.Lock()
+	if e.shuttingDown {
+		e.unlock()
+		return nil
+	}
+	e.shuttingDown = true
+	if e.janitorStop != nil {
+		close(e.janitorStop)
+	}
+
+	now := e.clock.Now()
+	interrupted := 0
+	for run := range e.runs {
+		id := run.simulationID
+		simulation, exists := e.simulations[id]
+		if !exists || e.stopChannels[id] != run.stopChan {
+			continue
+		}
+
+		// Ohne Eintrag in stopChannels verwirft tick jedes weitere Update dieses Laufs
+		close(run.stopChan)
+		delete(e.stopChannels, id)
+		delete(e.resumeChannels, id)
+
+		// Die Zeit bis zum Neustart zählt ab jetzt als Pause, nicht ab dem letzten Update
+		simulation.UpdatedAt = now
+		e.checkpointLocked(id, run.state)
+		e.saveSimulationLocked(simulation)
+		interrupted++
+	}
+	queued := len(e.queuedRuns)
+	e.unlock()
+
+	logging.Logger.Infof("Simulations-Engine wird heruntergefahren: %d Simulation(en) gesichert, %d wartend", interrupted, queued)
+
+	done := make(chan struct{})
+	go func() {
+		e.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logging.Logger.Info("Alle Simulations-Worker beendet")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("Worker wurden nicht rechtzeitig beendet: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,67 @@
+// backend/internal/simulation/shutdown_test.go
+package simulation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShutdownCheckpointsRunningSimulations(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	store := NewMemoryStore()
+	engine := NewEngine(WithClock(clock), WithStore(store), WithWorkerPool(1, 10))
+
+	running, err := engine.CreateSimulation(SimulationConfig{Name: "Läuft"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	queued, err := engine.CreateSimulation(SimulationConfig{Name: "Wartet"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	engine.StartSimulation(running.ID)
+	engine.StartSimulation(queued.ID)
+	waitFor(t, "Ticker von Worker und Watchdog", func() bool { return clock.activeTickers() == 2 })
+	clock.Advance(baseTickInterval)
+	waitFor(t, "Fortschritt nach Tick", func() bool { return simulationProgress(engine, running.ID) > 0 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := engine.Shutdown(ctx); err != nil {
+		t.Fatalf("Fehler beim Herunterfahren: %v", err)
+	}
+
+	// Der Worker ist beendet, die Warteschlange bleibt unverändert
+	if status, _ := simulationStatus(engine, running.ID); status != StatusRunning {
+		t.Fatalf("Laufende Simulation sollte %s bleiben, ist aber %s", StatusRunning, status)
+	}
+	if status, _ := simulationStatus(engine, queued.ID); status != StatusQueued {
+		t.Fatalf("Wartende Simulation sollte %s bleiben, ist aber %s", StatusQueued, status)
+	}
+	if _, err := store.GetCheckpoint(running.ID); err != nil {
+		t.Fatalf("Checkpoint der laufenden Simulation fehlt: %v", err)
+	}
+	if _, err := engine.StartSimulation(queued.ID); err != nil {
+		t.Fatalf("Start einer wartenden Simulation sollte nichts ändern: %v", err)
+	}
+	other, _ := engine.CreateSimulation(SimulationConfig{Name: "Zu spät"})
+	if _, err := engine.StartSimulation(other.ID); !errors.Is(err, ErrShuttingDown) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhalten: %v", ErrShuttingDown, err)
+	}
+
+	// Nach dem Neustart laufen beide Simulationen weiter
+	restarted := NewEngine(WithClock(clock), WithStore(store), WithWorkerPool(1, 10))
+	if resumed := restarted.ResumeInterrupted(); resumed != 2 {
+		t.Fatalf("Erwartet: 2 fortgesetzte Simulationen, Erhalten: %d", resumed)
+	}
+	defer restarted.StopSimulation(queued.ID)
+	defer restarted.StopSimulation(running.ID)
+	if status, _ := simulationStatus(restarted, running.ID); status != StatusRunning {
+		t.Fatalf("Simulation sollte nach Neustart laufen, ist aber %s", status)
+	}
+	if status, position := simulationStatus(restarted, queued.ID); status != StatusQueued || position != 1 {
+		t.Fatalf("Simulation sollte an Position 1 warten, ist aber %s (Position %d)", status, position)
+	}
+}
@@ -0,0 +1,26 @@
+// backend/internal/simulation/sqlite.go
+package simulation
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// SQLiteDSN baut den Verbindungsstring für eine SQLite-Datei mit Fremdschlüsseln,
+// Write-Ahead-Log und Wartezeit bei gesperrter Datenbank
+func SQLiteDSN(path string) string {
+	return fmt.Sprintf("file:%s?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000", path)
+}
+
+// NewSQLiteRepository erstellt ein Repository auf einer SQLite-Datenbank, deren Schema
+// bereits migriert ist. SQLite erlaubt nur einen Schreiber; die Verbindungen werden
+// deshalb auf eine begrenzt, was bei ":memory:" zugleich alle Zugriffe auf dieselbe
+// Datenbank lenkt.
+func NewSQLiteRepository(db *sql.DB) *Repository {
+	db.SetMaxOpenConns(1)
+
+	return &Repository{
+		db:      db,
+		dialect: dialectSQLite,
+	}
+}
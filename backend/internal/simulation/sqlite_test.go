@@ -0,0 +1,93 @@
+// backend/internal/simulation/sqlite_test.go
+package simulation
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/migrations"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openSQLiteRepository(t *testing.T, path string) *Repository {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", SQLiteDSN(path))
+	if err != nil {
+		t.Fatalf("Fehler beim Öffnen der SQLite-Datenbank: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	repository := NewSQLiteRepository(db)
+	migrator, err := migrations.NewMigrator(db, migrations.SQLite)
+	if err != nil {
+		t.Fatalf("Fehler beim Laden der Migrationen: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Fehler beim Migrieren der Datenbank: %v", err)
+	}
+	return repository
+}
+
+func TestSQLiteRepositoryPersistsSimulations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aegis.db")
+	assertReloadsFromStore(t, openSQLiteRepository(t, path))
+
+	// Eine zweite Verbindung auf dieselbe Datei findet die Simulation ebenfalls
+	reopened := openSQLiteRepository(t, path)
+	simulations, err := reopened.GetAllSimulations()
+	if err != nil || len(simulations) != 1 {
+		t.Fatalf("Erwartet: 1 gespeicherte Simulation, Erhalten: %d (%v)", len(simulations), err)
+	}
+	if simulations[0].Seed != 7 || simulations[0].Parameters[ParameterSeed] != 7.0 {
+		t.Fatalf("Seed und Parameter wurden nicht gespeichert: %d, %v", simulations[0].Seed, simulations[0].Parameters)
+	}
+
+	if _, err := reopened.GetSimulation("unbekannt"); !errors.Is(err, ErrSimulationNotFound) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrSimulationNotFound, err)
+	}
+}
+
+func TestSQLiteRepositoryComparesTimestampsInUTC(t *testing.T) {
+	store := openSQLiteRepository(t, filepath.Join(t.TempDir(), "aegis.db"))
+
+	// Die Uhr liefert Zeitpunkte in einer Zone mit Offset, die Abfragen kommen in UTC
+	local := time.FixedZone("MESZ", 2*60*60)
+	engine := NewEngine(WithClock(newFakeClock(time.Date(2025, 6, 1, 12, 0, 0, 0, local))), WithStore(store))
+	sim := runInstant(t, engine, SimulationConfig{Name: "Ortszeit"})
+	if sim.CreatedAt.Location() != local {
+		t.Fatalf("Erwartet: Zeitpunkt in %s, Erhalten: %s", local, sim.CreatedAt.Location())
+	}
+
+	later := sim.CreatedAt.Add(time.Hour).UTC()
+	page, _, err := store.QuerySimulations(SimulationQuery{SimulationFilter: SimulationFilter{CreatedAfter: &later}})
+	if err != nil || len(page) != 0 {
+		t.Fatalf("Erwartet: keine Simulation nach %s, Erhalten: %d (%v)", later, len(page), err)
+	}
+	page, _, err = store.QuerySimulations(SimulationQuery{SimulationFilter: SimulationFilter{CreatedBefore: &later}})
+	if err != nil || len(page) != 1 {
+		t.Fatalf("Erwartet: 1 Simulation vor %s, Erhalten: %d (%v)", later, len(page), err)
+	}
+
+	events, err := store.GetEvents(sim.ID, sim.RunID)
+	if err != nil || len(events) == 0 {
+		t.Fatalf("Erwartet: gespeicherte Events, Erhalten: %d (%v)", len(events), err)
+	}
+	until := events[0].Timestamp.Add(30 * time.Minute).UTC()
+	since := events[len(events)-1].Timestamp.Add(30 * time.Minute).UTC()
+	for _, c := range []struct {
+		filter EventFilter
+		empty  bool
+	}{
+		{EventFilter{Until: &until}, false},
+		{EventFilter{Since: &since}, true},
+	} {
+		found, err := store.QueryEvents(sim.ID, sim.RunID, EventQuery{EventFilter: c.filter})
+		if err != nil || (len(found) == 0) != c.empty {
+			t.Fatalf("Unerwartete Events für %+v: %d (%v)", c.filter, len(found), err)
+		}
+	}
+}
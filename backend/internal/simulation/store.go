@@ -0,0 +1,569 @@
+// backend/internal/simulation/store.go
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+)
+
+// ErrSimulationNotFound wird von einem Store zurückgegeben, wenn eine Simulation nicht gespeichert ist
+var ErrSimulationNotFound = errors.New("Simulation nicht gefunden")
+
+// Store speichert Simulationen, ihre Läufe, Events und betroffenen Ressourcen dauerhaft.
+// Die Engine schreibt jede Änderung nach dem Freigeben ihrer Sperre durch und lädt
+// Simulationen aus dem Store, die sie nicht im Speicher hat, etwa nach einem Neustart.
+// Events und Ressourcen werden je Lauf gelesen.
+type Store interface {
+	SaveSimulation(sim *Simulation) error
+	GetSimulation(id string) (*Simulation, error)
+	GetAllSimulations() ([]*Simulation, error)
+	QuerySimulations(query SimulationQuery) ([]*Simulation, int, error)
+	DeleteSimulation(id string) error
+	ExpiredSimulations(endedBefore time.Time) ([]string, error)
+	SaveRun(run *SimulationRun) error
+	GetRuns(simulationID string) ([]*SimulationRun, error)
+	SaveEvent(event SimulationEvent) error
+	GetEvents(simulationID, runID string) ([]SimulationEvent, error)
+	PurgeEvents(endedBefore time.Time) ([]string, error)
+	QueryEvents(simulationID, runID string, query EventQuery) ([]SimulationEvent, error)
+	SaveAffectedResource(resource AffectedResource) error
+	GetAffectedResources(simulationID, runID string) ([]AffectedResource, error)
+	SaveCheckpoint(checkpoint Checkpoint) error
+	GetCheckpoint(simulationID string) (*Checkpoint, error)
+	DeleteCheckpoint(simulationID string) error
+	SaveSchedule(schedule *Schedule) error
+	GetSchedule(id string) (*Schedule, error)
+	GetSchedules() ([]*Schedule, error)
+	DeleteSchedule(id string) error
+	ScenarioStore
+}
+
+// ScenarioStore speichert die eigenen Szenarien; die mitgelieferten liefert die Engine selbst
+type ScenarioStore interface {
+	SaveScenario(scenario *Scenario) error
+	GetScenario(id string) (*Scenario, error)
+	GetScenarios() ([]*Scenario, error)
+	DeleteScenario(id string) error
+}
+
+// MemoryStore ist ein Store ohne Datenbank; seine Daten gehen beim Beenden verloren
+type MemoryStore struct {
+	mutex       sync.RWMutex
+	simulations map[string]Simulation
+	runs        map[string][]SimulationRun
+	events      map[string][]SimulationEvent
+	eventIDs    map[string]map[string]bool // IDs der gespeicherten Events je Simulation
+	resources   map[string][]AffectedResource
+	checkpoints map[string]Checkpoint
+	schedules   map[string]Schedule
+	scenarios   map[string]Scenario
+}
+
+// NewMemoryStore erstellt einen leeren MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		simulations: make(map[string]Simulation),
+		runs:        make(map[string][]SimulationRun),
+		events:      make(map[string][]SimulationEvent),
+		eventIDs:    make(map[string]map[string]bool),
+		resources:   make(map[string][]AffectedResource),
+		checkpoints: make(map[string]Checkpoint),
+		schedules:   make(map[string]Schedule),
+		scenarios:   make(map[string]Scenario),
+	}
+}
+
+// SaveSimulation legt eine Kopie der Simulation ab
+func (s *MemoryStore) SaveSimulation(sim *Simulation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.simulations[sim.ID] = *sim
+	return nil
+}
+
+// GetSimulation gibt eine Kopie der gespeicherten Simulation zurück
+func (s *MemoryStore) GetSimulation(id string) (*Simulation, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	sim, exists := s.simulations[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSimulationNotFound, id)
+	}
+	return &sim, nil
+}
+
+// GetAllSimulations gibt alle Simulationen zurück, die neuesten zuerst
+func (s *MemoryStore) GetAllSimulations() ([]*Simulation, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	simulations := make([]*Simulation, 0, len(s.simulations))
+	for _, sim := range s.simulations {
+		sim := sim
+		simulations = append(simulations, &sim)
+	}
+	sort.Slice(simulations, func(i, j int) bool {
+		return simulations[i].CreatedAt.After(simulations[j].CreatedAt)
+	})
+	return simulations, nil
+}
+
+// QuerySimulations gibt eine Seite der passenden Simulationen und deren Gesamtzahl zurück
+func (s *MemoryStore) QuerySimulations(query SimulationQuery) ([]*Simulation, int, error) {
+	simulations, err := s.GetAllSimulations()
+	if err != nil {
+		return nil, 0, err
+	}
+	return query.apply(simulations)
+}
+
+// DeleteSimulation entfernt eine Simulation mit allen zugehörigen Daten
+func (s *MemoryStore) DeleteSimulation(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.deleteLocked(id)
+	return nil
+}
+
+// ExpiredSimulations gibt die IDs der beendeten Simulationen zurück, deren Läufe alle
+// vor endedBefore endeten
+func (s *MemoryStore) ExpiredSimulations(endedBefore time.Time) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var ids []string
+	for id, sim := range s.simulations {
+		runs := s.runs[id]
+		if !containsStatus(finishedStatuses, sim.Status) || len(runs) == 0 {
+			continue
+		}
+		expired := true
+		for i := range runs {
+			expired = expired && runEndedBefore(&runs[i], endedBefore)
+		}
+		if expired {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (s *MemoryStore) deleteLocked(id string) {
+	delete(s.simulations, id)
+	delete(s.runs, id)
+	delete(s.events, id)
+	delete(s.eventIDs, id)
+	delete(s.resources, id)
+	delete(s.checkpoints, id)
+}
+
+// runEndedBefore gibt an, ob ein Lauf beendet ist und vor dem Zeitpunkt endete
+func runEndedBefore(run *SimulationRun, endedBefore time.Time) bool {
+	return containsStatus(finishedStatuses, run.Status) && run.EndTime != nil && run.EndTime.Before(endedBefore)
+}
+
+// SaveEvent hängt ein Event an; ein bereits gespeichertes Event bleibt unverändert
+func (s *MemoryStore) SaveEvent(event SimulationEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ids, exists := s.eventIDs[event.SimulationID]
+	if !exists {
+		ids = make(map[string]bool)
+		s.eventIDs[event.SimulationID] = ids
+	}
+	if ids[event.ID] {
+		return nil
+	}
+	ids[event.ID] = true
+	s.events[event.SimulationID] = append(s.events[event.SimulationID], event)
+	return nil
+}
+
+// SaveRun legt einen Lauf an oder ersetzt ihn; der Zeitpunkt des Anlegens bleibt erhalten
+func (s *MemoryStore) SaveRun(run *SimulationRun) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	runs := s.runs[run.SimulationID]
+	for i := range runs {
+		if runs[i].ID == run.ID {
+			createdAt := runs[i].CreatedAt
+			runs[i] = *run
+			runs[i].CreatedAt = createdAt
+			return nil
+		}
+	}
+	s.runs[run.SimulationID] = append(runs, *run)
+	return nil
+}
+
+// GetRuns gibt Kopien aller Läufe einer Simulation zurück, den ersten zuerst
+func (s *MemoryStore) GetRuns(simulationID string) ([]*SimulationRun, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	runs := []*SimulationRun{}
+	for _, run := range s.runs[simulationID] {
+		run := run
+		runs = append(runs, &run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Number < runs[j].Number })
+	return runs, nil
+}
+
+// GetEvents gibt die Events eines Laufs in der Reihenfolge ihres Auftretens zurück
+func (s *MemoryStore) GetEvents(simulationID, runID string) ([]SimulationEvent, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return runEvents(s.events[simulationID], runID), nil
+}
+
+// runEvents gibt die Events eines Laufs zurück
+func runEvents(events []SimulationEvent, runID string) []SimulationEvent {
+	filtered := []SimulationEvent{}
+	for _, event := range events {
+		if event.RunID == runID {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// PurgeEvents löscht die Events aller Läufe, die vor endedBefore beendet wurden, und gibt
+// die IDs der Läufe zurück, die Events hatten
+func (s *MemoryStore) PurgeEvents(endedBefore time.Time) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ids []string
+	for simulationID, runs := range s.runs {
+		ended := make(map[string]bool)
+		for i := range runs {
+			if runEndedBefore(&runs[i], endedBefore) {
+				ended[runs[i].ID] = true
+			}
+		}
+		if len(ended) == 0 {
+			continue
+		}
+
+		purged := make(map[string]bool)
+		kept := []SimulationEvent{}
+		for _, event := range s.events[simulationID] {
+			if ended[event.RunID] {
+				purged[event.RunID] = true
+				delete(s.eventIDs[simulationID], event.ID)
+				continue
+			}
+			kept = append(kept, event)
+		}
+		s.events[simulationID] = kept
+		for id := range purged {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// QueryEvents gibt die Events eines Laufs zurück, die zur Abfrage passen,
+// aufsteigend nach Sequenz
+func (s *MemoryStore) QueryEvents(simulationID, runID string, query EventQuery) ([]SimulationEvent, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return query.apply(runEvents(s.events[simulationID], runID)), nil
+}
+
+// SaveAffectedResource legt eine Ressource an oder ersetzt sie
+func (s *MemoryStore) SaveAffectedResource(resource AffectedResource) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	resources := s.resources[resource.SimulationID]
+	for i := range resources {
+		if resources[i].ID == resource.ID && resources[i].RunID == resource.RunID {
+			resources[i] = resource
+			return nil
+		}
+	}
+	s.resources[resource.SimulationID] = append(resources, resource)
+	return nil
+}
+
+// GetAffectedResources gibt die betroffenen Ressourcen eines Laufs zurück
+func (s *MemoryStore) GetAffectedResources(simulationID, runID string) ([]AffectedResource, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var resources []AffectedResource
+	for _, resource := range s.resources[simulationID] {
+		if resource.RunID == runID {
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// SaveCheckpoint ersetzt den Checkpoint einer Simulation
+func (s *MemoryStore) SaveCheckpoint(checkpoint Checkpoint) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.checkpoints[checkpoint.SimulationID] = checkpoint
+	return nil
+}
+
+// GetCheckpoint gibt den Checkpoint einer Simulation zurück
+func (s *MemoryStore) GetCheckpoint(simulationID string) (*Checkpoint, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	checkpoint, exists := s.checkpoints[simulationID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrCheckpointNotFound, simulationID)
+	}
+	return &checkpoint, nil
+}
+
+// DeleteCheckpoint entfernt den Checkpoint einer Simulation, sofern vorhanden
+func (s *MemoryStore) DeleteCheckpoint(simulationID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.checkpoints, simulationID)
+	return nil
+}
+
+// SaveSchedule legt eine Kopie des Zeitplans ab
+func (s *MemoryStore) SaveSchedule(schedule *Schedule) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.schedules[schedule.ID] = *schedule
+	return nil
+}
+
+// GetSchedule gibt eine Kopie des gespeicherten Zeitplans zurück
+func (s *MemoryStore) GetSchedule(id string) (*Schedule, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	schedule, exists := s.schedules[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrScheduleNotFound, id)
+	}
+	return &schedule, nil
+}
+
+// GetSchedules gibt Kopien aller Zeitpläne zurück, die ältesten zuerst
+func (s *MemoryStore) GetSchedules() ([]*Schedule, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	schedules := []*Schedule{}
+	for _, schedule := range s.schedules {
+		schedule := schedule
+		schedules = append(schedules, &schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		if !schedules[i].CreatedAt.Equal(schedules[j].CreatedAt) {
+			return schedules[i].CreatedAt.Before(schedules[j].CreatedAt)
+		}
+		return schedules[i].ID < schedules[j].ID
+	})
+	return schedules, nil
+}
+
+// DeleteSchedule entfernt einen Zeitplan, sofern vorhanden
+func (s *MemoryStore) DeleteSchedule(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.schedules, id)
+	return nil
+}
+
+// SaveScenario legt eine Kopie des Szenarios ab; der Zeitpunkt der Erstellung bleibt erhalten
+func (s *MemoryStore) SaveScenario(scenario *Scenario) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored := *scenario
+	stored.Steps = append([]ScenarioStep(nil), scenario.Steps...)
+	if existing, exists := s.scenarios[scenario.ID]; exists {
+		stored.CreatedAt = existing.CreatedAt
+	}
+	s.scenarios[scenario.ID] = stored
+	return nil
+}
+
+// GetScenario gibt eine Kopie des gespeicherten Szenarios zurück
+func (s *MemoryStore) GetScenario(id string) (*Scenario, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	scenario, exists := s.scenarios[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrScenarioNotFound, id)
+	}
+	scenario.Steps = append([]ScenarioStep(nil), scenario.Steps...)
+	return &scenario, nil
+}
+
+// GetScenarios gibt Kopien aller Szenarien zurück, die ältesten zuerst
+func (s *MemoryStore) GetScenarios() ([]*Scenario, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	scenarios := []*Scenario{}
+	for _, scenario := range s.scenarios {
+		scenario := scenario
+		scenario.Steps = append([]ScenarioStep(nil), scenario.Steps...)
+		scenarios = append(scenarios, &scenario)
+	}
+	sort.Slice(scenarios, func(i, j int) bool {
+		if !scenarios[i].CreatedAt.Equal(scenarios[j].CreatedAt) {
+			return scenarios[i].CreatedAt.Before(scenarios[j].CreatedAt)
+		}
+		return scenarios[i].ID < scenarios[j].ID
+	})
+	return scenarios, nil
+}
+
+// DeleteScenario entfernt ein Szenario, sofern vorhanden
+func (s *MemoryStore) DeleteScenario(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.scenarios, id)
+	return nil
+}
+
+// queueWriteLocked merkt einen Schreibvorgang für den Store vor. Er läuft erst, wenn der
+// Aufrufer e.mutex mit unlock freigibt, damit langsame Datenbanken weder den Worker
+// noch lesende Aufrufer blockieren. Der Aufrufer muss e.mutex halten.
+func (e *Engine) queueWriteLocked(write func(Store)) {
+	e.writes = append(e.writes, write)
+}
+
+// unlock gibt e.mutex frei und führt danach die vorgemerkten Schreibvorgänge aus
+func (e *Engine) unlock() {
+	pending := len(e.writes) > 0
+	e.mutex.Unlock()
+	if pending {
+		e.flushWrites()
+	}
+}
+
+// flushWrites führt alle vorgemerkten Schreibvorgänge aus. Sie werden unter writeMutex
+// entnommen und geschrieben, sodass kein älterer Stand einen neueren überschreibt; kehrt
+// flushWrites zurück, sind auch die zuvor von anderen Aufrufern vorgemerkten geschrieben.
+func (e *Engine) flushWrites() {
+	e.writeMutex.Lock()
+	defer e.writeMutex.Unlock()
+
+	e.mutex.Lock()
+	writes := e.writes
+	e.writes = nil
+	e.mutex.Unlock()
+
+	for _, write := range writes {
+		write(e.store)
+	}
+}
+
+// saveSimulationLocked merkt den aktuellen Stand einer Simulation zum Speichern vor und
+// verteilt ihn an die Abonnenten. Fehler des Stores werden protokolliert, halten die
+// Simulation aber nicht an. Der Aufrufer muss e.mutex halten.
+func (e *Engine) saveSimulationLocked(simulation *Simulation) {
+	snapshot := *simulation
+	e.queueWriteLocked(func(store Store) {
+		if err := store.SaveSimulation(&snapshot); err != nil {
+			logging.Logger.Errorf("Simulation %s konnte nicht gespeichert werden: %v", snapshot.ID, err)
+		}
+	})
+	e.publishStatusLocked(simulation)
+}
+
+// saveResourceLocked merkt eine betroffene Ressource zum Speichern vor und verteilt sie
+// an die Abonnenten. Der Aufrufer muss e.mutex halten.
+func (e *Engine) saveResourceLocked(resource AffectedResource) {
+	e.queueWriteLocked(func(store Store) {
+		if err := store.SaveAffectedResource(resource); err != nil {
+			logging.Logger.Errorf("Ressource %s der Simulation %s konnte nicht gespeichert werden: %v", resource.ID, resource.SimulationID, err)
+		}
+	})
+	e.publishLocked(SimulationUpdate{Type: UpdateResource, SimulationID: resource.SimulationID, Resource: &resource})
+}
+
+// ensureLoaded lädt eine Simulation, die nicht im Speicher ist, samt Events und
+// Ressourcen aus dem Store. Eine Simulation, die beim Beenden des Servers noch lief,
+// hat keinen Worker mehr und wird als fehlgeschlagen markiert.
+func (e *Engine) ensureLoaded(id string) {
+	e.mutex.RLock()
+	_, exists := e.simulations[id]
+	e.mutex.RUnlock()
+	if exists {
+		return
+	}
+
+	simulation, err := e.store.GetSimulation(id)
+	if err != nil {
+		if !errors.Is(err, ErrSimulationNotFound) {
+			logging.Logger.Errorf("Simulation %s konnte nicht aus dem Store geladen werden: %v", id, err)
+		}
+		return
+	}
+	events, err := e.store.GetEvents(id, simulation.RunID)
+	if err != nil {
+		logging.Logger.Errorf("Events der Simulation %s konnten nicht geladen werden: %v", id, err)
+		return
+	}
+	resources, err := e.store.GetAffectedResources(id, simulation.RunID)
+	if err != nil {
+		logging.Logger.Errorf("Ressourcen der Simulation %s konnten nicht geladen werden: %v", id, err)
+		return
+	}
+
+	e.mutex.Lock()
+	defer e.unlock()
+
+	// Zwischenzeitlich von einem anderen Aufruf geladen
+	if !e.registerLoadedLocked(simulation, events, resources) {
+		return
+	}
+
+	switch simulation.Status {
+	case StatusRunning, StatusPaused, StatusQueued:
+		e.failLocked(simulation, "Simulation wurde durch einen Neustart des Servers unterbrochen")
+	}
+}
+
+// registerLoadedLocked übernimmt eine aus dem Store geladene Simulation in den Speicher.
+// Gibt false zurück, wenn sie dort bereits vorhanden ist. Der Aufrufer muss e.mutex halten.
+func (e *Engine) registerLoadedLocked(simulation *Simulation, events []SimulationEvent, resources []AffectedResource) bool {
+	if _, exists := e.simulations[simulation.ID]; exists {
+		return false
+	}
+	if events == nil {
+		events = []SimulationEvent{}
+	}
+	if resources == nil {
+		resources = []AffectedResource{}
+	}
+	simulation.QueuePosition = 0
+	e.simulations[simulation.ID] = simulation
+	e.events[simulation.ID] = events
+	e.affectedResources[simulation.ID] = resources
+	return true
+}
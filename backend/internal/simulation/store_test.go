@@ -0,0 +1,138 @@
+// backend/internal/simulation/store_test.go
+package simulation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// assertReloadsFromStore führt eine Simulation aus und prüft, dass eine zweite Engine
+// auf demselben Store denselben Stand sieht
+func assertReloadsFromStore(t *testing.T, store Store) {
+	t.Helper()
+
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	first := NewEngine(WithClock(clock), WithStore(store))
+
+	sim, err := first.CreateSimulation(SimulationConfig{
+		Name:       "Persistiert",
+		ScenarioID: "scenario-2",
+		Parameters: map[string]interface{}{ParameterSeed: 7.0, ParameterSpeed: SpeedInstant},
+	})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	if _, err := first.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+	// Der einzige Worker endet erst, wenn der Abschluss gespeichert ist; so hängt der
+	// Test nicht davon ab, wie schnell der Store schreibt
+	first.workers.Wait()
+	if status, _ := first.GetSimulationStatus(sim.ID); status.Status != StatusCompleted {
+		t.Fatalf("Erwartet: abgeschlossene Simulation, Erhalten: %s", status.Status)
+	}
+
+	// Eine neue Engine auf demselben Store entspricht einem Neustart des Servers
+	second := NewEngine(WithStore(store))
+
+	found := false
+	for _, listed := range second.GetSimulations() {
+		found = found || listed.ID == sim.ID
+	}
+	if !found {
+		t.Fatal("Gespeicherte Simulation fehlt in der Liste")
+	}
+
+	reloaded, err := second.GetSimulation(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Laden der Simulation: %v", err)
+	}
+	if reloaded.Status != StatusCompleted || reloaded.Results == nil {
+		t.Fatalf("Erwartet: abgeschlossene Simulation mit Bericht, Erhalten: %s (Bericht: %v)", reloaded.Status, reloaded.Results != nil)
+	}
+	if !reflect.DeepEqual(eventTrace(t, first, sim.ID), eventTrace(t, second, sim.ID)) {
+		t.Fatal("Events und Ressourcen nach dem Neuladen weichen ab")
+	}
+
+	before, _ := first.GetSimulationStatus(sim.ID)
+	after, _ := second.GetSimulationStatus(sim.ID)
+	if !reflect.DeepEqual(before, after) {
+		t.Fatalf("Status nach dem Neuladen weicht ab:\nvorher:  %+v\nnachher: %+v", before, after)
+	}
+}
+
+func TestEngineReloadsSimulationsFromStore(t *testing.T) {
+	assertReloadsFromStore(t, NewMemoryStore())
+}
+
+func TestInterruptedSimulationFailsOnReload(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	store := NewMemoryStore()
+	first := NewEngine(WithClock(clock), WithStore(store))
+
+	sim, err := first.CreateSimulation(SimulationConfig{Name: "Unterbrochen"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	first.StartSimulation(sim.ID)
+	defer first.StopSimulation(sim.ID)
+
+	second := NewEngine(WithClock(clock), WithStore(store))
+	reloaded, err := second.GetSimulation(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Laden der Simulation: %v", err)
+	}
+	if reloaded.Status != StatusFailed || reloaded.Error == "" {
+		t.Fatalf("Unterbrochene Simulation sollte %s mit Grund sein, ist aber %s (%q)", StatusFailed, reloaded.Status, reloaded.Error)
+	}
+
+	stored, _ := store.GetSimulation(sim.ID)
+	if stored.Status != StatusFailed {
+		t.Fatalf("Neuer Status wurde nicht in den Store geschrieben: %s", stored.Status)
+	}
+}
+
+// blockingStore hält das Speichern von Events an, bis release geschlossen wird
+type blockingStore struct {
+	*MemoryStore
+	saving  chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStore) SaveEvent(event SimulationEvent) error {
+	s.saving <- struct{}{}
+	<-s.release
+	return s.MemoryStore.SaveEvent(event)
+}
+
+func TestSlowStoreDoesNotBlockTheEngine(t *testing.T) {
+	store := &blockingStore{MemoryStore: NewMemoryStore(), saving: make(chan struct{}), release: make(chan struct{})}
+	engine := NewEngine(WithStore(store))
+
+	sim, err := engine.CreateSimulation(SimulationConfig{Name: "Langsamer Store"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	added := make(chan struct{})
+	go func() {
+		engine.AddEvent(sim.ID, SimulationEvent{ID: "event-1", SimulationID: sim.ID, Type: EventTypeSystem})
+		close(added)
+	}()
+	<-store.saving
+
+	// Während der Store schreibt, bleibt die Engine für Lesende erreichbar
+	if events, _ := engine.GetEvents(sim.ID); len(events) != 1 {
+		t.Fatalf("Erwartet: 1 Event im Speicher, Erhalten: %d", len(events))
+	}
+	if status, err := engine.GetSimulationStatus(sim.ID); err != nil || status.Status != StatusNotStarted {
+		t.Fatalf("Unerwarteter Status: %+v (%v)", status, err)
+	}
+
+	// AddEvent kehrt erst zurück, wenn das Event gespeichert ist
+	close(store.release)
+	<-added
+	if events, _ := store.GetEvents(sim.ID, ""); len(events) != 1 {
+		t.Fatalf("Erwartet: 1 gespeichertes Event, Erhalten: %d", len(events))
+	}
+}
@@ -0,0 +1,110 @@
+// backend/internal/simulation/subscriptions.go
+package simulation
+
+import (
+	"fmt"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+)
+
+// subscriberBuffer ist die Anzahl Updates, die ein Abonnent im Rückstand sein darf
+const subscriberBuffer = 256
+
+// UpdateType unterscheidet die Arten von Updates an Abonnenten
+type UpdateType string
+
+const (
+	UpdateEvent    UpdateType = "event"
+	UpdateStatus   UpdateType = "status"
+	UpdateResource UpdateType = "resource"
+)
+
+// SimulationUpdate ist eine Änderung an einer Simulation. Je nach Type ist genau
+// eines der Felder Event, Status oder Resource gesetzt.
+type SimulationUpdate struct {
+	Type         UpdateType        `json:"type"`
+	SimulationID string            `json:"simulationId"`
+	Event        *SimulationEvent  `json:"event,omitempty"`
+	Status       *SimulationStatus `json:"status,omitempty"`
+	Resource     *AffectedResource `json:"resource,omitempty"`
+}
+
+// subscriber ist ein einzelnes Abonnement auf die Updates einer Simulation
+type subscriber struct {
+	updates chan SimulationUpdate
+	closed  bool
+}
+
+// Subscribe abonniert die Updates einer Simulation: neue Events, Status- und
+// Fortschrittsänderungen sowie Änderungen betroffener Ressourcen, jeweils in der
+// Reihenfolge, in der sie auftreten.
+//
+// Updates werden nie blockierend zugestellt. Gerät ein Abonnent mehr als
+// subscriberBuffer Updates in Rückstand, wird er abgemeldet und sein Kanal
+// geschlossen; er muss den Stand dann über GetEvents und GetSimulationStatus neu
+// laden und sich erneut anmelden. Die zurückgegebene Funktion beendet das Abonnement.
+func (e *Engine) Subscribe(simulationID string) (<-chan SimulationUpdate, func(), error) {
+	e.ensureLoaded(simulationID)
+
+	e.mutex.Lock()
+	defer e.unlock()
+
+	if _, exists := e.simulations[simulationID]; !exists {
+		return nil, nil, fmt.Errorf("Simulation mit ID %s nicht gefunden", simulationID)
+	}
+
+	sub := &subscriber{updates: make(chan SimulationUpdate, subscriberBuffer)}
+	if e.subscribers[simulationID] == nil {
+		e.subscribers[simulationID] = make(map[*subscriber]bool)
+	}
+	e.subscribers[simulationID][sub] = true
+
+	cancel := func() {
+		e.mutex.Lock()
+		defer e.unlock()
+		e.unsubscribeLocked(simulationID, sub)
+	}
+	return sub.updates, cancel, nil
+}
+
+// unsubscribeLocked meldet einen Abonnenten ab und schließt seinen Kanal.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) unsubscribeLocked(simulationID string, sub *subscriber) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.updates)
+
+	delete(e.subscribers[simulationID], sub)
+	if len(e.subscribers[simulationID]) == 0 {
+		delete(e.subscribers, simulationID)
+	}
+}
+
+// publishLocked verteilt ein Update an alle Abonnenten der Simulation.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) publishLocked(update SimulationUpdate) {
+	for sub := range e.subscribers[update.SimulationID] {
+		select {
+		case sub.updates <- update:
+		default:
+			// Langsamer Abonnent: abmelden statt die Engine zu blockieren
+			logging.Logger.Warnf("Abonnent der Simulation %s zu langsam, Abonnement beendet", update.SimulationID)
+			e.unsubscribeLocked(update.SimulationID, sub)
+		}
+	}
+}
+
+// publishStatusLocked verteilt den aktuellen Status einer Simulation.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) publishStatusLocked(simulation *Simulation) {
+	if len(e.subscribers[simulation.ID]) == 0 {
+		return
+	}
+	e.publishLocked(SimulationUpdate{
+		Type:         UpdateStatus,
+		SimulationID: simulation.ID,
+		Status:       e.statusLocked(simulation),
+	})
+}
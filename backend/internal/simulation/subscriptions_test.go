@@ -0,0 +1,128 @@
+// backend/internal/simulation/subscriptions_test.go
+package simulation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribeReceivesUpdatesInOrder(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock))
+	sim, err := engine.CreateSimulation(SimulationConfig{
+		Name:       "Abonnement",
+		Parameters: map[string]interface{}{ParameterSeed: 3.0},
+	})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+
+	updates, cancel, err := engine.Subscribe(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Abonnieren: %v", err)
+	}
+	defer cancel()
+
+	var events []SimulationEvent
+	var resources int
+	var last *SimulationStatus
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range updates {
+			switch update.Type {
+			case UpdateEvent:
+				events = append(events, *update.Event)
+			case UpdateResource:
+				resources++
+			case UpdateStatus:
+				last = update.Status
+				if last.Status == StatusStopped {
+					return
+				}
+			}
+		}
+	}()
+
+	if _, err := engine.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Fehler beim Starten der Simulation: %v", err)
+	}
+	waitFor(t, "Ticker von Worker und Watchdog", func() bool { return clock.activeTickers() == 2 })
+
+	// Einige Updates durchlaufen lassen, dann stoppen
+	for step := 1; step <= 20; step++ {
+		clock.Advance(baseTickInterval)
+		waitFor(t, "Update nach Tick", func() bool {
+			engine.mutex.RLock()
+			defer engine.mutex.RUnlock()
+			return engine.simulations[sim.ID].UpdatedAt.Equal(clock.Now())
+		})
+	}
+	engine.StopSimulation(sim.ID)
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Zeitüberschreitung beim Warten auf den Stopp")
+	}
+
+	if last == nil || last.Status != StatusStopped || last.Progress == 0 {
+		t.Fatalf("Letzter Status sollte gestoppt mit Fortschritt sein, erhalten: %+v", last)
+	}
+	stoppedResources, _ := engine.GetAffectedResources(sim.ID)
+	if resources < len(stoppedResources) {
+		t.Fatalf("Erwartete Ressourcenänderungen: mindestens %d, Empfangen: %d", len(stoppedResources), resources)
+	}
+
+	// Die empfangenen Events entsprechen den gespeicherten Events
+	stored, _ := engine.GetEvents(sim.ID)
+	if len(events) != len(stored) {
+		t.Fatalf("Erwartete Events: %d, Empfangene Events: %d", len(stored), len(events))
+	}
+	for i := range stored {
+		if events[i].ID != stored[i].ID {
+			t.Fatalf("Event %d in falscher Reihenfolge", i)
+		}
+	}
+}
+
+func TestSlowSubscriberIsDisconnected(t *testing.T) {
+	engine := NewEngine()
+	sim, err := engine.CreateSimulation(SimulationConfig{Name: "Langsam"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+
+	updates, cancel, err := engine.Subscribe(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Abonnieren: %v", err)
+	}
+	defer cancel()
+
+	// Mehr Updates, als der Puffer fasst, ohne zu lesen
+	for i := 0; i <= subscriberBuffer; i++ {
+		engine.AddEvent(sim.ID, SimulationEvent{ID: "event", SimulationID: sim.ID, Type: EventTypeSystem})
+	}
+
+	received := 0
+	for range updates {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Fatalf("Erwartete Updates vor dem Abmelden: %d, Erhalten: %d", subscriberBuffer, received)
+	}
+
+	engine.mutex.RLock()
+	remaining := len(engine.subscribers[sim.ID])
+	engine.mutex.RUnlock()
+	if remaining != 0 {
+		t.Fatalf("Langsamer Abonnent sollte abgemeldet sein, verbleibend: %d", remaining)
+	}
+}
+
+func TestSubscribeUnknownSimulation(t *testing.T) {
+	engine := NewEngine()
+	if _, _, err := engine.Subscribe("unbekannt"); err == nil {
+		t.Fatal("Abonnieren einer unbekannten Simulation sollte fehlschlagen")
+	}
+}
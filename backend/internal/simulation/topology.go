@@ -0,0 +1,224 @@
+// backend/internal/simulation/topology.go
+package simulation
+
+import (
+	"fmt"
+	"sort"
+)
+
+// InfrastructureLoader lädt die Infrastrukturdefinition (nodes und connections)
+// zu einer Infrastruktur-ID im Format von GenerateMockInfrastructure
+type InfrastructureLoader func(infrastructureID string) (map[string]interface{}, error)
+
+// defaultInfrastructureLoader verwendet die stabile Mock-Infrastruktur zur ID
+func defaultInfrastructureLoader(infrastructureID string) (map[string]interface{}, error) {
+	return MockInfrastructureFor(infrastructureID), nil
+}
+
+// topologyNode ist ein Knoten des Netzwerks, den ein Angreifer erreichen kann
+type topologyNode struct {
+	ID        string
+	Name      string
+	Type      string
+	IPAddress string
+	Tags      []string
+	Controls  []DefensiveControl
+}
+
+// matches prüft, ob der Knoten ein zulässiges Ziel für den Selektor ist
+func (n *topologyNode) matches(selector *TargetSelector) bool {
+	if selector.empty() {
+		return true
+	}
+	for _, nodeType := range selector.NodeTypes {
+		if n.Type == nodeType {
+			return true
+		}
+	}
+	for _, tag := range selector.Tags {
+		for _, own := range n.Tags {
+			if own == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// topologyConnection ist eine Netzwerkverbindung zwischen zwei Knoten
+type topologyConnection struct {
+	ID       string
+	Source   string
+	Target   string
+	Protocol string
+	Ports    []string
+}
+
+// topologyEdge ist eine Verbindung aus Sicht eines Knotens
+type topologyEdge struct {
+	Connection *topologyConnection
+	Neighbor   string
+}
+
+// topology ist der Infrastrukturgraph, auf dem sich ein Angriff ausbreitet.
+// Verbindungen gelten in beide Richtungen.
+type topology struct {
+	nodes       map[string]*topologyNode
+	nodeOrder   []string // stabile Reihenfolge für reproduzierbare Zufallsentscheidungen
+	connections []*topologyConnection
+	adjacency   map[string][]topologyEdge
+}
+
+// topologyFromMap baut den Graphen aus einer Infrastrukturdefinition auf
+func topologyFromMap(infrastructure map[string]interface{}) (*topology, error) {
+	graph := &topology{
+		nodes:     make(map[string]*topologyNode),
+		adjacency: make(map[string][]topologyEdge),
+	}
+
+	for _, raw := range toMapList(infrastructure["nodes"]) {
+		node := &topologyNode{
+			ID:        toString(raw["id"]),
+			Name:      toString(raw["name"]),
+			Type:      toString(raw["type"]),
+			IPAddress: toString(raw["ipAddress"]),
+		}
+		if node.ID == "" {
+			continue
+		}
+		if node.Name == "" {
+			node.Name = node.ID
+		}
+		node.Tags = toStringList(raw["tags"])
+		if metadata, ok := raw["metadata"].(map[string]interface{}); ok && len(node.Tags) == 0 {
+			node.Tags = toStringList(metadata["tags"])
+		}
+
+		// Abwehrmaßnahmen aus der Definition oder Standardwerte je Knotentyp
+		node.Controls = controlsFromList(raw["controls"])
+		if metadata, ok := raw["metadata"].(map[string]interface{}); ok && len(node.Controls) == 0 {
+			node.Controls = controlsFromList(metadata["controls"])
+		}
+		if len(node.Controls) == 0 {
+			node.Controls = controlsFor(node.Type)
+		}
+		graph.nodes[node.ID] = node
+		graph.nodeOrder = append(graph.nodeOrder, node.ID)
+	}
+	if len(graph.nodes) == 0 {
+		return nil, fmt.Errorf("Infrastruktur enthält keine Knoten")
+	}
+
+	for _, raw := range toMapList(infrastructure["connections"]) {
+		connection := &topologyConnection{
+			ID:       toString(raw["id"]),
+			Source:   toString(raw["source"]),
+			Target:   toString(raw["target"]),
+			Protocol: toString(raw["protocol"]),
+			Ports:    toStringList(raw["ports"]),
+		}
+		if graph.nodes[connection.Source] == nil || graph.nodes[connection.Target] == nil {
+			continue
+		}
+		graph.addConnection(connection)
+	}
+	graph.sortEdges()
+
+	return graph, nil
+}
+
+// newTopology baut einen Graphen aus bereits aufbereiteten Knoten und Verbindungen auf,
+// etwa beim Fortsetzen einer Simulation aus einem Checkpoint
+func newTopology(nodes []*topologyNode, connections []*topologyConnection) (*topology, error) {
+	graph := &topology{
+		nodes:     make(map[string]*topologyNode),
+		adjacency: make(map[string][]topologyEdge),
+	}
+	for _, node := range nodes {
+		graph.nodes[node.ID] = node
+		graph.nodeOrder = append(graph.nodeOrder, node.ID)
+	}
+	if len(graph.nodes) == 0 {
+		return nil, fmt.Errorf("Infrastruktur enthält keine Knoten")
+	}
+	for _, connection := range connections {
+		if graph.nodes[connection.Source] == nil || graph.nodes[connection.Target] == nil {
+			return nil, fmt.Errorf("Verbindung %s verweist auf einen unbekannten Knoten", connection.ID)
+		}
+		graph.addConnection(connection)
+	}
+	graph.sortEdges()
+
+	return graph, nil
+}
+
+// addConnection fügt eine Verbindung in beide Richtungen hinzu
+func (t *topology) addConnection(connection *topologyConnection) {
+	t.connections = append(t.connections, connection)
+	t.adjacency[connection.Source] = append(t.adjacency[connection.Source], topologyEdge{Connection: connection, Neighbor: connection.Target})
+	t.adjacency[connection.Target] = append(t.adjacency[connection.Target], topologyEdge{Connection: connection, Neighbor: connection.Source})
+}
+
+// sortEdges ordnet die Verbindungen jedes Knotens nach Nachbar, damit Zufallsentscheidungen
+// nicht von der Reihenfolge der Definition abhängen
+func (t *topology) sortEdges() {
+	for _, edges := range t.adjacency {
+		sort.SliceStable(edges, func(i, j int) bool {
+			return edges[i].Neighbor < edges[j].Neighbor
+		})
+	}
+}
+
+// edges gibt die Verbindungen eines Knotens zurück
+func (t *topology) edges(nodeID string) []topologyEdge {
+	return t.adjacency[nodeID]
+}
+
+// nodesOfType gibt die Knoten eines Typs in stabiler Reihenfolge zurück
+func (t *topology) nodesOfType(nodeType string) []string {
+	var ids []string
+	for _, id := range t.nodeOrder {
+		if t.nodes[id].Type == nodeType {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// removeConnection entfernt eine Verbindung aus dem Graphen
+func (t *topology) removeConnection(connectionID string) bool {
+	removed := false
+	for i, connection := range t.connections {
+		if connection.ID == connectionID {
+			t.connections = append(t.connections[:i], t.connections[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	if !removed {
+		return false
+	}
+
+	for nodeID, edges := range t.adjacency {
+		kept := edges[:0]
+		for _, edge := range edges {
+			if edge.Connection.ID != connectionID {
+				kept = append(kept, edge)
+			}
+		}
+		t.adjacency[nodeID] = kept
+	}
+	return true
+}
+
+// isolate trennt alle Verbindungen eines Knotens und gibt deren Anzahl zurück
+func (t *topology) isolate(nodeID string) int {
+	var ids []string
+	for _, edge := range t.edges(nodeID) {
+		ids = append(ids, edge.Connection.ID)
+	}
+	for _, id := range ids {
+		t.removeConnection(id)
+	}
+	return len(ids)
+}
@@ -0,0 +1,63 @@
+// backend/internal/simulation/values.go
+package simulation
+
+// Hilfsfunktionen zum Auslesen untypisierter Werte aus map[string]interface{},
+// wie sie Mock-Daten und dekodiertes JSON liefern
+
+// toFloat wandelt einen numerischen Wert aus einer Map in float64 um
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	default:
+		return 0
+	}
+}
+
+// toString wandelt einen optionalen String-Wert aus einer Map um
+func toString(value interface{}) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	return ""
+}
+
+// toStringList wandelt eine Liste aus einer Map in []string um
+func toStringList(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if text, ok := item.(string); ok {
+				list = append(list, text)
+			}
+		}
+		return list
+	default:
+		return nil
+	}
+}
+
+// toMapList wandelt eine Liste von Objekten aus einer Map in []map[string]interface{} um
+func toMapList(value interface{}) []map[string]interface{} {
+	switch v := value.(type) {
+	case []map[string]interface{}:
+		return v
+	case []interface{}:
+		list := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			if entry, ok := item.(map[string]interface{}); ok {
+				list = append(list, entry)
+			}
+		}
+		return list
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,143 @@
+// backend/internal/simulation/watchdog.go
+package simulation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Kurs-24-06/aegis/backend/internal/observability/logging"
+	"github.com/google/uuid"
+)
+
+// watchdogInterval ist der Abstand, in dem der Watchdog die laufenden Simulationen prüft
+const watchdogInterval = time.Second
+
+// missedHeartbeats ist die Anzahl ausgebliebener Updates, nach der ein Worker als hängend gilt
+const missedHeartbeats = 3
+
+// minStuckThreshold verhindert, dass schnelle Simulationen schon bei kurzen Verzögerungen
+// als hängend gelten
+const minStuckThreshold = 30 * time.Second
+
+// activeRun ist ein Worker, der einen Platz im Pool belegt
+type activeRun struct {
+	simulationID  string
+	stopChan      chan struct{}
+	state         *runState
+	interval      time.Duration // reales Intervall zwischen zwei Updates, 0 bei "instant"
+	timeout       time.Duration // maximale Laufzeit, 0 für unbegrenzt
+	lastHeartbeat time.Time     // Zeitpunkt des letzten Updates
+}
+
+// stuckThreshold gibt an, wie lange ein Worker ohne Update bleiben darf
+func (r *activeRun) stuckThreshold() time.Duration {
+	threshold := missedHeartbeats * r.interval
+	if threshold < minStuckThreshold {
+		return minStuckThreshold
+	}
+	return threshold
+}
+
+// runFor gibt den aktuellen Lauf einer Simulation zurück. Der Aufrufer muss e.mutex halten.
+func (e *Engine) runFor(simulationID string) *activeRun {
+	stopChan := e.stopChannels[simulationID]
+	for run := range e.runs {
+		if run.simulationID == simulationID && run.stopChan == stopChan {
+			return run
+		}
+	}
+	return nil
+}
+
+// startWatchdogLocked startet den Watchdog, falls er nicht bereits läuft.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) startWatchdogLocked() {
+	if e.watchdogActive {
+		return
+	}
+	e.watchdogActive = true
+	go e.watchdog()
+}
+
+// watchdog beendet Simulationen, die ihre maximale Laufzeit überschreiten oder deren
+// Worker keine Updates mehr liefert. Er läuft, solange Worker einen Platz belegen.
+func (e *Engine) watchdog() {
+	ticker := e.clock.NewTicker(watchdogInterval)
+	defer ticker.Stop()
+
+	for range ticker.C() {
+		e.mutex.Lock()
+		if len(e.runs) == 0 {
+			e.watchdogActive = false
+			e.unlock()
+			return
+		}
+
+		now := e.clock.Now()
+		for run := range e.runs {
+			simulation, exists := e.simulations[run.simulationID]
+			if !exists || simulation.Status != StatusRunning || e.stopChannels[run.simulationID] != run.stopChan {
+				// Pausierte Simulationen laufen nicht ab; beendete Worker geben ihren Platz selbst frei
+				continue
+			}
+
+			switch {
+			case run.timeout > 0 && activeRuntime(simulation, now) > run.timeout:
+				e.failLocked(simulation, fmt.Sprintf("Zeitüberschreitung: maximale Laufzeit von %s überschritten", run.timeout))
+			case now.Sub(run.lastHeartbeat) > run.stuckThreshold():
+				e.failLocked(simulation, fmt.Sprintf("Worker reagiert nicht: kein Update seit %s", now.Sub(run.lastHeartbeat).Round(time.Second)))
+				// Ein hängender Worker gibt seinen Platz nicht selbst frei
+				e.releaseRunLocked(run)
+			}
+		}
+		e.unlock()
+	}
+}
+
+// failRun beendet die Simulation eines Laufs als fehlgeschlagen, sofern der Lauf noch aktuell ist
+func (e *Engine) failRun(run *activeRun, reason string) {
+	e.mutex.Lock()
+	defer e.unlock()
+
+	simulation, exists := e.simulations[run.simulationID]
+	if !exists || e.stopChannels[run.simulationID] != run.stopChan {
+		return
+	}
+	e.failLocked(simulation, reason)
+}
+
+// failLocked setzt eine Simulation auf "failed", hält ihren Worker an und vermerkt den Grund.
+// Der Aufrufer muss e.mutex halten.
+func (e *Engine) failLocked(simulation *Simulation, reason string) {
+	id := simulation.ID
+
+	if stopChan, exists := e.stopChannels[id]; exists {
+		close(stopChan)
+		delete(e.stopChannels, id)
+	}
+	delete(e.resumeChannels, id)
+
+	now := e.clock.Now()
+	if simulation.PausedAt != nil {
+		simulation.PausedDuration += now.Sub(*simulation.PausedAt)
+		simulation.PausedAt = nil
+	}
+	simulation.Status = StatusFailed
+	simulation.Error = reason
+	simulation.EndTime = &now
+	simulation.UpdatedAt = now
+
+	e.appendEventLocked(id, SimulationEvent{
+		ID:           uuid.New().String(),
+		SimulationID: id,
+		Timestamp:    now,
+		Type:         EventTypeSystem,
+		Description:  fmt.Sprintf("Simulation fehlgeschlagen: %s", reason),
+		Severity:     SeverityHigh,
+	})
+	e.deleteCheckpointLocked(id)
+	e.storeResultsLocked(simulation)
+	e.saveSimulationLocked(simulation)
+
+	logging.Logger.Errorf("Simulation '%s' (ID: %s) fehlgeschlagen: %s", simulation.Name, id, reason)
+}
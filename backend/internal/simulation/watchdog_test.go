@@ -0,0 +1,109 @@
+// backend/internal/simulation/watchdog_test.go
+package simulation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSimulationTimeout(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := NewEngine(WithClock(clock), WithWorkerPool(1, 1), WithDefaultTimeout(time.Hour))
+
+	short, err := engine.CreateSimulation(SimulationConfig{
+		Name:       "Timeout",
+		Parameters: map[string]interface{}{ParameterTimeout: 5.0},
+	})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	next, err := engine.CreateSimulation(SimulationConfig{Name: "Wartend"})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+	engine.StartSimulation(short.ID)
+	engine.StartSimulation(next.ID)
+	waitFor(t, "Ticker von Worker und Watchdog", func() bool { return clock.activeTickers() == 2 })
+
+	// Nach Ablauf der Laufzeit beendet der Watchdog die Simulation
+	clock.Advance(6 * time.Second)
+	waitFor(t, "Zeitüberschreitung", func() bool {
+		status, _ := simulationStatus(engine, short.ID)
+		return status == StatusFailed
+	})
+
+	status, err := engine.GetSimulationStatus(short.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen des Status: %v", err)
+	}
+	if !strings.Contains(status.Error, "Zeitüberschreitung") {
+		t.Fatalf("Erwarteter Grund: Zeitüberschreitung, Erhaltener Grund: %q", status.Error)
+	}
+
+	// Der frei gewordene Worker übernimmt die wartende Simulation
+	waitFor(t, "Start aus der Warteschlange", func() bool {
+		status, _ := simulationStatus(engine, next.ID)
+		return status == StatusRunning
+	})
+	engine.StopSimulation(next.ID)
+}
+
+func TestInvalidTimeoutParameter(t *testing.T) {
+	engine := NewEngine()
+	_, err := engine.CreateSimulation(SimulationConfig{
+		Name:       "Ungültig",
+		Parameters: map[string]interface{}{ParameterTimeout: -1.0},
+	})
+	if !errors.Is(err, ErrInvalidParameter) {
+		t.Fatalf("Erwarteter Fehler: %v, Erhaltener Fehler: %v", ErrInvalidParameter, err)
+	}
+}
+
+func TestPanickingWorkerFailsSimulation(t *testing.T) {
+	engine := NewEngine(WithWorkerPool(1, 0))
+
+	sim, err := engine.CreateSimulation(SimulationConfig{
+		Name:       "Absturz",
+		Parameters: map[string]interface{}{ParameterSpeed: SpeedInstant},
+	})
+	if err != nil {
+		t.Fatalf("Fehler beim Erstellen der Simulation: %v", err)
+	}
+
+	// Ein Schritt ohne Eventtypen lässt den Worker beim ersten Update abstürzen
+	engine.mutex.Lock()
+	state, err := engine.prepareRun(engine.simulations[sim.ID], sim.Seed)
+	if err != nil {
+		engine.mutex.Unlock()
+		t.Fatalf("Fehler beim Vorbereiten des Laufs: %v", err)
+	}
+	state.plan = &scenarioPlan{ID: "kaputt", Steps: []phasePlan{{Name: "Kaputt", Duration: time.Minute}}}
+	engine.scheduler.tryAcquire()
+	engine.launchLocked(engine.simulations[sim.ID], state)
+	engine.mutex.Unlock()
+
+	waitFor(t, "Fehlschlag nach Absturz", func() bool {
+		status, _ := simulationStatus(engine, sim.ID)
+		return status == StatusFailed
+	})
+
+	failed, err := engine.GetSimulation(sim.ID)
+	if err != nil {
+		t.Fatalf("Fehler beim Abrufen der Simulation: %v", err)
+	}
+	if !strings.HasPrefix(failed.Error, "Interner Fehler") {
+		t.Fatalf("Erwarteter Grund: Interner Fehler, Erhaltener Grund: %q", failed.Error)
+	}
+
+	// Der Worker-Platz ist wieder frei
+	waitFor(t, "freier Worker", func() bool {
+		engine.mutex.RLock()
+		defer engine.mutex.RUnlock()
+		return len(engine.runs) == 0 && engine.scheduler.active == 0
+	})
+	if _, err := engine.StartSimulation(sim.ID); err != nil {
+		t.Fatalf("Neustart nach Absturz fehlgeschlagen: %v", err)
+	}
+}